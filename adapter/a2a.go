@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/jonwraymond/toolfoundation/model"
 )
 
 // A2AAdapter converts between A2A AgentSkill and CanonicalTool.
@@ -70,8 +72,21 @@ type A2AAgentSkill struct {
 	InputModes           []string              `json:"inputModes,omitempty"`
 	OutputModes          []string              `json:"outputModes,omitempty"`
 	SecurityRequirements []SecurityRequirement `json:"securityRequirements,omitempty"`
+
+	// InputSchema carries the canonical input schema for round-trip
+	// conversion. A2A skills have no schema field, so it is kept in memory
+	// only and never serialized.
+	InputSchema map[string]any `json:"-"`
+
+	// SourceMeta carries SourceMeta entries of other formats (see
+	// CanonicalTool.SourceMeta) for round-trip conversion. It is kept in
+	// memory only and never serialized.
+	SourceMeta map[string]any `json:"-"`
 }
 
+// a2aSkillMetaKeys are the tool SourceMeta keys the A2A adapter owns.
+var a2aSkillMetaKeys = []string{"a2a", "skillId"}
+
 // A2AAgentInterface describes a supported protocol binding.
 type A2AAgentInterface struct {
 	URL             string `json:"url"`
@@ -81,7 +96,8 @@ type A2AAgentInterface struct {
 }
 
 // ToCanonical converts an A2A AgentSkill to the canonical format.
-// Accepts *A2AAgentSkill or A2AAgentSkill.
+// Accepts *A2AAgentSkill, A2AAgentSkill, or a decoded JSON object
+// (map[string]any) in the AgentSkill shape.
 func (a *A2AAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 	if raw == nil {
 		return nil, &ConversionError{
@@ -98,6 +114,16 @@ func (a *A2AAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 		skill = v
 	case A2AAgentSkill:
 		skill = &v
+	case map[string]any:
+		var decoded A2AAgentSkill
+		if err := decodeToolMap(v, &decoded); err != nil {
+			return nil, &ConversionError{
+				Adapter:   a.Name(),
+				Direction: "to_canonical",
+				Cause:     err,
+			}
+		}
+		return a.ToCanonical(&decoded)
 	case *A2AAgentCard:
 		return nil, &ConversionError{
 			Adapter:   a.Name(),
@@ -183,6 +209,15 @@ func (a *A2AAdapter) ToCanonicalProvider(raw any) (*CanonicalProvider, error) {
 			Cause:     errors.New("agent card supportedInterfaces is required"),
 		}
 	}
+	for i, iface := range card.SupportedInterfaces {
+		if err := validateA2AInterface(iface); err != nil {
+			return nil, &ConversionError{
+				Adapter:   a.Name(),
+				Direction: "to_canonical_provider",
+				Cause:     fmt.Errorf("supportedInterfaces[%d]: %w", i, err),
+			}
+		}
+	}
 
 	provider := &CanonicalProvider{
 		Name:                 card.Name,
@@ -233,11 +268,11 @@ func (a *A2AAdapter) FromCanonicalProvider(provider *CanonicalProvider) (*A2AAge
 		}
 	}
 
-	if provider.Name == "" || provider.Description == "" || provider.Version == "" {
+	if err := provider.Validate(); err != nil {
 		return nil, &ConversionError{
 			Adapter:   a.Name(),
 			Direction: "from_canonical_provider",
-			Cause:     errors.New("provider name, description, and version are required"),
+			Cause:     err,
 		}
 	}
 
@@ -286,6 +321,30 @@ func (a *A2AAdapter) FromCanonicalProvider(provider *CanonicalProvider) (*A2AAge
 	return card, nil
 }
 
+// ProviderFromCanonical implements ProviderAdapter. It is FromCanonicalProvider
+// with an untyped result, an *A2AAgentCard.
+func (a *A2AAdapter) ProviderFromCanonical(provider *CanonicalProvider) (any, error) {
+	card, err := a.FromCanonicalProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+// validateA2AInterface checks that an interface declares where and how to
+// reach the agent.
+func validateA2AInterface(iface A2AAgentInterface) error {
+	switch {
+	case iface.URL == "":
+		return errors.New("url is required")
+	case iface.ProtocolBinding == "":
+		return errors.New("protocolBinding is required")
+	case iface.ProtocolVersion == "":
+		return errors.New("protocolVersion is required")
+	}
+	return nil
+}
+
 func canonicalFromA2ASkill(skill *A2AAgentSkill) (*CanonicalTool, error) {
 	if skill == nil {
 		return nil, errors.New("agent skill is nil")
@@ -319,6 +378,10 @@ func canonicalFromA2ASkill(skill *A2AAgentSkill) (*CanonicalTool, error) {
 		SourceFormat:         "a2a",
 		SourceMeta:           map[string]any{"skillId": skill.ID},
 	}
+	if skill.InputSchema != nil {
+		ct.InputSchema = schemaFromMap(skill.InputSchema)
+	}
+	restoreCarriedMeta(ct, skill.SourceMeta, a2aSkillMetaKeys)
 
 	return ct, nil
 }
@@ -341,7 +404,7 @@ func a2aSkillFromCanonical(ct *CanonicalTool) (*A2AAgentSkill, error) {
 		name = ct.Name
 	}
 
-	return &A2AAgentSkill{
+	skill := &A2AAgentSkill{
 		ID:                   skillID,
 		Name:                 name,
 		Description:          ct.Description,
@@ -350,27 +413,25 @@ func a2aSkillFromCanonical(ct *CanonicalTool) (*A2AAgentSkill, error) {
 		InputModes:           ct.InputModes,
 		OutputModes:          ct.OutputModes,
 		SecurityRequirements: ct.SecurityRequirements,
-	}, nil
+		SourceMeta:           carriedMeta(ct, a2aSkillMetaKeys),
+	}
+
+	// Carry InputSchema unfiltered; it is not serialized
+	if ct.InputSchema != nil {
+		skill.InputSchema = ct.InputSchema.ToMap()
+	}
+
+	return skill, nil
 }
 
+// parseA2ASkillID splits a skill ID with model.ParseQualifiedID. IDs that
+// are not qualified tool IDs are opaque to A2A, so they become the name.
 func parseA2ASkillID(id string) (namespace, name, version string) {
-	parts := strings.Split(id, ":")
-	switch len(parts) {
-	case 1:
-		return "", id, ""
-	case 2:
-		if parts[0] == "" || parts[1] == "" {
-			return "", id, ""
-		}
-		return parts[0], parts[1], ""
-	case 3:
-		if parts[0] == "" || parts[1] == "" || parts[2] == "" {
-			return "", id, ""
-		}
-		return parts[0], parts[1], parts[2]
-	default:
+	namespace, name, version, err := model.ParseQualifiedID(id)
+	if err != nil {
 		return "", id, ""
 	}
+	return namespace, name, version
 }
 
 func skillIDFromCanonical(ct *CanonicalTool) string {
@@ -1,6 +1,12 @@
 package adapter
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jonwraymond/toolfoundation/model"
+)
 
 func TestNewA2AAdapter(t *testing.T) {
 	adapter := NewA2AAdapter()
@@ -102,6 +108,94 @@ func TestA2AAdapter_FromCanonical_UsesSourceMetaSkillID(t *testing.T) {
 	}
 }
 
+func TestA2AAdapter_InputSchemaRoundTrip(t *testing.T) {
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name:        "search",
+			Description: "Search documents",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{"type": "string", "minLength": 1},
+					"filters": map[string]any{
+						"type":  "array",
+						"items": map[string]any{"type": "string", "enum": []any{"a", "b"}},
+					},
+				},
+				"required": []any{"query"},
+			},
+		},
+		Namespace: "docs",
+	}
+
+	ct, err := NewMCPAdapter().ToCanonical(tool)
+	if err != nil {
+		t.Fatalf("MCP ToCanonical() error = %v", err)
+	}
+
+	a2a := NewA2AAdapter()
+	raw, err := a2a.FromCanonical(ct)
+	if err != nil {
+		t.Fatalf("FromCanonical() error = %v", err)
+	}
+	skill := raw.(*A2AAgentSkill)
+	if skill.InputSchema == nil {
+		t.Fatal("skill InputSchema = nil, want carried schema")
+	}
+
+	back, err := a2a.ToCanonical(skill)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	if !back.InputSchema.Equal(ct.InputSchema) {
+		t.Errorf("round-tripped InputSchema = %+v, want %+v", back.InputSchema.ToMap(), ct.InputSchema.ToMap())
+	}
+}
+
+func TestA2AAdapter_ToCanonical_Map(t *testing.T) {
+	raw := map[string]any{
+		"id":          "docs:search",
+		"name":        "Search",
+		"description": "Search docs",
+		"tags":        []any{"search"},
+	}
+
+	ct, err := NewA2AAdapter().ToCanonical(raw)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	if ct.Namespace != "docs" || ct.Name != "search" || ct.DisplayName != "Search" {
+		t.Errorf("ToCanonical() = %+v, want namespace docs, name search", ct)
+	}
+}
+
+func TestParseA2ASkillID_MatchesToolIDs(t *testing.T) {
+	for _, id := range []string{"search", "docs:search", "docs:search:1.0.0", ":search", "docs:", "docs:search:", "a:b:c:d"} {
+		t.Run(id, func(t *testing.T) {
+			namespace, name, version := parseA2ASkillID(id)
+			wantNamespace, wantName, wantVersion, err := model.ParseQualifiedID(id)
+			if err != nil {
+				// Not a qualified ID: the whole skill ID is the name.
+				wantNamespace, wantName, wantVersion = "", id, ""
+			}
+			if namespace != wantNamespace || name != wantName || version != wantVersion {
+				t.Errorf("parseA2ASkillID(%q) = (%q, %q, %q), want (%q, %q, %q)",
+					id, namespace, name, version, wantNamespace, wantName, wantVersion)
+			}
+		})
+	}
+}
+
+func TestA2AAdapter_ToCanonical_DefaultInputSchema(t *testing.T) {
+	ct, err := NewA2AAdapter().ToCanonical(&A2AAgentSkill{ID: "search", Name: "Search"})
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	if ct.InputSchema == nil || ct.InputSchema.Type != "object" {
+		t.Errorf("InputSchema = %+v, want {type: object}", ct.InputSchema)
+	}
+}
+
 func TestA2AAdapter_ProviderRoundTrip(t *testing.T) {
 	adapter := NewA2AAdapter()
 
@@ -171,3 +265,56 @@ func TestA2AAdapter_FromCanonicalProvider_MissingInterfaces(t *testing.T) {
 		t.Error("expected error for missing supportedInterfaces")
 	}
 }
+
+func TestA2AAdapter_ToCanonicalProvider_InvalidInterface(t *testing.T) {
+	valid := A2AAgentInterface{URL: "https://example.com/a2a", ProtocolBinding: "JSONRPC", ProtocolVersion: "1.0"}
+
+	tests := []struct {
+		name   string
+		mutate func(*A2AAgentInterface)
+		want   string
+	}{
+		{name: "missing url", mutate: func(i *A2AAgentInterface) { i.URL = "" }, want: "supportedInterfaces[1]: url is required"},
+		{name: "missing protocolBinding", mutate: func(i *A2AAgentInterface) { i.ProtocolBinding = "" }, want: "supportedInterfaces[1]: protocolBinding is required"},
+		{name: "missing protocolVersion", mutate: func(i *A2AAgentInterface) { i.ProtocolVersion = "" }, want: "supportedInterfaces[1]: protocolVersion is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bad := valid
+			tt.mutate(&bad)
+			card := testAgentCard()
+			card.SupportedInterfaces = []A2AAgentInterface{valid, bad}
+
+			_, err := NewA2AAdapter().ToCanonicalProvider(card)
+			convErr, ok := err.(*ConversionError)
+			if !ok {
+				t.Fatalf("ToCanonicalProvider() error = %v, want *ConversionError", err)
+			}
+			if convErr.Cause == nil || convErr.Cause.Error() != tt.want {
+				t.Errorf("Cause = %v, want %q", convErr.Cause, tt.want)
+			}
+		})
+	}
+}
+
+func TestA2AAdapter_FromCanonicalProvider_InvalidSkill(t *testing.T) {
+	_, err := NewA2AAdapter().FromCanonicalProvider(&CanonicalProvider{
+		Name:        "Agent",
+		Description: "Desc",
+		Version:     "1.0.0",
+		Skills:      []CanonicalTool{{InputSchema: &JSONSchema{Type: "object"}}},
+		SourceMeta: map[string]any{
+			"supportedInterfaces": []A2AAgentInterface{
+				{URL: "https://example.com/a2a", ProtocolBinding: "JSONRPC", ProtocolVersion: "1.0"},
+			},
+		},
+	})
+	convErr, ok := err.(*ConversionError)
+	if !ok {
+		t.Fatalf("FromCanonicalProvider() error = %v, want *ConversionError", err)
+	}
+	if want := "skill 0: tool name is required"; convErr.Cause.Error() != want {
+		t.Errorf("Cause = %v, want %q", convErr.Cause, want)
+	}
+}
@@ -3,7 +3,8 @@ package adapter
 import "fmt"
 
 // SchemaFeature represents a JSON Schema feature that may or may not be
-// supported by a particular protocol adapter.
+// supported by a particular protocol adapter. A few features, such as
+// FeatureTimeout, describe tool-level fields rather than schema keywords.
 type SchemaFeature int
 
 const (
@@ -63,38 +64,66 @@ const (
 	FeatureReadOnly
 	// FeatureWriteOnly indicates write-only properties
 	FeatureWriteOnly
+	// FeatureTimeout is the tool-level execution timeout (CanonicalTool.Timeout)
+	FeatureTimeout
+	// FeatureAdditionalPropertiesSchema is additionalProperties given as a
+	// schema that extra properties must match, rather than a boolean
+	FeatureAdditionalPropertiesSchema
+	// FeatureContains requires an array item matching a schema
+	FeatureContains
+	// FeatureMinContains is the minimum count of items matching contains
+	FeatureMinContains
+	// FeatureMaxContains is the maximum count of items matching contains
+	FeatureMaxContains
+	// FeaturePrefixItems gives a schema for each leading array position
+	FeaturePrefixItems
+	// FeatureIf is the condition of an if/then/else conditional
+	FeatureIf
+	// FeatureThen applies when the if schema matches
+	FeatureThen
+	// FeatureElse applies when the if schema does not match
+	FeatureElse
 )
 
 // featureNames maps features to their string representations
 var featureNames = map[SchemaFeature]string{
-	FeatureRef:                  "$ref",
-	FeatureDefs:                 "$defs",
-	FeatureAnyOf:                "anyOf",
-	FeatureOneOf:                "oneOf",
-	FeatureAllOf:                "allOf",
-	FeatureNot:                  "not",
-	FeaturePattern:              "pattern",
-	FeatureFormat:               "format",
-	FeatureAdditionalProperties: "additionalProperties",
-	FeatureMinimum:              "minimum",
-	FeatureMaximum:              "maximum",
-	FeatureMinLength:            "minLength",
-	FeatureMaxLength:            "maxLength",
-	FeatureEnum:                 "enum",
-	FeatureConst:                "const",
-	FeatureDefault:              "default",
-	FeatureTitle:                "title",
-	FeatureExamples:             "examples",
-	FeatureMultipleOf:           "multipleOf",
-	FeatureMinItems:             "minItems",
-	FeatureMaxItems:             "maxItems",
-	FeatureMinProperties:        "minProperties",
-	FeatureMaxProperties:        "maxProperties",
-	FeatureUniqueItems:          "uniqueItems",
-	FeatureNullable:             "nullable",
-	FeatureDeprecated:           "deprecated",
-	FeatureReadOnly:             "readOnly",
-	FeatureWriteOnly:            "writeOnly",
+	FeatureRef:                        "$ref",
+	FeatureDefs:                       "$defs",
+	FeatureAnyOf:                      "anyOf",
+	FeatureOneOf:                      "oneOf",
+	FeatureAllOf:                      "allOf",
+	FeatureNot:                        "not",
+	FeatureIf:                         "if",
+	FeatureThen:                       "then",
+	FeatureElse:                       "else",
+	FeaturePattern:                    "pattern",
+	FeatureFormat:                     "format",
+	FeatureAdditionalProperties:       "additionalProperties",
+	FeatureAdditionalPropertiesSchema: "additionalProperties:schema",
+	FeatureMinimum:                    "minimum",
+	FeatureMaximum:                    "maximum",
+	FeatureMinLength:                  "minLength",
+	FeatureMaxLength:                  "maxLength",
+	FeatureEnum:                       "enum",
+	FeatureConst:                      "const",
+	FeatureDefault:                    "default",
+	FeatureTitle:                      "title",
+	FeatureExamples:                   "examples",
+	FeatureMultipleOf:                 "multipleOf",
+	FeatureMinItems:                   "minItems",
+	FeatureMaxItems:                   "maxItems",
+	FeatureContains:                   "contains",
+	FeatureMinContains:                "minContains",
+	FeatureMaxContains:                "maxContains",
+	FeaturePrefixItems:                "prefixItems",
+	FeatureMinProperties:              "minProperties",
+	FeatureMaxProperties:              "maxProperties",
+	FeatureUniqueItems:                "uniqueItems",
+	FeatureNullable:                   "nullable",
+	FeatureDeprecated:                 "deprecated",
+	FeatureReadOnly:                   "readOnly",
+	FeatureWriteOnly:                  "writeOnly",
+	FeatureTimeout:                    "timeout",
 }
 
 // String returns the JSON Schema keyword name for this feature.
@@ -114,9 +143,13 @@ func AllFeatures() []SchemaFeature {
 		FeatureOneOf,
 		FeatureAllOf,
 		FeatureNot,
+		FeatureIf,
+		FeatureThen,
+		FeatureElse,
 		FeaturePattern,
 		FeatureFormat,
 		FeatureAdditionalProperties,
+		FeatureAdditionalPropertiesSchema,
 		FeatureMinimum,
 		FeatureMaximum,
 		FeatureMinLength,
@@ -129,6 +162,10 @@ func AllFeatures() []SchemaFeature {
 		FeatureMultipleOf,
 		FeatureMinItems,
 		FeatureMaxItems,
+		FeatureContains,
+		FeatureMinContains,
+		FeatureMaxContains,
+		FeaturePrefixItems,
 		FeatureMinProperties,
 		FeatureMaxProperties,
 		FeatureUniqueItems,
@@ -136,6 +173,7 @@ func AllFeatures() []SchemaFeature {
 		FeatureDeprecated,
 		FeatureReadOnly,
 		FeatureWriteOnly,
+		FeatureTimeout,
 	}
 }
 
@@ -187,21 +225,86 @@ func (e *ConversionError) Unwrap() error {
 	return e.Cause
 }
 
+// WarningSeverity classifies how much a lost feature changes the meaning of a tool.
+type WarningSeverity int
+
+const (
+	// SeverityInfo marks annotation-only losses (e.g., title, examples).
+	SeverityInfo WarningSeverity = iota
+	// SeverityWarning marks losses of validation keywords (e.g., pattern, minimum).
+	SeverityWarning
+	// SeverityError marks losses that change the schema structure (e.g., anyOf, $ref)
+	// or widen the accepted input (const).
+	SeverityError
+)
+
+// severityNames maps severities to their string representations
+var severityNames = map[WarningSeverity]string{
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+// String returns the lowercase name of the severity.
+func (s WarningSeverity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("WarningSeverity(%d)", s)
+}
+
+// featureSeverity returns the severity of losing a feature.
+func featureSeverity(f SchemaFeature) WarningSeverity {
+	switch f {
+	case FeatureRef, FeatureDefs, FeatureAnyOf, FeatureOneOf, FeatureAllOf, FeatureNot:
+		return SeverityError
+	case FeatureConst:
+		// Dropping const widens the schema to any value of its type.
+		return SeverityError
+	case FeatureTitle, FeatureExamples, FeatureDefault, FeatureFormat,
+		FeatureDeprecated, FeatureReadOnly, FeatureWriteOnly:
+		return SeverityInfo
+	default:
+		return SeverityWarning
+	}
+}
+
 // FeatureLossWarning indicates that a schema feature will be lost during conversion.
 // This is a warning, not an error - the conversion proceeds but with reduced fidelity.
 type FeatureLossWarning struct {
 	// Feature is the schema feature that will be lost
 	Feature SchemaFeature
 
+	// Severity classifies the impact of the loss.
+	Severity WarningSeverity
+
 	// Path is the JSON pointer path to the schema location using the feature.
 	// Empty string indicates the root schema.
 	Path string
 
+	// InOutput indicates the feature is used in the output schema rather
+	// than the input schema. Path is relative to that schema's root.
+	InOutput bool
+
 	// FromAdapter is the source adapter name
 	FromAdapter string
 
 	// ToAdapter is the target adapter name
 	ToAdapter string
+
+	// Rewritten indicates the feature was rewritten into an equivalent
+	// supported form rather than dropped.
+	Rewritten bool
+
+	// Message optionally explains the loss or rewrite.
+	Message string
+
+	// Limit names the ConvertOptions limit the tool exceeds, such as
+	// "maxDepth", "maxProperties", "maxDescriptionLength", or "toolName"
+	// for a tool name outside MCP's character set. When set, the
+	// warning reports a size limit rather than a lost feature, and Feature
+	// is not meaningful.
+	Limit string
 }
 
 // String returns a human-readable warning message.
@@ -210,6 +313,48 @@ func (w FeatureLossWarning) String() string {
 	if path == "" {
 		path = "/"
 	}
-	return fmt.Sprintf("feature %s lost converting from %s to %s at %s",
-		w.Feature, w.FromAdapter, w.ToAdapter, path)
+	verb := "lost"
+	if w.Rewritten {
+		verb = "rewritten"
+	}
+	msg := fmt.Sprintf("feature %s %s converting from %s to %s at %s",
+		w.Feature, verb, w.FromAdapter, w.ToAdapter, path)
+	switch w.Limit {
+	case "":
+	case limitToolName:
+		msg = fmt.Sprintf("tool name not allowed in MCP converting from %s to %s",
+			w.FromAdapter, w.ToAdapter)
+	default:
+		subject := "schema"
+		if w.Limit == limitMaxDescriptionLength {
+			subject = "tool"
+		}
+		msg = fmt.Sprintf("%s exceeds %s converting from %s to %s at %s",
+			subject, w.Limit, w.FromAdapter, w.ToAdapter, path)
+	}
+	if w.InOutput {
+		msg += " in output schema"
+	}
+	if w.Message != "" {
+		msg += ": " + w.Message
+	}
+	return msg
+}
+
+// PartialFeatureAdapter is an optional interface for adapters whose support
+// for a feature depends on where it is used, e.g. pattern only on string
+// schemas. The registry's loss detection prefers FeatureSupport over
+// SupportsFeature for each schema node that uses the feature.
+type PartialFeatureAdapter interface {
+	// FeatureSupport reports whether feature survives conversion on node.
+	FeatureSupport(feature SchemaFeature, node *JSONSchema) bool
+}
+
+// FeatureRewriter is an optional interface for adapters that rewrite a schema
+// feature into an equivalent form instead of dropping it. The registry reports
+// rewritten features as info-severity warnings rather than losses.
+type FeatureRewriter interface {
+	// RewriteNote describes how feature is rewritten on node, or returns ""
+	// if the adapter does not rewrite it there.
+	RewriteNote(feature SchemaFeature, node *JSONSchema) string
 }
@@ -19,6 +19,7 @@ func TestSchemaFeature_String(t *testing.T) {
 		{FeaturePattern, "pattern"},
 		{FeatureFormat, "format"},
 		{FeatureAdditionalProperties, "additionalProperties"},
+		{FeatureAdditionalPropertiesSchema, "additionalProperties:schema"},
 		{FeatureMinimum, "minimum"},
 		{FeatureMaximum, "maximum"},
 		{FeatureMinLength, "minLength"},
@@ -31,6 +32,9 @@ func TestSchemaFeature_String(t *testing.T) {
 		{FeatureMultipleOf, "multipleOf"},
 		{FeatureMinItems, "minItems"},
 		{FeatureMaxItems, "maxItems"},
+		{FeatureContains, "contains"},
+		{FeatureMinContains, "minContains"},
+		{FeatureMaxContains, "maxContains"},
 		{FeatureMinProperties, "minProperties"},
 		{FeatureMaxProperties, "maxProperties"},
 		{FeatureUniqueItems, "uniqueItems"},
@@ -38,6 +42,10 @@ func TestSchemaFeature_String(t *testing.T) {
 		{FeatureDeprecated, "deprecated"},
 		{FeatureReadOnly, "readOnly"},
 		{FeatureWriteOnly, "writeOnly"},
+		{FeaturePrefixItems, "prefixItems"},
+		{FeatureIf, "if"},
+		{FeatureThen, "then"},
+		{FeatureElse, "else"},
 	}
 
 	for _, tt := range tests {
@@ -77,6 +85,7 @@ func TestAllFeatures(t *testing.T) {
 		FeaturePattern,
 		FeatureFormat,
 		FeatureAdditionalProperties,
+		FeatureAdditionalPropertiesSchema,
 		FeatureMinimum,
 		FeatureMaximum,
 		FeatureMinLength,
@@ -89,6 +98,9 @@ func TestAllFeatures(t *testing.T) {
 		FeatureMultipleOf,
 		FeatureMinItems,
 		FeatureMaxItems,
+		FeatureContains,
+		FeatureMinContains,
+		FeatureMaxContains,
 		FeatureMinProperties,
 		FeatureMaxProperties,
 		FeatureUniqueItems,
@@ -96,6 +108,10 @@ func TestAllFeatures(t *testing.T) {
 		FeatureDeprecated,
 		FeatureReadOnly,
 		FeatureWriteOnly,
+		FeaturePrefixItems,
+		FeatureIf,
+		FeatureThen,
+		FeatureElse,
 	}
 
 	for _, known := range knownFeatures {
@@ -293,3 +309,73 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestWarningSeverity_String(t *testing.T) {
+	tests := []struct {
+		severity WarningSeverity
+		want     string
+	}{
+		{SeverityInfo, "info"},
+		{SeverityWarning, "warning"},
+		{SeverityError, "error"},
+		{WarningSeverity(99), "WarningSeverity(99)"},
+	}
+	for _, tt := range tests {
+		if got := tt.severity.String(); got != tt.want {
+			t.Errorf("WarningSeverity(%d).String() = %q, want %q", int(tt.severity), got, tt.want)
+		}
+	}
+}
+
+func TestFeatureSeverity(t *testing.T) {
+	tests := []struct {
+		feature SchemaFeature
+		want    WarningSeverity
+	}{
+		{FeatureAnyOf, SeverityError},
+		{FeatureRef, SeverityError},
+		{FeatureConst, SeverityError},
+		{FeaturePattern, SeverityWarning},
+		{FeatureMinimum, SeverityWarning},
+		{FeatureTitle, SeverityInfo},
+		{FeatureExamples, SeverityInfo},
+	}
+	for _, tt := range tests {
+		if got := featureSeverity(tt.feature); got != tt.want {
+			t.Errorf("featureSeverity(%s) = %s, want %s", tt.feature, got, tt.want)
+		}
+	}
+}
+
+func TestFeatureLossWarning_String_RewriteMessage(t *testing.T) {
+	warning := FeatureLossWarning{
+		Feature:     FeatureConst,
+		Path:        "/properties/mode",
+		FromAdapter: "mcp",
+		ToAdapter:   "gemini",
+		Rewritten:   true,
+		Message:     "emitted as single-value enum",
+	}
+
+	want := "feature const rewritten converting from mcp to gemini at /properties/mode: emitted as single-value enum"
+	if got := warning.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestFeatureLossWarning_String_InOutput(t *testing.T) {
+	warning := FeatureLossWarning{
+		Feature:     FeaturePattern,
+		Path:        "/properties/id",
+		InOutput:    true,
+		FromAdapter: "mcp",
+		ToAdapter:   "openai",
+	}
+
+	want := "feature pattern lost converting from mcp to openai at /properties/id in output schema"
+	if got := warning.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+var _ FeatureRewriter = (*GeminiAdapter)(nil)
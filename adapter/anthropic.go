@@ -14,6 +14,16 @@ type AnthropicTool struct {
 	InputSchema   map[string]any         `json:"input_schema"`
 	InputExamples []any                  `json:"input_examples,omitempty"`
 	CacheControl  *AnthropicCacheControl `json:"cache_control,omitempty"`
+
+	// OutputSchema carries the canonical output schema for round-trip
+	// conversion. Anthropic tool use has no output schema, so it is
+	// never serialized.
+	OutputSchema map[string]any `json:"-"`
+
+	// SourceMeta carries SourceMeta entries of other formats (see
+	// CanonicalTool.SourceMeta) for round-trip conversion. It is kept in
+	// memory only and never serialized.
+	SourceMeta map[string]any `json:"-"`
 }
 
 // AnthropicCacheControl for prompt caching.
@@ -29,6 +39,9 @@ func NewAnthropicAdapter() *AnthropicAdapter {
 	return &AnthropicAdapter{}
 }
 
+// anthropicMetaKeys are the SourceMeta keys the Anthropic adapter owns.
+var anthropicMetaKeys = []string{"anthropic", "cache_control", "input_examples"}
+
 // Name returns the adapter's identifier.
 func (a *AnthropicAdapter) Name() string {
 	return "anthropic"
@@ -70,7 +83,8 @@ var anthropicFeatures = map[SchemaFeature]bool{
 }
 
 // ToCanonical converts an Anthropic tool to the canonical format.
-// Accepts *AnthropicTool or AnthropicTool.
+// Accepts *AnthropicTool, AnthropicTool, or a decoded JSON object
+// (map[string]any) in the Anthropic tool shape.
 func (a *AnthropicAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 	if raw == nil {
 		return nil, &ConversionError{
@@ -87,6 +101,16 @@ func (a *AnthropicAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 		tool = v
 	case AnthropicTool:
 		tool = &v
+	case map[string]any:
+		var tool AnthropicTool
+		if err := decodeToolMap(v, &tool); err != nil {
+			return nil, &ConversionError{
+				Adapter:   a.Name(),
+				Direction: "to_canonical",
+				Cause:     err,
+			}
+		}
+		return a.ToCanonical(&tool)
 	default:
 		return nil, &ConversionError{
 			Adapter:   a.Name(),
@@ -105,16 +129,21 @@ func (a *AnthropicAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 
 	// Convert InputSchema to JSONSchema
 	inputSchema := schemaFromMap(tool.InputSchema)
+	if inputSchema == nil {
+		inputSchema = &JSONSchema{Type: "object"}
+	}
 
 	ct := &CanonicalTool{
 		Name:         tool.Name,
 		Description:  tool.Description,
 		InputSchema:  inputSchema,
+		OutputSchema: schemaFromMap(tool.OutputSchema),
 		SourceFormat: "anthropic",
 		SourceMeta:   make(map[string]any),
 	}
 
 	// Preserve Anthropic-specific fields in SourceMeta for round-trip
+	restoreCarriedMeta(ct, tool.SourceMeta, anthropicMetaKeys)
 	if tool.CacheControl != nil {
 		ct.SourceMeta["cache_control"] = tool.CacheControl
 	}
@@ -164,6 +193,13 @@ func (a *AnthropicAdapter) FromCanonical(ct *CanonicalTool) (any, error) {
 		tool.InputSchema = map[string]any{"type": "object"}
 	}
 
+	// Carry OutputSchema unfiltered; it is not sent to the API
+	if ct.OutputSchema != nil {
+		tool.OutputSchema = ct.OutputSchema.ToMap()
+	}
+
+	tool.SourceMeta = carriedMeta(ct, anthropicMetaKeys)
+
 	// Restore cache_control from SourceMeta
 	if ct.SourceMeta != nil {
 		if cc, ok := ct.SourceMeta["cache_control"].(*AnthropicCacheControl); ok {
@@ -182,6 +218,19 @@ func (a *AnthropicAdapter) FromCanonical(ct *CanonicalTool) (any, error) {
 		}
 	}
 
+	// Without preserved input_examples, rebuild them from canonical Examples:
+	// JSON examples are decoded back to values, anything else is kept as text.
+	if len(tool.InputExamples) == 0 && len(ct.Examples) > 0 {
+		tool.InputExamples = make([]any, 0, len(ct.Examples))
+		for _, example := range ct.Examples {
+			var value any
+			if err := json.Unmarshal([]byte(example), &value); err != nil {
+				value = example
+			}
+			tool.InputExamples = append(tool.InputExamples, value)
+		}
+	}
+
 	return tool, nil
 }
 
@@ -193,15 +242,18 @@ func (a *AnthropicAdapter) SupportsFeature(feature SchemaFeature) bool {
 
 // filterAnthropicSchema removes unsupported features from a schema for Anthropic.
 func filterAnthropicSchema(schema *JSONSchema) *JSONSchema {
-	if schema == nil {
-		return nil
-	}
+	return Transform(schema, anthropicNode)
+}
 
+// anthropicNode copies the fields of a single schema node that Anthropic
+// supports. Subschemas are left for Transform to filter.
+func anthropicNode(schema *JSONSchema) *JSONSchema {
 	filtered := &JSONSchema{
 		Type:        schema.Type,
 		Description: schema.Description,
 		Default:     schema.Default,
 		Const:       schema.Const,
+		HasConst:    schema.HasConst,
 	}
 
 	// Copy supported pointer fields
@@ -217,6 +269,14 @@ func filterAnthropicSchema(schema *JSONSchema) *JSONSchema {
 		v := *schema.Maximum
 		filtered.Maximum = &v
 	}
+	if schema.ExclusiveMinimum != nil {
+		v := *schema.ExclusiveMinimum
+		filtered.ExclusiveMinimum = &v
+	}
+	if schema.ExclusiveMaximum != nil {
+		v := *schema.ExclusiveMaximum
+		filtered.ExclusiveMaximum = &v
+	}
 	if schema.MinLength != nil {
 		v := *schema.MinLength
 		filtered.MinLength = &v
@@ -260,26 +320,11 @@ func filterAnthropicSchema(schema *JSONSchema) *JSONSchema {
 		copy(filtered.Enum, schema.Enum)
 	}
 
-	// Recursively filter properties
-	if schema.Properties != nil {
-		filtered.Properties = make(map[string]*JSONSchema, len(schema.Properties))
-		for k, v := range schema.Properties {
-			filtered.Properties[k] = filterAnthropicSchema(v)
-		}
-	}
-
-	// Recursively filter items
-	if schema.Items != nil {
-		filtered.Items = filterAnthropicSchema(schema.Items)
-	}
-
-	// Anthropic supports anyOf (unlike OpenAI)
-	if schema.AnyOf != nil {
-		filtered.AnyOf = make([]*JSONSchema, len(schema.AnyOf))
-		for i, v := range schema.AnyOf {
-			filtered.AnyOf[i] = filterAnthropicSchema(v)
-		}
-	}
+	// Keep supported subschemas; Transform filters them in turn.
+	// Anthropic supports anyOf (unlike OpenAI).
+	filtered.Properties = schema.Properties
+	filtered.Items = schema.Items
+	filtered.AnyOf = schema.AnyOf
 
 	// Note: Explicitly NOT copying unsupported fields:
 	// - Ref, Defs ($ref, $defs)
@@ -2,6 +2,7 @@ package adapter
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -160,6 +161,36 @@ func TestAnthropicAdapter_ToCanonical(t *testing.T) {
 	}
 }
 
+func TestAnthropicAdapter_ToCanonical_Map(t *testing.T) {
+	raw := map[string]any{
+		"name":          "search",
+		"description":   "Search things",
+		"input_schema":  map[string]any{"type": "object", "required": []any{"q"}},
+		"cache_control": map[string]any{"type": "ephemeral"},
+	}
+
+	ct, err := NewAnthropicAdapter().ToCanonical(raw)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	if ct.Name != "search" || !reflect.DeepEqual(ct.InputSchema.Required, []string{"q"}) {
+		t.Errorf("ToCanonical() = %+v, want search requiring q", ct)
+	}
+	if cc, ok := ct.SourceMeta["cache_control"].(*AnthropicCacheControl); !ok || cc.Type != "ephemeral" {
+		t.Errorf("SourceMeta[cache_control] = %v, want ephemeral", ct.SourceMeta["cache_control"])
+	}
+}
+
+func TestAnthropicAdapter_ToCanonical_NoInputSchema(t *testing.T) {
+	ct, err := NewAnthropicAdapter().ToCanonical(&AnthropicTool{Name: "ping"})
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	if ct.InputSchema == nil || ct.InputSchema.Type != "object" {
+		t.Errorf("InputSchema = %+v, want empty object schema", ct.InputSchema)
+	}
+}
+
 func TestAnthropicAdapter_ToCanonical_InputExamples(t *testing.T) {
 	adapter := NewAnthropicAdapter()
 
@@ -311,6 +342,60 @@ func TestAnthropicAdapter_FromCanonical_InputExamples(t *testing.T) {
 	}
 }
 
+func TestAnthropicAdapter_FromCanonical_ExamplesFromGemini(t *testing.T) {
+	ct, err := NewGeminiAdapter().ToCanonical(&GeminiFunctionDeclaration{
+		Name:        "search",
+		Description: "Search documents",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{"type": "string"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	// Gemini has no examples field; callers attach them on the canonical tool.
+	ct.Examples = []string{`{"query":"hello"}`, "find the latest report"}
+
+	result, err := NewAnthropicAdapter().FromCanonical(ct)
+	if err != nil {
+		t.Fatalf("FromCanonical() error = %v", err)
+	}
+
+	tool := result.(*AnthropicTool)
+	want := []any{
+		map[string]any{"query": "hello"},
+		"find the latest report",
+	}
+	if !reflect.DeepEqual(tool.InputExamples, want) {
+		t.Errorf("InputExamples = %#v, want %#v", tool.InputExamples, want)
+	}
+}
+
+func TestAnthropicAdapter_FromCanonical_SourceMetaExamplesWin(t *testing.T) {
+	ct := &CanonicalTool{
+		Name:        "example-tool",
+		InputSchema: &JSONSchema{Type: "object"},
+		Examples:    []string{`{"query":"stringified"}`},
+		SourceMeta: map[string]any{
+			"input_examples": []any{map[string]any{"query": "original"}},
+		},
+	}
+
+	result, err := NewAnthropicAdapter().FromCanonical(ct)
+	if err != nil {
+		t.Fatalf("FromCanonical() error = %v", err)
+	}
+
+	tool := result.(*AnthropicTool)
+	want := []any{map[string]any{"query": "original"}}
+	if !reflect.DeepEqual(tool.InputExamples, want) {
+		t.Errorf("InputExamples = %#v, want %#v", tool.InputExamples, want)
+	}
+}
+
 func TestAnthropicAdapter_FromCanonical_SchemaConversion(t *testing.T) {
 	adapter := NewAnthropicAdapter()
 
@@ -1,10 +1,24 @@
 package adapter
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/jonwraymond/toolfoundation/internal/canonjson"
 )
 
+// ErrInconsistentSchema is returned when a schema contradicts itself, such as
+// inverted bounds or required names that are not declared as properties.
+var ErrInconsistentSchema = errors.New("inconsistent schema")
+
 // CanonicalTool is the protocol-agnostic representation of a tool definition.
 // It serves as the intermediate format for converting between MCP, OpenAI,
 // and Anthropic tool formats.
@@ -58,6 +72,9 @@ type CanonicalTool struct {
 	SecurityRequirements []SecurityRequirement
 
 	// Annotations contains protocol-agnostic annotations for UI or policy.
+	// Adapters whose formats have no annotations field carry them through
+	// their native types in memory, like SourceMeta, so hints such as
+	// readOnlyHint survive a round trip through any format back to MCP.
 	Annotations map[string]any
 
 	// UIHints provides UI-specific hints for rendering tool inputs.
@@ -75,11 +92,70 @@ type CanonicalTool struct {
 	// SourceFormat is the original format (e.g., "mcp", "openai", "anthropic")
 	SourceFormat string
 
-	// SourceMeta contains format-specific metadata for round-trip conversion
+	// SourceMeta contains format-specific metadata for round-trip conversion.
+	// Metadata that must survive conversion through other formats is
+	// namespaced under the owning adapter's name as a map[string]any, e.g.
+	// SourceMeta["openai"]["strict"]. Adapters carry keys they do not own
+	// through their native types and merge their own keys into the carried
+	// map in ToCanonical rather than replacing it (see carriedMeta).
 	SourceMeta map[string]any
 
 	// RequiredScopes are authorization scopes needed to use the tool
 	RequiredScopes []string
+
+	// descriptionsStripped is set by ConvertOptions.StripDescriptions so
+	// that adapters do not fall back to DisplayName for a description.
+	descriptionsStripped bool
+}
+
+// carriedAnnotationsKey is the carried-meta key under which adapters whose
+// native types have no annotations field carry CanonicalTool.Annotations.
+// It never appears in SourceMeta.
+const carriedAnnotationsKey = "annotations:canonical"
+
+// carriedMeta returns the entries an adapter carries through its native type
+// for round-trip conversion: the SourceMeta entries it does not own, plus the
+// tool's Annotations, which are format-agnostic. owned lists the adapter's
+// namespace and its top-level keys. Maps are copied. Returns nil if there
+// are none.
+func carriedMeta(ct *CanonicalTool, owned []string) map[string]any {
+	carried := copyMeta(ct.SourceMeta, owned)
+	if len(ct.Annotations) > 0 {
+		if carried == nil {
+			carried = make(map[string]any)
+		}
+		carried[carriedAnnotationsKey] = maps.Clone(ct.Annotations)
+	}
+	return carried
+}
+
+// restoreCarriedMeta merges entries carried by an adapter's native type into
+// ct.SourceMeta, skipping the owned keys, which the adapter rebuilds from the
+// native fields, and restores carried Annotations.
+func restoreCarriedMeta(ct *CanonicalTool, carried map[string]any, owned []string) {
+	maps.Copy(ct.SourceMeta, copyMeta(carried, append(slices.Clip(owned), carriedAnnotationsKey)))
+	if annotations, ok := carried[carriedAnnotationsKey].(map[string]any); ok && ct.Annotations == nil {
+		ct.Annotations = maps.Clone(annotations)
+	}
+}
+
+// copyMeta returns the entries of meta whose keys are not in skip.
+// Namespace maps are copied. Returns nil if there are none.
+func copyMeta(meta map[string]any, skip []string) map[string]any {
+	var copied map[string]any
+	for key, v := range meta {
+		if slices.Contains(skip, key) {
+			continue
+		}
+		if ns, ok := v.(map[string]any); ok {
+			v = maps.Clone(ns)
+		}
+		if copied == nil {
+			copied = make(map[string]any)
+		}
+		copied[key] = v
+	}
+	return copied
 }
 
 // SecurityScheme describes a security scheme definition.
@@ -134,6 +210,17 @@ func (t *CanonicalTool) ID() string {
 	return t.Namespace + ":" + t.Name
 }
 
+// QualifiedID returns the tool's identifier in the scheme of
+// model.Tool.ToolID: "namespace:name:version" when both namespace and
+// version are set, "namespace:name" when only namespace is set, otherwise
+// just "name".
+func (t *CanonicalTool) QualifiedID() string {
+	if t.Namespace != "" && t.Version != "" {
+		return t.ID() + ":" + t.Version
+	}
+	return t.ID()
+}
+
 // Validate checks that the tool has all required fields.
 // Returns an error if Name or InputSchema is missing.
 func (t *CanonicalTool) Validate() error {
@@ -146,6 +233,45 @@ func (t *CanonicalTool) Validate() error {
 	return nil
 }
 
+// ValidateDeep runs Validate and additionally checks the input schema and,
+// when present, the output schema for internal consistency.
+// All consistency problems are returned as a single joined error.
+func (t *CanonicalTool) ValidateDeep() error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	var errs []error
+	if err := t.InputSchema.ValidateConsistency(); err != nil {
+		errs = append(errs, fmt.Errorf("input schema: %w", err))
+	}
+	if t.OutputSchema != nil {
+		if err := t.OutputSchema.ValidateConsistency(); err != nil {
+			errs = append(errs, fmt.Errorf("output schema: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks that the provider has a Name, Description, and Version and
+// that every skill passes CanonicalTool.Validate.
+func (p *CanonicalProvider) Validate() error {
+	switch {
+	case p.Name == "":
+		return errors.New("provider name is required")
+	case p.Description == "":
+		return errors.New("provider description is required")
+	case p.Version == "":
+		return errors.New("provider version is required")
+	}
+	for i := range p.Skills {
+		if err := p.Skills[i].Validate(); err != nil {
+			return fmt.Errorf("skill %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
 // JSONSchema represents a JSON Schema definition.
 // It is a superset supporting features from MCP, OpenAI, and Anthropic formats.
 type JSONSchema struct {
@@ -173,6 +299,10 @@ type JSONSchema struct {
 	// Const restricts to a single value
 	Const any
 
+	// HasConst marks Const as explicitly set, so that a const of null is
+	// distinguishable from an absent const.
+	HasConst bool
+
 	// Default is the default value
 	Default any
 
@@ -188,6 +318,12 @@ type JSONSchema struct {
 	// Maximum is the maximum numeric value
 	Maximum *float64
 
+	// ExclusiveMinimum is the exclusive lower numeric bound
+	ExclusiveMinimum *float64
+
+	// ExclusiveMaximum is the exclusive upper numeric bound
+	ExclusiveMaximum *float64
+
 	// MinLength is the minimum string length
 	MinLength *int
 
@@ -200,6 +336,18 @@ type JSONSchema struct {
 	// MaxItems is the maximum array length
 	MaxItems *int
 
+	// Contains is a schema that at least one array item must match
+	Contains *JSONSchema
+
+	// MinContains is the minimum number of items matching Contains
+	MinContains *int
+
+	// MaxContains is the maximum number of items matching Contains
+	MaxContains *int
+
+	// PrefixItems are the schemas of the leading array items, by position
+	PrefixItems []*JSONSchema
+
 	// MinProperties is the minimum number of properties
 	MinProperties *int
 
@@ -224,6 +372,11 @@ type JSONSchema struct {
 	// AdditionalProperties controls whether extra properties are allowed
 	AdditionalProperties *bool
 
+	// AdditionalPropertiesSchema is the schema extra properties must match,
+	// for additionalProperties given as an object. It takes precedence over
+	// AdditionalProperties when both are set.
+	AdditionalPropertiesSchema *JSONSchema
+
 	// Nullable indicates nullable values (OpenAPI-compatible).
 	Nullable *bool
 
@@ -247,6 +400,15 @@ type JSONSchema struct {
 
 	// Not disallows the specified schema
 	Not *JSONSchema
+
+	// If is the condition of an if/then/else conditional
+	If *JSONSchema
+
+	// Then must match when If matches
+	Then *JSONSchema
+
+	// Else must match when If does not match
+	Else *JSONSchema
 }
 
 // DeepCopy creates a deep copy of the JSONSchema.
@@ -261,6 +423,7 @@ func (s *JSONSchema) DeepCopy() *JSONSchema {
 		Title:       s.Title,
 		Description: s.Description,
 		Const:       s.Const,
+		HasConst:    s.HasConst,
 		Default:     s.Default,
 		Pattern:     s.Pattern,
 		Format:      s.Format,
@@ -280,6 +443,14 @@ func (s *JSONSchema) DeepCopy() *JSONSchema {
 		v := *s.Maximum
 		copied.Maximum = &v
 	}
+	if s.ExclusiveMinimum != nil {
+		v := *s.ExclusiveMinimum
+		copied.ExclusiveMinimum = &v
+	}
+	if s.ExclusiveMaximum != nil {
+		v := *s.ExclusiveMaximum
+		copied.ExclusiveMaximum = &v
+	}
 	if s.MinLength != nil {
 		v := *s.MinLength
 		copied.MinLength = &v
@@ -296,6 +467,14 @@ func (s *JSONSchema) DeepCopy() *JSONSchema {
 		v := *s.MaxItems
 		copied.MaxItems = &v
 	}
+	if s.MinContains != nil {
+		v := *s.MinContains
+		copied.MinContains = &v
+	}
+	if s.MaxContains != nil {
+		v := *s.MaxContains
+		copied.MaxContains = &v
+	}
 	if s.MinProperties != nil {
 		v := *s.MinProperties
 		copied.MinProperties = &v
@@ -361,6 +540,13 @@ func (s *JSONSchema) DeepCopy() *JSONSchema {
 
 	// Deep copy Items
 	copied.Items = s.Items.DeepCopy()
+	copied.Contains = s.Contains.DeepCopy()
+	if s.PrefixItems != nil {
+		copied.PrefixItems = make([]*JSONSchema, len(s.PrefixItems))
+		for i, v := range s.PrefixItems {
+			copied.PrefixItems[i] = v.DeepCopy()
+		}
+	}
 
 	// Deep copy combinators
 	if s.AnyOf != nil {
@@ -384,13 +570,553 @@ func (s *JSONSchema) DeepCopy() *JSONSchema {
 
 	// Deep copy Not
 	copied.Not = s.Not.DeepCopy()
+	copied.If = s.If.DeepCopy()
+	copied.Then = s.Then.DeepCopy()
+	copied.Else = s.Else.DeepCopy()
+	copied.AdditionalPropertiesSchema = s.AdditionalPropertiesSchema.DeepCopy()
 
 	return copied
 }
 
+// hasConst reports whether the schema sets const, including an explicit null.
+func (s *JSONSchema) hasConst() bool {
+	return s.Const != nil || s.HasConst
+}
+
+// multipleOfEpsilon is the relative tolerance MatchesMultipleOf allows, so
+// that decimal divisors such as 0.1 accept values like 0.3 despite binary
+// floating-point rounding.
+const multipleOfEpsilon = 1e-9
+
+// MatchesMultipleOf reports whether value satisfies the schema's multipleOf
+// constraint, tolerating floating-point rounding. It returns true when
+// multipleOf is unset and false when it is not positive.
+func (s *JSONSchema) MatchesMultipleOf(value float64) bool {
+	if s == nil || s.MultipleOf == nil {
+		return true
+	}
+	divisor := *s.MultipleOf
+	if !(divisor > 0) {
+		return false
+	}
+	q := value / divisor
+	return math.Abs(q-math.Round(q)) <= multipleOfEpsilon*math.Max(1, math.Abs(q))
+}
+
+// ApplyDefaults returns a copy of value with the Default of every absent
+// property filled in. Nested objects, including defaults that are themselves
+// objects, are filled recursively, as are object elements of arrays whose
+// Items schema declares properties. Defaults are deep-copied and value is
+// never mutated. A nil value is treated as an empty object. Defaults reached
+// only through $ref or combinators are not applied.
+func (s *JSONSchema) ApplyDefaults(value map[string]any) map[string]any {
+	out := make(map[string]any, len(value))
+	for k, v := range value {
+		out[k] = v
+	}
+	if s == nil {
+		return out
+	}
+
+	for name, prop := range s.Properties {
+		if prop == nil {
+			continue
+		}
+		v, present := out[name]
+		if !present {
+			if prop.Default == nil {
+				continue
+			}
+			v = copyJSONValue(prop.Default)
+		}
+		out[name] = prop.applyNestedDefaults(v)
+	}
+	return out
+}
+
+// applyNestedDefaults fills defaults inside an object or array value.
+// Other values are returned unchanged.
+func (s *JSONSchema) applyNestedDefaults(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(s.Properties) == 0 {
+			return v
+		}
+		return s.ApplyDefaults(v)
+	case []any:
+		if s.Items == nil || len(s.Items.Properties) == 0 {
+			return v
+		}
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = s.Items.applyNestedDefaults(item)
+		}
+		return items
+	default:
+		return v
+	}
+}
+
+// copyJSONValue deep-copies the maps and slices of a decoded JSON value.
+func copyJSONValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, child := range t {
+			out[k] = copyJSONValue(child)
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, child := range t {
+			out[i] = copyJSONValue(child)
+		}
+		return out
+	case []string:
+		return append([]string(nil), t...)
+	default:
+		return v
+	}
+}
+
+// ValidateConsistency checks the schema tree for contradictory constraints:
+// negative length/count bounds, minimum bounds greater than their maximum,
+// numeric bounds that leave no value (including exclusive bounds, as in
+// exclusiveMinimum >= exclusiveMaximum or minimum >= exclusiveMaximum), a
+// multipleOf that is not positive, and required names missing from
+// Properties.
+// Every problem is reported with its JSON pointer path and wraps
+// ErrInconsistentSchema. Returns nil if the receiver is nil or consistent.
+func (s *JSONSchema) ValidateConsistency() error {
+	return errors.Join(s.consistencyErrors("")...)
+}
+
+func (s *JSONSchema) consistencyErrors(path string) []error {
+	if s == nil {
+		return nil
+	}
+
+	var errs []error
+	fail := func(format string, args ...any) {
+		p := path
+		if p == "" {
+			p = "/"
+		}
+		errs = append(errs, fmt.Errorf("%w at %s: %s", ErrInconsistentSchema, p, fmt.Sprintf(format, args...)))
+	}
+
+	if s.Minimum != nil && s.Maximum != nil && *s.Minimum > *s.Maximum {
+		fail("minimum %v exceeds maximum %v", *s.Minimum, *s.Maximum)
+	}
+	// A pair with an exclusive side is empty when its bounds meet.
+	exclusiveBounds := []struct {
+		minName, maxName string
+		min, max         *float64
+	}{
+		{"exclusiveMinimum", "exclusiveMaximum", s.ExclusiveMinimum, s.ExclusiveMaximum},
+		{"minimum", "exclusiveMaximum", s.Minimum, s.ExclusiveMaximum},
+		{"exclusiveMinimum", "maximum", s.ExclusiveMinimum, s.Maximum},
+	}
+	for _, b := range exclusiveBounds {
+		if b.min != nil && b.max != nil && *b.min >= *b.max {
+			fail("%s %v must be less than %s %v", b.minName, *b.min, b.maxName, *b.max)
+		}
+	}
+	if s.MultipleOf != nil && !(*s.MultipleOf > 0) {
+		fail("multipleOf %v must be greater than 0", *s.MultipleOf)
+	}
+
+	bounds := []struct {
+		name     string
+		min, max *int
+	}{
+		{"Length", s.MinLength, s.MaxLength},
+		{"Items", s.MinItems, s.MaxItems},
+		{"Contains", s.MinContains, s.MaxContains},
+		{"Properties", s.MinProperties, s.MaxProperties},
+	}
+	for _, b := range bounds {
+		if b.min != nil && *b.min < 0 {
+			fail("min%s %d is negative", b.name, *b.min)
+		}
+		if b.max != nil && *b.max < 0 {
+			fail("max%s %d is negative", b.name, *b.max)
+		}
+		if b.min != nil && b.max != nil && *b.min > *b.max {
+			fail("min%s %d exceeds max%s %d", b.name, *b.min, b.name, *b.max)
+		}
+	}
+
+	for _, name := range s.Required {
+		if _, ok := s.Properties[name]; !ok {
+			fail("required property %q is not defined", name)
+		}
+	}
+
+	for _, name := range sortedKeys(s.Properties) {
+		errs = append(errs, s.Properties[name].consistencyErrors(joinJSONPath(path, "properties", name))...)
+	}
+	for _, name := range sortedKeys(s.Defs) {
+		errs = append(errs, s.Defs[name].consistencyErrors(joinJSONPath(path, "$defs", name))...)
+	}
+	errs = append(errs, s.Items.consistencyErrors(joinJSONPath(path, "items"))...)
+	for i, sub := range s.PrefixItems {
+		errs = append(errs, sub.consistencyErrors(joinJSONPath(path, "prefixItems", indexPath(i)))...)
+	}
+	errs = append(errs, s.Contains.consistencyErrors(joinJSONPath(path, "contains"))...)
+	for i, sub := range s.AnyOf {
+		errs = append(errs, sub.consistencyErrors(joinJSONPath(path, "anyOf", indexPath(i)))...)
+	}
+	for i, sub := range s.OneOf {
+		errs = append(errs, sub.consistencyErrors(joinJSONPath(path, "oneOf", indexPath(i)))...)
+	}
+	for i, sub := range s.AllOf {
+		errs = append(errs, sub.consistencyErrors(joinJSONPath(path, "allOf", indexPath(i)))...)
+	}
+	errs = append(errs, s.Not.consistencyErrors(joinJSONPath(path, "not"))...)
+	errs = append(errs, s.If.consistencyErrors(joinJSONPath(path, "if"))...)
+	errs = append(errs, s.Then.consistencyErrors(joinJSONPath(path, "then"))...)
+	errs = append(errs, s.Else.consistencyErrors(joinJSONPath(path, "else"))...)
+	errs = append(errs, s.AdditionalPropertiesSchema.consistencyErrors(joinJSONPath(path, "additionalProperties"))...)
+
+	return errs
+}
+
+// Walk calls visit for s and every nested schema (properties, items,
+// prefixItems, contains, $defs, anyOf, oneOf, allOf, not, if, then, else,
+// and additionalProperties) in depth-first order, passing each node's JSON
+// Pointer path relative to s. The root has the empty path. Properties and
+// definitions are visited in sorted key order.
+func Walk(s *JSONSchema, visit func(path string, node *JSONSchema)) {
+	walkSchema(s, "", visit)
+}
+
+// Transform builds a new schema tree by applying fn to s and each nested
+// schema, top down: fn receives a node and returns its replacement, and
+// Transform then recurses into the subschemas of the replacement. fn may
+// therefore drop a subschema field to prune that branch. A nil result
+// removes the node: properties and definitions are deleted and combinator
+// branches are dropped.
+//
+// fn must not modify its argument. Transform copies each result before
+// replacing its subschemas, so returning the node unchanged is safe and
+// leaves s untouched.
+func Transform(s *JSONSchema, fn func(*JSONSchema) *JSONSchema) *JSONSchema {
+	if s == nil {
+		return nil
+	}
+	out := fn(s)
+	if out == nil {
+		return nil
+	}
+
+	node := *out
+	node.Properties = transformMap(out.Properties, fn)
+	node.Items = Transform(out.Items, fn)
+	node.PrefixItems = transformList(out.PrefixItems, fn)
+	node.Contains = Transform(out.Contains, fn)
+	node.Defs = transformMap(out.Defs, fn)
+	node.AnyOf = transformList(out.AnyOf, fn)
+	node.OneOf = transformList(out.OneOf, fn)
+	node.AllOf = transformList(out.AllOf, fn)
+	node.Not = Transform(out.Not, fn)
+	node.If = Transform(out.If, fn)
+	node.Then = Transform(out.Then, fn)
+	node.Else = Transform(out.Else, fn)
+	node.AdditionalPropertiesSchema = Transform(out.AdditionalPropertiesSchema, fn)
+	return &node
+}
+
+// transformMap applies Transform to each schema in m, preserving a non-nil
+// empty map.
+func transformMap(m map[string]*JSONSchema, fn func(*JSONSchema) *JSONSchema) map[string]*JSONSchema {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]*JSONSchema, len(m))
+	for k, v := range m {
+		if t := Transform(v, fn); t != nil {
+			out[k] = t
+		}
+	}
+	return out
+}
+
+// transformList applies Transform to each schema in list, preserving a
+// non-nil empty slice.
+func transformList(list []*JSONSchema, fn func(*JSONSchema) *JSONSchema) []*JSONSchema {
+	if list == nil {
+		return nil
+	}
+	out := make([]*JSONSchema, 0, len(list))
+	for _, v := range list {
+		if t := Transform(v, fn); t != nil {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// walkSchema calls visit for the schema and every nested schema in
+// depth-first order, passing each node's JSON pointer path.
+func walkSchema(s *JSONSchema, path string, visit func(path string, node *JSONSchema)) {
+	walkSchemaDepth(s, path, 1, func(path string, _ int, node *JSONSchema) {
+		visit(path, node)
+	})
+}
+
+// walkSchemaDepth is walkSchema that also passes each node's nesting depth,
+// counting s as depth.
+func walkSchemaDepth(s *JSONSchema, path string, depth int, visit func(path string, depth int, node *JSONSchema)) {
+	if s == nil {
+		return
+	}
+	visit(path, depth, s)
+
+	next := depth + 1
+	for _, name := range sortedKeys(s.Properties) {
+		walkSchemaDepth(s.Properties[name], joinJSONPath(path, "properties", name), next, visit)
+	}
+	walkSchemaDepth(s.Items, joinJSONPath(path, "items"), next, visit)
+	for i, sub := range s.PrefixItems {
+		walkSchemaDepth(sub, joinJSONPath(path, "prefixItems", indexPath(i)), next, visit)
+	}
+	walkSchemaDepth(s.Contains, joinJSONPath(path, "contains"), next, visit)
+	for _, name := range sortedKeys(s.Defs) {
+		walkSchemaDepth(s.Defs[name], joinJSONPath(path, "$defs", name), next, visit)
+	}
+	for i, sub := range s.AnyOf {
+		walkSchemaDepth(sub, joinJSONPath(path, "anyOf", indexPath(i)), next, visit)
+	}
+	for i, sub := range s.OneOf {
+		walkSchemaDepth(sub, joinJSONPath(path, "oneOf", indexPath(i)), next, visit)
+	}
+	for i, sub := range s.AllOf {
+		walkSchemaDepth(sub, joinJSONPath(path, "allOf", indexPath(i)), next, visit)
+	}
+	walkSchemaDepth(s.Not, joinJSONPath(path, "not"), next, visit)
+	walkSchemaDepth(s.If, joinJSONPath(path, "if"), next, visit)
+	walkSchemaDepth(s.Then, joinJSONPath(path, "then"), next, visit)
+	walkSchemaDepth(s.Else, joinJSONPath(path, "else"), next, visit)
+	walkSchemaDepth(s.AdditionalPropertiesSchema, joinJSONPath(path, "additionalProperties"), next, visit)
+}
+
+// Depth returns the nesting depth of the schema: 1 for a schema without
+// subschemas, plus one for each level of properties, items, prefixItems,
+// contains, $defs, combinators, not, if/then/else, or additionalProperties.
+// Returns 0 if the receiver is nil.
+func (s *JSONSchema) Depth() int {
+	deepest := 0
+	walkSchemaDepth(s, "", 1, func(_ string, depth int, _ *JSONSchema) {
+		deepest = max(deepest, depth)
+	})
+	return deepest
+}
+
+// PropertyCount returns the number of properties declared anywhere in the
+// schema tree, including nested objects and definitions.
+func (s *JSONSchema) PropertyCount() int {
+	count := 0
+	walkSchema(s, "", func(_ string, node *JSONSchema) {
+		count += len(node.Properties)
+	})
+	return count
+}
+
+// FeaturesUsed counts how many schemas in the tree use each feature,
+// independent of any target format. Only features with a non-zero count
+// are present in the result. Compare the keys against an adapter's
+// SupportsFeature to see what a conversion would drop.
+func (s *JSONSchema) FeaturesUsed() map[SchemaFeature]int {
+	used := make(map[SchemaFeature]int)
+	walkSchema(s, "", func(_ string, node *JSONSchema) {
+		for feature, present := range nodeFeatures(node) {
+			if present {
+				used[feature]++
+			}
+		}
+	})
+	return used
+}
+
+// DetectCycles returns the $ref values that take part in a reference cycle
+// among the root schema ("#") and its $defs ("#/$defs/name"), sorted and
+// without duplicates. A definition that refers to itself, directly or
+// through other definitions, is cyclic. Transforms that follow $ref, such
+// as inlining, must consult it to bound recursion. Refs to anything else
+// are ignored. Returns nil if there are no cycles.
+func (s *JSONSchema) DetectCycles() []string {
+	if s == nil {
+		return nil
+	}
+
+	// Nodes are "" for the root schema and the names of its definitions.
+	type edge struct{ to, ref string }
+	edges := make(map[string][]edge)
+	collect := func(from string, node *JSONSchema) {
+		walkSchema(node, "", func(_ string, n *JSONSchema) {
+			if to, ok := s.refTarget(n.Ref); ok {
+				edges[from] = append(edges[from], edge{to: to, ref: n.Ref})
+			}
+		})
+	}
+	root := *s
+	root.Defs = nil
+	collect("", &root)
+	names := sortedKeys(s.Defs)
+	for _, name := range names {
+		collect(name, s.Defs[name])
+	}
+
+	// Tarjan's algorithm: nodes sharing a component with more than one
+	// member, or with a self-edge, lie on a cycle.
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	component := make(map[string]int)
+	var stack []string
+	var sizes []int
+	var visit func(node string)
+	visit = func(node string) {
+		index[node] = len(index)
+		low[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+		for _, e := range edges[node] {
+			if _, seen := index[e.to]; !seen {
+				visit(e.to)
+				low[node] = min(low[node], low[e.to])
+			} else if onStack[e.to] {
+				low[node] = min(low[node], index[e.to])
+			}
+		}
+		if low[node] != index[node] {
+			return
+		}
+		id := len(sizes)
+		sizes = append(sizes, 0)
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component[top] = id
+			sizes[id]++
+			if top == node {
+				break
+			}
+		}
+	}
+	for _, node := range append([]string{""}, names...) {
+		if _, seen := index[node]; !seen {
+			visit(node)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var refs []string
+	for from, list := range edges {
+		for _, e := range list {
+			if component[from] != component[e.to] || (sizes[component[from]] == 1 && from != e.to) {
+				continue
+			}
+			if !seen[e.ref] {
+				seen[e.ref] = true
+				refs = append(refs, e.ref)
+			}
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// refTarget resolves a local $ref to the root ("") or a definition name.
+func (s *JSONSchema) refTarget(ref string) (string, bool) {
+	if ref == "#" {
+		return "", true
+	}
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok || strings.Contains(name, "/") {
+		return "", false
+	}
+	name = strings.ReplaceAll(strings.ReplaceAll(name, "~1", "/"), "~0", "~")
+	if _, exists := s.Defs[name]; !exists {
+		return "", false
+	}
+	return name, true
+}
+
+// sortedKeys returns the keys of a schema map in sorted order.
+func sortedKeys(m map[string]*JSONSchema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CanonicalJSON returns the schema encoded as canonical JSON: the ToMap
+// form with object keys, including property names, in sorted order at every
+// depth. Equal schemas always produce identical bytes.
+func (s *JSONSchema) CanonicalJSON() ([]byte, error) {
+	return canonjson.Marshal(s.ToMap())
+}
+
+// MarshalJSON encodes the schema as JSON Schema in its CanonicalJSON form,
+// so json.Marshal output is byte-identical across runs.
+func (s *JSONSchema) MarshalJSON() ([]byte, error) {
+	return s.CanonicalJSON()
+}
+
+// UnmarshalJSON decodes a JSON Schema object as SchemaFromJSON does.
+func (s *JSONSchema) UnmarshalJSON(data []byte) error {
+	decoded, err := SchemaFromJSON(data)
+	if err != nil {
+		return err
+	}
+	*s = *decoded
+	return nil
+}
+
+// SchemaFromJSON decodes a JSON Schema object. Both 2020-12 and draft-07
+// spellings are accepted: definitions is read into Defs, $ref pointers into
+// definitions are rewritten to #/$defs/, and boolean exclusiveMinimum or
+// exclusiveMaximum flags become the numeric exclusive bounds.
+func SchemaFromJSON(data []byte) (*JSONSchema, error) {
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("decode schema: %w", err)
+	}
+	if m == nil {
+		return nil, errors.New("decode schema: schema must be a JSON object")
+	}
+	return schemaFromMap(m), nil
+}
+
+// Equal reports whether s and other have the same canonical JSON encoding.
+func (s *JSONSchema) Equal(other *JSONSchema) bool {
+	a, errA := s.CanonicalJSON()
+	b, errB := other.CanonicalJSON()
+	if errA != nil || errB != nil {
+		return false
+	}
+	return bytes.Equal(a, b)
+}
+
 // ToMap converts the JSONSchema to a map[string]any representation.
-// Zero-valued fields are omitted from the output.
+// Zero-valued fields are omitted from the output. Go maps are unordered;
+// use CanonicalJSON or json.Marshal for stable serialized output.
 func (s *JSONSchema) ToMap() map[string]any {
+	return s.ToMapForDraft(Draft202012)
+}
+
+// ToMapForDraft converts the JSONSchema to a map[string]any using the
+// keyword spellings of the given draft. For Draft07, $defs is written as
+// definitions, $ref pointers into $defs are rewritten to match, the
+// exclusive bounds become boolean exclusiveMinimum/exclusiveMaximum flags
+// on minimum/maximum, and prefixItems becomes an items array with items as
+// additionalItems.
+func (s *JSONSchema) ToMapForDraft(draft SchemaDraft) map[string]any {
 	if s == nil {
 		return nil
 	}
@@ -414,11 +1140,11 @@ func (s *JSONSchema) ToMap() map[string]any {
 		m["format"] = s.Format
 	}
 	if s.Ref != "" {
-		m["$ref"] = s.Ref
+		m["$ref"] = draftRef(s.Ref, draft)
 	}
 
 	// Any fields
-	if s.Const != nil {
+	if s.hasConst() {
 		m["const"] = s.Const
 	}
 	if s.Default != nil {
@@ -438,6 +1164,17 @@ func (s *JSONSchema) ToMap() map[string]any {
 	if s.Maximum != nil {
 		m["maximum"] = *s.Maximum
 	}
+	if draft == Draft07 {
+		setDraft07Bound(m, "minimum", "exclusiveMinimum", s.ExclusiveMinimum, func(a, b float64) bool { return a >= b })
+		setDraft07Bound(m, "maximum", "exclusiveMaximum", s.ExclusiveMaximum, func(a, b float64) bool { return a <= b })
+	} else {
+		if s.ExclusiveMinimum != nil {
+			m["exclusiveMinimum"] = *s.ExclusiveMinimum
+		}
+		if s.ExclusiveMaximum != nil {
+			m["exclusiveMaximum"] = *s.ExclusiveMaximum
+		}
+	}
 	if s.MinLength != nil {
 		m["minLength"] = *s.MinLength
 	}
@@ -450,6 +1187,12 @@ func (s *JSONSchema) ToMap() map[string]any {
 	if s.MaxItems != nil {
 		m["maxItems"] = *s.MaxItems
 	}
+	if s.MinContains != nil {
+		m["minContains"] = *s.MinContains
+	}
+	if s.MaxContains != nil {
+		m["maxContains"] = *s.MaxContains
+	}
 	if s.MinProperties != nil {
 		m["minProperties"] = *s.MinProperties
 	}
@@ -487,7 +1230,7 @@ func (s *JSONSchema) ToMap() map[string]any {
 	if len(s.Properties) > 0 {
 		props := make(map[string]any, len(s.Properties))
 		for k, v := range s.Properties {
-			props[k] = v.ToMap()
+			props[k] = v.ToMapForDraft(draft)
 		}
 		m["properties"] = props
 	}
@@ -496,41 +1239,98 @@ func (s *JSONSchema) ToMap() map[string]any {
 	if len(s.Defs) > 0 {
 		defs := make(map[string]any, len(s.Defs))
 		for k, v := range s.Defs {
-			defs[k] = v.ToMap()
+			defs[k] = v.ToMapForDraft(draft)
 		}
-		m["$defs"] = defs
+		m[draftDefsKeyword(draft)] = defs
 	}
 
 	// Items
+	itemsKeyword := "items"
+	if len(s.PrefixItems) > 0 {
+		prefixKeyword := "prefixItems"
+		if draft == Draft07 {
+			prefixKeyword, itemsKeyword = "items", "additionalItems"
+		}
+		prefixItems := make([]any, len(s.PrefixItems))
+		for i, v := range s.PrefixItems {
+			prefixItems[i] = v.ToMapForDraft(draft)
+		}
+		m[prefixKeyword] = prefixItems
+	}
 	if s.Items != nil {
-		m["items"] = s.Items.ToMap()
+		m[itemsKeyword] = s.Items.ToMapForDraft(draft)
+	}
+	if s.Contains != nil {
+		m["contains"] = s.Contains.ToMapForDraft(draft)
 	}
 
 	// Combinators
 	if len(s.AnyOf) > 0 {
 		anyOf := make([]any, len(s.AnyOf))
 		for i, v := range s.AnyOf {
-			anyOf[i] = v.ToMap()
+			anyOf[i] = v.ToMapForDraft(draft)
 		}
 		m["anyOf"] = anyOf
 	}
 	if len(s.OneOf) > 0 {
 		oneOf := make([]any, len(s.OneOf))
 		for i, v := range s.OneOf {
-			oneOf[i] = v.ToMap()
+			oneOf[i] = v.ToMapForDraft(draft)
 		}
 		m["oneOf"] = oneOf
 	}
 	if len(s.AllOf) > 0 {
 		allOf := make([]any, len(s.AllOf))
 		for i, v := range s.AllOf {
-			allOf[i] = v.ToMap()
+			allOf[i] = v.ToMapForDraft(draft)
 		}
 		m["allOf"] = allOf
 	}
 	if s.Not != nil {
-		m["not"] = s.Not.ToMap()
+		m["not"] = s.Not.ToMapForDraft(draft)
+	}
+	if s.If != nil {
+		m["if"] = s.If.ToMapForDraft(draft)
+	}
+	if s.Then != nil {
+		m["then"] = s.Then.ToMapForDraft(draft)
+	}
+	if s.Else != nil {
+		m["else"] = s.Else.ToMapForDraft(draft)
+	}
+	if s.AdditionalPropertiesSchema != nil {
+		m["additionalProperties"] = s.AdditionalPropertiesSchema.ToMapForDraft(draft)
 	}
 
 	return m
 }
+
+// draftDefsKeyword returns the keyword holding schema definitions in draft.
+func draftDefsKeyword(draft SchemaDraft) string {
+	if draft == Draft07 {
+		return "definitions"
+	}
+	return "$defs"
+}
+
+// draftRef rewrites a local pointer into $defs to the draft's spelling.
+func draftRef(ref string, draft SchemaDraft) string {
+	if draft == Draft07 && strings.HasPrefix(ref, "#/$defs/") {
+		return "#/definitions/" + strings.TrimPrefix(ref, "#/$defs/")
+	}
+	return ref
+}
+
+// setDraft07Bound folds an exclusive bound into its inclusive keyword using
+// the draft-07 boolean flag. When both bounds are set, the tighter one wins;
+// tighter reports whether the exclusive bound is at least as strict.
+func setDraft07Bound(m map[string]any, keyword, flag string, exclusive *float64, tighter func(a, b float64) bool) {
+	if exclusive == nil {
+		return
+	}
+	if inclusive, ok := m[keyword].(float64); ok && !tighter(*exclusive, inclusive) {
+		return
+	}
+	m[keyword] = *exclusive
+	m[flag] = true
+}
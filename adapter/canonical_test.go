@@ -1,9 +1,16 @@
 package adapter
 
 import (
+	"encoding/json"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jonwraymond/toolfoundation/model"
 )
 
 func TestCanonicalTool_ID_WithNamespace(t *testing.T) {
@@ -33,6 +40,31 @@ func TestCanonicalTool_ID_WithoutNamespace(t *testing.T) {
 	}
 }
 
+func TestCanonicalTool_QualifiedID(t *testing.T) {
+	tests := []struct {
+		namespace, name, version string
+		want                     string
+	}{
+		{namespace: "myns", name: "mytool", version: "1.2.0", want: "myns:mytool:1.2.0"},
+		{namespace: "myns", name: "mytool", want: "myns:mytool"},
+		{name: "mytool", version: "1.2.0", want: "mytool"},
+		{name: "mytool", want: "mytool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			tool := &CanonicalTool{Namespace: tt.namespace, Name: tt.name, Version: tt.version}
+			if got := tool.QualifiedID(); got != tt.want {
+				t.Errorf("QualifiedID() = %q, want %q", got, tt.want)
+			}
+			mt := &model.Tool{Tool: mcp.Tool{Name: tt.name}, Namespace: tt.namespace, Version: tt.version}
+			if got, want := tool.QualifiedID(), mt.ToolID(); got != want {
+				t.Errorf("QualifiedID() = %q, want model ToolID %q", got, want)
+			}
+		})
+	}
+}
+
 func TestCanonicalTool_Validate_Valid(t *testing.T) {
 	tool := &CanonicalTool{
 		Name: "mytool",
@@ -71,6 +103,659 @@ func TestCanonicalTool_Validate_MissingInputSchema(t *testing.T) {
 	}
 }
 
+func TestCanonicalProvider_Validate(t *testing.T) {
+	skill := CanonicalTool{Name: "search", InputSchema: &JSONSchema{Type: "object"}}
+
+	tests := []struct {
+		name     string
+		provider CanonicalProvider
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			provider: CanonicalProvider{Name: "Agent", Description: "Desc", Version: "1.0.0", Skills: []CanonicalTool{skill}},
+		},
+		{
+			name:     "missing name",
+			provider: CanonicalProvider{Description: "Desc", Version: "1.0.0"},
+			wantErr:  "provider name is required",
+		},
+		{
+			name:     "missing description",
+			provider: CanonicalProvider{Name: "Agent", Version: "1.0.0"},
+			wantErr:  "provider description is required",
+		},
+		{
+			name:     "missing version",
+			provider: CanonicalProvider{Name: "Agent", Description: "Desc"},
+			wantErr:  "provider version is required",
+		},
+		{
+			name: "invalid skill",
+			provider: CanonicalProvider{
+				Name: "Agent", Description: "Desc", Version: "1.0.0",
+				Skills: []CanonicalTool{skill, {Name: "broken"}},
+			},
+			wantErr: "skill 1: tool input schema is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.provider.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCanonicalTool_ValidateDeep_InconsistentInputSchema(t *testing.T) {
+	minLen, maxLen := 10, 2
+	tool := &CanonicalTool{
+		Name: "mytool",
+		InputSchema: &JSONSchema{
+			Type: "object",
+			Properties: map[string]*JSONSchema{
+				"name": {Type: "string", MinLength: &minLen, MaxLength: &maxLen},
+			},
+			Required: []string{"name", "missing"},
+		},
+	}
+
+	if err := tool.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+
+	err := tool.ValidateDeep()
+	if err == nil {
+		t.Fatal("ValidateDeep() = nil, want error for inconsistent schema")
+	}
+	if !errors.Is(err, ErrInconsistentSchema) {
+		t.Errorf("ValidateDeep() error = %v, want ErrInconsistentSchema", err)
+	}
+	msg := err.Error()
+	for _, want := range []string{"/properties/name", "minLength 10 exceeds maxLength 2", `"missing"`} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("ValidateDeep() error = %q, want it to contain %q", msg, want)
+		}
+	}
+}
+
+func TestCanonicalTool_ValidateDeep_OutputSchema(t *testing.T) {
+	lo, hi := 5.0, 1.0
+	tool := &CanonicalTool{
+		Name:         "mytool",
+		InputSchema:  &JSONSchema{Type: "object"},
+		OutputSchema: &JSONSchema{Type: "number", Minimum: &lo, Maximum: &hi},
+	}
+
+	err := tool.ValidateDeep()
+	if err == nil {
+		t.Fatal("ValidateDeep() = nil, want error for inverted output bounds")
+	}
+	if !strings.Contains(err.Error(), "output schema") {
+		t.Errorf("ValidateDeep() error = %q, want output schema prefix", err)
+	}
+}
+
+func TestCanonicalTool_ValidateDeep_Valid(t *testing.T) {
+	minLen, maxLen := 1, 10
+	tool := &CanonicalTool{
+		Name: "mytool",
+		InputSchema: &JSONSchema{
+			Type: "object",
+			Properties: map[string]*JSONSchema{
+				"name": {Type: "string", MinLength: &minLen, MaxLength: &maxLen},
+			},
+			Required: []string{"name"},
+		},
+	}
+
+	if err := tool.ValidateDeep(); err != nil {
+		t.Errorf("ValidateDeep() = %v, want nil", err)
+	}
+}
+
+func TestCanonicalTool_ValidateDeep_RunsShallowChecks(t *testing.T) {
+	tool := &CanonicalTool{Name: "mytool"}
+
+	if err := tool.ValidateDeep(); err == nil {
+		t.Error("ValidateDeep() = nil, want error for missing input schema")
+	}
+}
+
+func TestJSONSchema_ValidateConsistency_Nested(t *testing.T) {
+	neg := -1
+	schema := &JSONSchema{
+		Type: "object",
+		Properties: map[string]*JSONSchema{
+			"list": {
+				Type:  "array",
+				Items: &JSONSchema{Type: "string", MinLength: &neg},
+			},
+		},
+		AnyOf: []*JSONSchema{{Type: "object", Required: []string{"x"}}},
+	}
+
+	err := schema.ValidateConsistency()
+	if err == nil {
+		t.Fatal("ValidateConsistency() = nil, want error")
+	}
+	msg := err.Error()
+	for _, want := range []string{"/properties/list/items: minLength -1 is negative", "/anyOf/0: required property \"x\""} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("ValidateConsistency() error = %q, want it to contain %q", msg, want)
+		}
+	}
+}
+
+func TestJSONSchema_ValidateConsistency_NumericBounds(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *JSONSchema
+		want   string
+	}{
+		{
+			name:   "inclusive bounds may meet",
+			schema: &JSONSchema{Type: "number", Minimum: floatPtr(5), Maximum: floatPtr(5)},
+		},
+		{
+			name:   "exclusive bounds meet",
+			schema: &JSONSchema{Type: "number", ExclusiveMinimum: floatPtr(5), ExclusiveMaximum: floatPtr(5)},
+			want:   "exclusiveMinimum 5 must be less than exclusiveMaximum 5",
+		},
+		{
+			name:   "exclusive bounds inverted",
+			schema: &JSONSchema{Type: "number", ExclusiveMinimum: floatPtr(9), ExclusiveMaximum: floatPtr(1)},
+			want:   "exclusiveMinimum 9 must be less than exclusiveMaximum 1",
+		},
+		{
+			name:   "minimum meets exclusiveMaximum",
+			schema: &JSONSchema{Type: "number", Minimum: floatPtr(3), ExclusiveMaximum: floatPtr(3)},
+			want:   "minimum 3 must be less than exclusiveMaximum 3",
+		},
+		{
+			name:   "exclusiveMinimum above maximum",
+			schema: &JSONSchema{Type: "number", ExclusiveMinimum: floatPtr(4), Maximum: floatPtr(2)},
+			want:   "exclusiveMinimum 4 must be less than maximum 2",
+		},
+		{
+			name:   "mixed bounds with room",
+			schema: &JSONSchema{Type: "number", Minimum: floatPtr(0), ExclusiveMaximum: floatPtr(1), ExclusiveMinimum: floatPtr(-1), Maximum: floatPtr(2)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schema.ValidateConsistency()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("ValidateConsistency() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInconsistentSchema) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ValidateConsistency() = %v, want ErrInconsistentSchema containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONSchema_ValidateConsistency_MultipleOf(t *testing.T) {
+	for _, m := range []float64{0, -2} {
+		schema := &JSONSchema{Type: "number", MultipleOf: &m}
+		err := schema.ValidateConsistency()
+		if !errors.Is(err, ErrInconsistentSchema) {
+			t.Errorf("ValidateConsistency() with multipleOf %v = %v, want ErrInconsistentSchema", m, err)
+		}
+	}
+}
+
+func TestJSONSchema_MatchesMultipleOf(t *testing.T) {
+	tenth, three, zero := 0.1, 3.0, 0.0
+
+	tests := []struct {
+		name       string
+		multipleOf *float64
+		value      float64
+		want       bool
+	}{
+		{name: "0.1 accepts 0.3", multipleOf: &tenth, value: 0.3, want: true},
+		{name: "0.1 accepts 0.7", multipleOf: &tenth, value: 0.7, want: true},
+		{name: "0.1 accepts 12345.6", multipleOf: &tenth, value: 12345.6, want: true},
+		{name: "0.1 accepts negative", multipleOf: &tenth, value: -1.2, want: true},
+		{name: "0.1 rejects 0.35", multipleOf: &tenth, value: 0.35, want: false},
+		{name: "3 accepts 9", multipleOf: &three, value: 9, want: true},
+		{name: "3 accepts 0", multipleOf: &three, value: 0, want: true},
+		{name: "3 rejects 10", multipleOf: &three, value: 10, want: false},
+		{name: "3 rejects 9.5", multipleOf: &three, value: 9.5, want: false},
+		{name: "unset accepts anything", multipleOf: nil, value: 0.123, want: true},
+		{name: "zero rejects", multipleOf: &zero, value: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &JSONSchema{Type: "number", MultipleOf: tt.multipleOf}
+			if got := s.MatchesMultipleOf(tt.value); got != tt.want {
+				t.Errorf("MatchesMultipleOf(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func defaultsSchema() *JSONSchema {
+	return &JSONSchema{
+		Type: "object",
+		Properties: map[string]*JSONSchema{
+			"limit": {Type: "integer", Default: 10},
+			"query": {Type: "string"},
+			"options": {
+				Type: "object",
+				Properties: map[string]*JSONSchema{
+					"sort":  {Type: "string", Default: "asc"},
+					"fuzzy": {Type: "boolean", Default: false},
+				},
+			},
+			"filters": {
+				Type: "array",
+				Items: &JSONSchema{
+					Type: "object",
+					Properties: map[string]*JSONSchema{
+						"op": {Type: "string", Default: "eq"},
+					},
+				},
+			},
+			"fields": {Type: "array", Default: []any{"id", "name"}},
+		},
+	}
+}
+
+func TestJSONSchema_ApplyDefaults(t *testing.T) {
+	tests := []struct {
+		name  string
+		value map[string]any
+		want  map[string]any
+	}{
+		{
+			name:  "nil value gets top-level defaults",
+			value: nil,
+			want:  map[string]any{"limit": 10, "fields": []any{"id", "name"}},
+		},
+		{
+			name:  "present values are kept",
+			value: map[string]any{"limit": 5, "query": "go", "fields": []any{"id"}},
+			want:  map[string]any{"limit": 5, "query": "go", "fields": []any{"id"}},
+		},
+		{
+			name:  "nested object defaults",
+			value: map[string]any{"options": map[string]any{"sort": "desc"}},
+			want: map[string]any{
+				"limit":   10,
+				"fields":  []any{"id", "name"},
+				"options": map[string]any{"sort": "desc", "fuzzy": false},
+			},
+		},
+		{
+			name: "array item defaults",
+			value: map[string]any{"filters": []any{
+				map[string]any{"field": "name"},
+				map[string]any{"field": "age", "op": "gt"},
+				"raw",
+			}},
+			want: map[string]any{
+				"limit":  10,
+				"fields": []any{"id", "name"},
+				"filters": []any{
+					map[string]any{"field": "name", "op": "eq"},
+					map[string]any{"field": "age", "op": "gt"},
+					"raw",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultsSchema().ApplyDefaults(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyDefaults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONSchema_ApplyDefaults_DoesNotMutate(t *testing.T) {
+	schema := defaultsSchema()
+	options := map[string]any{}
+	filters := []any{map[string]any{}}
+	value := map[string]any{"options": options, "filters": filters}
+
+	got := schema.ApplyDefaults(value)
+
+	if len(value) != 2 || len(options) != 0 || len(filters[0].(map[string]any)) != 0 {
+		t.Errorf("input mutated: %v", value)
+	}
+
+	got["fields"].([]any)[0] = "changed"
+	if schema.Properties["fields"].Default.([]any)[0] != "id" {
+		t.Error("default value shares storage with the result")
+	}
+}
+
+func TestJSONSchema_ApplyDefaults_ObjectDefault(t *testing.T) {
+	schema := &JSONSchema{
+		Type: "object",
+		Properties: map[string]*JSONSchema{
+			"page": {
+				Type:    "object",
+				Default: map[string]any{"size": 20},
+				Properties: map[string]*JSONSchema{
+					"size":   {Type: "integer"},
+					"offset": {Type: "integer", Default: 0},
+				},
+			},
+		},
+	}
+
+	got := schema.ApplyDefaults(nil)
+	want := map[string]any{"page": map[string]any{"size": 20, "offset": 0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ApplyDefaults() = %v, want %v", got, want)
+	}
+	if _, ok := schema.Properties["page"].Default.(map[string]any)["offset"]; ok {
+		t.Error("object default was mutated")
+	}
+}
+
+func TestJSONSchema_ApplyDefaults_NilSchema(t *testing.T) {
+	var s *JSONSchema
+	value := map[string]any{"a": 1}
+	got := s.ApplyDefaults(value)
+	if !reflect.DeepEqual(got, value) {
+		t.Errorf("ApplyDefaults() = %v, want %v", got, value)
+	}
+}
+
+func TestJSONSchema_DetectCycles(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *JSONSchema
+		want   []string
+	}{
+		{
+			name: "direct self-reference",
+			schema: &JSONSchema{
+				Ref: "#/$defs/node",
+				Defs: map[string]*JSONSchema{
+					"node": {
+						Type: "object",
+						Properties: map[string]*JSONSchema{
+							"children": {Type: "array", Items: &JSONSchema{Ref: "#/$defs/node"}},
+						},
+					},
+				},
+			},
+			want: []string{"#/$defs/node"},
+		},
+		{
+			name: "two-node mutual cycle",
+			schema: &JSONSchema{
+				Type: "object",
+				Properties: map[string]*JSONSchema{
+					"a":     {Ref: "#/$defs/a"},
+					"other": {Ref: "#/$defs/leaf"},
+				},
+				Defs: map[string]*JSONSchema{
+					"a":    {Properties: map[string]*JSONSchema{"b": {Ref: "#/$defs/b"}}},
+					"b":    {AnyOf: []*JSONSchema{{Type: "null"}, {Ref: "#/$defs/a"}}},
+					"leaf": {Type: "string"},
+				},
+			},
+			want: []string{"#/$defs/a", "#/$defs/b"},
+		},
+		{
+			name: "root recursion",
+			schema: &JSONSchema{
+				Type:       "object",
+				Properties: map[string]*JSONSchema{"next": {Ref: "#"}},
+			},
+			want: []string{"#"},
+		},
+		{
+			name: "acyclic refs",
+			schema: &JSONSchema{
+				Properties: map[string]*JSONSchema{"a": {Ref: "#/$defs/a"}},
+				Defs: map[string]*JSONSchema{
+					"a": {Properties: map[string]*JSONSchema{"b": {Ref: "#/$defs/b"}}},
+					"b": {Type: "string"},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "unresolved and remote refs",
+			schema: &JSONSchema{
+				Properties: map[string]*JSONSchema{
+					"missing": {Ref: "#/$defs/missing"},
+					"remote":  {Ref: "https://example.com/schema.json"},
+				},
+			},
+			want: nil,
+		},
+		{
+			name:   "nil",
+			schema: nil,
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schema.DetectCycles(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DetectCycles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// nestedSchema returns an object schema with levels of nested "child"
+// objects below the root, each also holding a "name" string.
+func nestedSchema(levels int) *JSONSchema {
+	schema := &JSONSchema{Type: "string"}
+	for i := 0; i < levels; i++ {
+		schema = &JSONSchema{
+			Type: "object",
+			Properties: map[string]*JSONSchema{
+				"child": schema,
+				"name":  {Type: "string"},
+			},
+		}
+	}
+	return schema
+}
+
+func TestJSONSchema_DepthAndPropertyCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    *JSONSchema
+		wantDepth int
+		wantProps int
+	}{
+		{name: "nil", schema: nil, wantDepth: 0, wantProps: 0},
+		{name: "leaf", schema: &JSONSchema{Type: "string"}, wantDepth: 1, wantProps: 0},
+		{name: "deeply nested", schema: nestedSchema(6), wantDepth: 7, wantProps: 12},
+		{
+			name: "items and combinators",
+			schema: &JSONSchema{
+				Type: "array",
+				Items: &JSONSchema{
+					AnyOf: []*JSONSchema{
+						{Type: "string"},
+						{Type: "object", Properties: map[string]*JSONSchema{"a": {Type: "string"}}},
+					},
+				},
+			},
+			wantDepth: 4,
+			wantProps: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schema.Depth(); got != tt.wantDepth {
+				t.Errorf("Depth() = %d, want %d", got, tt.wantDepth)
+			}
+			if got := tt.schema.PropertyCount(); got != tt.wantProps {
+				t.Errorf("PropertyCount() = %d, want %d", got, tt.wantProps)
+			}
+		})
+	}
+}
+
+func TestJSONSchema_FeaturesUsed(t *testing.T) {
+	min := 1.0
+	schema := &JSONSchema{
+		Type: "object",
+		Properties: map[string]*JSONSchema{
+			"code":  {Type: "string", Pattern: "^[A-Z]+$"},
+			"email": {Type: "string", Format: "email", Pattern: ".+@.+"},
+			"count": {Type: "integer", Minimum: &min},
+			"value": {AnyOf: []*JSONSchema{{Type: "string", Pattern: "^x"}, {Ref: "#/$defs/Thing"}}},
+			"tags":  {Type: "array", Items: &JSONSchema{Type: "string", Enum: []any{"a", "b"}}},
+		},
+		Defs: map[string]*JSONSchema{
+			"Thing": {Type: "object", AnyOf: []*JSONSchema{{Required: []string{"a"}}, {Required: []string{"b"}}}},
+		},
+	}
+
+	want := map[SchemaFeature]int{
+		FeatureDefs:    1,
+		FeatureRef:     1,
+		FeatureAnyOf:   2,
+		FeaturePattern: 3,
+		FeatureFormat:  1,
+		FeatureMinimum: 1,
+		FeatureEnum:    1,
+	}
+	if got := schema.FeaturesUsed(); !reflect.DeepEqual(got, want) {
+		t.Errorf("FeaturesUsed() = %v, want %v", got, want)
+	}
+
+	var nilSchema *JSONSchema
+	if got := nilSchema.FeaturesUsed(); len(got) != 0 {
+		t.Errorf("FeaturesUsed() on nil = %v, want empty", got)
+	}
+}
+
+// everySubschema returns a schema that uses every recursion point once.
+func everySubschema() *JSONSchema {
+	return &JSONSchema{
+		Type:        "object",
+		Properties:  map[string]*JSONSchema{"p": {Type: "string"}},
+		Items:       &JSONSchema{Type: "string"},
+		PrefixItems: []*JSONSchema{{Type: "string"}},
+		Contains:    &JSONSchema{Type: "string"},
+		Defs:        map[string]*JSONSchema{"d": {Type: "string"}},
+		AnyOf:       []*JSONSchema{{Type: "string"}},
+		OneOf:       []*JSONSchema{{Type: "string"}},
+		AllOf:       []*JSONSchema{{Type: "string"}},
+		Not:         &JSONSchema{Type: "string"},
+		If:          &JSONSchema{Type: "string"},
+		Then:        &JSONSchema{Type: "string"},
+		Else:        &JSONSchema{Type: "string"},
+
+		AdditionalPropertiesSchema: &JSONSchema{Type: "string"},
+	}
+}
+
+func TestWalk_VisitsEveryRecursionPoint(t *testing.T) {
+	var paths []string
+	Walk(everySubschema(), func(path string, _ *JSONSchema) {
+		paths = append(paths, path)
+	})
+
+	want := []string{"", "/properties/p", "/items", "/prefixItems/0", "/contains", "/$defs/d", "/anyOf/0", "/oneOf/0", "/allOf/0", "/not", "/if", "/then", "/else", "/additionalProperties"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Walk() paths = %v, want %v", paths, want)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	original := everySubschema()
+	snapshot := original.DeepCopy()
+
+	got := Transform(original, func(s *JSONSchema) *JSONSchema {
+		out := *s
+		if out.Type == "string" {
+			out.Description = "seen"
+		}
+		return &out
+	})
+
+	count := 0
+	Walk(got, func(path string, node *JSONSchema) {
+		if path != "" {
+			count++
+			if node.Description != "seen" {
+				t.Errorf("node at %s was not transformed", path)
+			}
+		}
+	})
+	if count != 13 {
+		t.Errorf("transformed %d subschemas, want 13", count)
+	}
+	if !reflect.DeepEqual(original, snapshot) {
+		t.Error("Transform() modified its input")
+	}
+}
+
+func TestTransform_Prune(t *testing.T) {
+	schema := &JSONSchema{
+		Type: "object",
+		Properties: map[string]*JSONSchema{
+			"keep": {Type: "string"},
+			"drop": {Type: "null"},
+		},
+		AnyOf: []*JSONSchema{{Type: "null"}, {Type: "string"}},
+		Not:   &JSONSchema{Type: "string"},
+	}
+
+	got := Transform(schema, func(s *JSONSchema) *JSONSchema {
+		if s.Type == "null" {
+			return nil
+		}
+		out := *s
+		out.Not = nil
+		return &out
+	})
+
+	if _, ok := got.Properties["drop"]; ok || got.Properties["keep"] == nil {
+		t.Errorf("Properties = %v, want only keep", got.Properties)
+	}
+	if len(got.AnyOf) != 1 || got.AnyOf[0].Type != "string" {
+		t.Errorf("AnyOf = %v, want the string branch only", got.AnyOf)
+	}
+	if got.Not != nil {
+		t.Error("Not should be pruned by fn")
+	}
+	if Transform(nil, func(s *JSONSchema) *JSONSchema { return s }) != nil {
+		t.Error("Transform(nil) should return nil")
+	}
+}
+
+func TestJSONSchema_ValidateConsistency_Nil(t *testing.T) {
+	var s *JSONSchema
+	if err := s.ValidateConsistency(); err != nil {
+		t.Errorf("ValidateConsistency() on nil = %v, want nil", err)
+	}
+}
+
 func TestJSONSchema_DeepCopy_Nil(t *testing.T) {
 	var s *JSONSchema
 	got := s.DeepCopy()
@@ -475,6 +1160,170 @@ func TestJSONSchema_ToMap_Defs(t *testing.T) {
 	}
 }
 
+func TestJSONSchema_ToMapForDraft(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *JSONSchema
+		draft  SchemaDraft
+		want   map[string]any
+	}{
+		{
+			name:   "2020-12 exclusive bounds stay numeric",
+			schema: &JSONSchema{Type: "number", ExclusiveMinimum: floatPtr(0), ExclusiveMaximum: floatPtr(10)},
+			draft:  Draft202012,
+			want:   map[string]any{"type": "number", "exclusiveMinimum": 0.0, "exclusiveMaximum": 10.0},
+		},
+		{
+			name:   "draft-07 exclusive bounds become flags",
+			schema: &JSONSchema{Type: "number", ExclusiveMinimum: floatPtr(0), ExclusiveMaximum: floatPtr(10)},
+			draft:  Draft07,
+			want: map[string]any{
+				"type":    "number",
+				"minimum": 0.0, "exclusiveMinimum": true,
+				"maximum": 10.0, "exclusiveMaximum": true,
+			},
+		},
+		{
+			name:   "draft-07 keeps the tighter inclusive bound",
+			schema: &JSONSchema{Type: "number", Minimum: floatPtr(5), ExclusiveMinimum: floatPtr(1)},
+			draft:  Draft07,
+			want:   map[string]any{"type": "number", "minimum": 5.0},
+		},
+		{
+			name:   "draft-07 keeps the tighter exclusive bound",
+			schema: &JSONSchema{Type: "number", Maximum: floatPtr(10), ExclusiveMaximum: floatPtr(3)},
+			draft:  Draft07,
+			want:   map[string]any{"type": "number", "maximum": 3.0, "exclusiveMaximum": true},
+		},
+		{
+			name: "draft-07 definitions and refs",
+			schema: &JSONSchema{
+				Type:       "object",
+				Properties: map[string]*JSONSchema{"owner": {Ref: "#/$defs/Person"}},
+				Defs:       map[string]*JSONSchema{"Person": {Type: "object"}},
+			},
+			draft: Draft07,
+			want: map[string]any{
+				"type":        "object",
+				"properties":  map[string]any{"owner": map[string]any{"$ref": "#/definitions/Person"}},
+				"definitions": map[string]any{"Person": map[string]any{"type": "object"}},
+			},
+		},
+		{
+			name: "2020-12 prefixItems and conditionals",
+			schema: &JSONSchema{
+				Type:        "array",
+				PrefixItems: []*JSONSchema{{Type: "string"}},
+				Items:       &JSONSchema{Type: "number"},
+				If:          &JSONSchema{MinItems: intPtr(2)},
+				Then:        &JSONSchema{MaxItems: intPtr(3)},
+				Else:        &JSONSchema{MaxItems: intPtr(1)},
+			},
+			draft: Draft202012,
+			want: map[string]any{
+				"type":        "array",
+				"prefixItems": []any{map[string]any{"type": "string"}},
+				"items":       map[string]any{"type": "number"},
+				"if":          map[string]any{"minItems": 2},
+				"then":        map[string]any{"maxItems": 3},
+				"else":        map[string]any{"maxItems": 1},
+			},
+		},
+		{
+			name: "draft-07 prefixItems become an items array",
+			schema: &JSONSchema{
+				Type:        "array",
+				PrefixItems: []*JSONSchema{{Type: "string"}},
+				Items:       &JSONSchema{Type: "number"},
+			},
+			draft: Draft07,
+			want: map[string]any{
+				"type":            "array",
+				"items":           []any{map[string]any{"type": "string"}},
+				"additionalItems": map[string]any{"type": "number"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schema.ToMapForDraft(tt.draft); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToMapForDraft() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaFromJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want *JSONSchema
+	}{
+		{
+			name: "2020-12 spellings",
+			json: `{"type":"object","properties":{"n":{"type":"number","exclusiveMinimum":0}},"$defs":{"N":{"type":"number"}}}`,
+			want: &JSONSchema{
+				Type:       "object",
+				Properties: map[string]*JSONSchema{"n": {Type: "number", ExclusiveMinimum: floatPtr(0)}},
+				Defs:       map[string]*JSONSchema{"N": {Type: "number"}},
+			},
+		},
+		{
+			name: "draft-07 spellings",
+			json: `{"type":"object","properties":{"n":{"$ref":"#/definitions/N"}},"definitions":{"N":{"type":"number","minimum":0,"exclusiveMinimum":true,"maximum":9,"exclusiveMaximum":false}}}`,
+			want: &JSONSchema{
+				Type:       "object",
+				Properties: map[string]*JSONSchema{"n": {Ref: "#/$defs/N"}},
+				Defs:       map[string]*JSONSchema{"N": {Type: "number", ExclusiveMinimum: floatPtr(0), Maximum: floatPtr(9)}},
+			},
+		},
+		{
+			name: "2020-12 prefixItems and conditionals",
+			json: `{"type":"array","prefixItems":[{"type":"string"}],"items":{"type":"number"},"if":{"minItems":2},"then":{"maxItems":3},"else":{"maxItems":1}}`,
+			want: &JSONSchema{
+				Type:        "array",
+				PrefixItems: []*JSONSchema{{Type: "string"}},
+				Items:       &JSONSchema{Type: "number"},
+				If:          &JSONSchema{MinItems: intPtr(2)},
+				Then:        &JSONSchema{MaxItems: intPtr(3)},
+				Else:        &JSONSchema{MaxItems: intPtr(1)},
+			},
+		},
+		{
+			name: "draft-07 items array",
+			json: `{"type":"array","items":[{"type":"string"}],"additionalItems":{"type":"number"}}`,
+			want: &JSONSchema{
+				Type:        "array",
+				PrefixItems: []*JSONSchema{{Type: "string"}},
+				Items:       &JSONSchema{Type: "number"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SchemaFromJSON([]byte(tt.json))
+			if err != nil {
+				t.Fatalf("SchemaFromJSON() error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				gotJSON, _ := got.CanonicalJSON()
+				wantJSON, _ := tt.want.CanonicalJSON()
+				t.Errorf("SchemaFromJSON() = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestSchemaFromJSON_Invalid(t *testing.T) {
+	for _, input := range []string{`not json`, `null`, `[1, 2]`} {
+		if _, err := SchemaFromJSON([]byte(input)); err == nil {
+			t.Errorf("SchemaFromJSON(%s) error = nil, want error", input)
+		}
+	}
+}
+
 func TestJSONSchema_ToMap_AdditionalProperties(t *testing.T) {
 	f := false
 	s := &JSONSchema{
@@ -489,6 +1338,34 @@ func TestJSONSchema_ToMap_AdditionalProperties(t *testing.T) {
 	}
 }
 
+func TestJSONSchema_AdditionalPropertiesSchema(t *testing.T) {
+	s := &JSONSchema{
+		Type:                       "object",
+		AdditionalPropertiesSchema: &JSONSchema{Type: "string", MaxLength: intPtr(8)},
+	}
+
+	got := s.ToMap()
+	extra, ok := got["additionalProperties"].(map[string]any)
+	if !ok || extra["type"] != "string" || extra["maxLength"] != 8 {
+		t.Errorf("additionalProperties = %v, want the string schema", got["additionalProperties"])
+	}
+
+	copied := s.DeepCopy()
+	if copied.AdditionalPropertiesSchema == s.AdditionalPropertiesSchema {
+		t.Error("AdditionalPropertiesSchema pointer is aliased, want deep copy")
+	}
+	if !copied.Equal(s) {
+		t.Errorf("DeepCopy() = %v, want %v", copied.ToMap(), got)
+	}
+
+	if back := schemaFromMap(got); !back.Equal(s) {
+		t.Errorf("schemaFromMap(ToMap()) = %v, want %v", back.ToMap(), got)
+	}
+	if d := s.Depth(); d != 2 {
+		t.Errorf("Depth() = %d, want 2", d)
+	}
+}
+
 func TestJSONSchema_ToMap_Combinators(t *testing.T) {
 	s := &JSONSchema{
 		AnyOf: []*JSONSchema{
@@ -748,3 +1625,107 @@ func TestJSONSchema_Fields(t *testing.T) {
 		t.Error("Enum has wrong type")
 	}
 }
+
+func TestJSONSchema_DeepCopy_HasConst(t *testing.T) {
+	original := &JSONSchema{HasConst: true}
+
+	copied := original.DeepCopy()
+	if !copied.HasConst {
+		t.Error("DeepCopy() lost HasConst")
+	}
+	if _, ok := copied.ToMap()["const"]; !ok {
+		t.Error("ToMap() should emit const for explicit null")
+	}
+}
+
+func TestJSONSchema_CanonicalJSON(t *testing.T) {
+	maxLen := 10
+	schema := &JSONSchema{
+		Type:     "object",
+		Required: []string{"b"},
+		Properties: map[string]*JSONSchema{
+			"b": {Type: "string", MaxLength: &maxLen},
+			"a": {Type: "array", Items: &JSONSchema{Type: "string", Format: "email"}},
+		},
+	}
+
+	got, err := schema.CanonicalJSON()
+	if err != nil {
+		t.Fatalf("CanonicalJSON() error = %v", err)
+	}
+	want := `{"properties":{"a":{"items":{"format":"email","type":"string"},"type":"array"},"b":{"maxLength":10,"type":"string"}},"required":["b"],"type":"object"}`
+	if string(got) != want {
+		t.Errorf("CanonicalJSON() = %s, want %s", got, want)
+	}
+
+	nilJSON, err := (*JSONSchema)(nil).CanonicalJSON()
+	if err != nil || string(nilJSON) != "null" {
+		t.Errorf("nil.CanonicalJSON() = %s, %v, want null", nilJSON, err)
+	}
+}
+
+func TestJSONSchema_MarshalJSON_Deterministic(t *testing.T) {
+	schema := &JSONSchema{
+		Type: "object",
+		Properties: map[string]*JSONSchema{
+			"zeta":  {Type: "string"},
+			"alpha": {Type: "integer"},
+			"mid":   {AnyOf: []*JSONSchema{{Type: "string"}, {Type: "null"}}},
+		},
+		Defs: map[string]*JSONSchema{
+			"Y": {Type: "boolean"},
+			"X": {Type: "number"},
+		},
+	}
+
+	first, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	for range 10 {
+		again, err := json.Marshal(schema)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		if string(again) != string(first) {
+			t.Fatalf("json.Marshal() = %s, want identical %s", again, first)
+		}
+	}
+	if want, _ := schema.CanonicalJSON(); string(first) != string(want) {
+		t.Errorf("json.Marshal() = %s, want CanonicalJSON %s", first, want)
+	}
+
+	var decoded JSONSchema
+	if err := json.Unmarshal(first, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !decoded.Equal(schema) {
+		t.Errorf("json.Unmarshal() = %+v, want the original schema", decoded)
+	}
+}
+
+func TestJSONSchema_Equal(t *testing.T) {
+	build := func(names ...string) *JSONSchema {
+		s := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}}
+		for _, n := range names {
+			s.Properties[n] = &JSONSchema{Type: "string"}
+		}
+		return s
+	}
+
+	if !build("x", "y", "z").Equal(build("z", "y", "x")) {
+		t.Error("Equal() = false for schemas differing only in insertion order")
+	}
+	if build("x").Equal(build("x", "y")) {
+		t.Error("Equal() = true for different properties")
+	}
+	if !(*JSONSchema)(nil).Equal(nil) {
+		t.Error("nil.Equal(nil) = false, want true")
+	}
+	if build("x").Equal(nil) {
+		t.Error("Equal(nil) = true, want false")
+	}
+	if !build("x").Equal(build("x").DeepCopy()) {
+		t.Error("Equal(DeepCopy()) = false, want true")
+	}
+}
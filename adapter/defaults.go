@@ -1,7 +1,10 @@
 package adapter
 
 // DefaultRegistry returns a registry pre-configured with all built-in adapters.
-// The registry includes MCP, OpenAI, Anthropic, A2A, and Gemini adapters.
+// The registry includes MCP, OpenAI, Anthropic, A2A, Gemini, OpenAPI, and
+// Vertex AI adapters. New built-in adapters are registered here as they are
+// added; use NewRegistry and Register to build a registry with only some
+// formats.
 func DefaultRegistry() *AdapterRegistry {
 	registry := NewRegistry()
 
@@ -11,6 +14,15 @@ func DefaultRegistry() *AdapterRegistry {
 	_ = registry.Register(NewAnthropicAdapter())
 	_ = registry.Register(NewA2AAdapter())
 	_ = registry.Register(NewGeminiAdapter())
+	_ = registry.Register(NewOpenAPIAdapter())
+	_ = registry.Register(NewVertexAdapter())
 
 	return registry
 }
+
+// DefaultRegistryAll returns a registry with every built-in adapter. It is
+// the same as DefaultRegistry, which already registers all of them, and is
+// provided for callers that want to state that intent explicitly.
+func DefaultRegistryAll() *AdapterRegistry {
+	return DefaultRegistry()
+}
@@ -1,7 +1,11 @@
 package adapter
 
 import (
+	"bytes"
+	"encoding/json"
+	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -19,7 +23,7 @@ func TestDefaultRegistry(t *testing.T) {
 	adapters := registry.List()
 	sort.Strings(adapters)
 
-	expected := []string{"a2a", "anthropic", "gemini", "mcp", "openai"}
+	expected := []string{"a2a", "anthropic", "gemini", "mcp", "openai", "openapi", "vertex"}
 	if len(adapters) != len(expected) {
 		t.Errorf("List() = %v, want %v", adapters, expected)
 	}
@@ -42,6 +46,22 @@ func TestDefaultRegistry(t *testing.T) {
 	}
 }
 
+func TestDefaultRegistryAll(t *testing.T) {
+	all := DefaultRegistryAll().List()
+	sort.Strings(all)
+
+	want := []string{"a2a", "anthropic", "gemini", "mcp", "openai", "openapi", "vertex"}
+	if !reflect.DeepEqual(all, want) {
+		t.Errorf("DefaultRegistryAll().List() = %v, want %v", all, want)
+	}
+
+	defaults := DefaultRegistry().List()
+	sort.Strings(defaults)
+	if !reflect.DeepEqual(all, defaults) {
+		t.Errorf("DefaultRegistryAll().List() = %v, want DefaultRegistry().List() = %v", all, defaults)
+	}
+}
+
 func TestDefaultRegistry_MCPToOpenAI(t *testing.T) {
 	registry := DefaultRegistry()
 
@@ -182,6 +202,46 @@ func TestDefaultRegistry_FeatureLossWarnings(t *testing.T) {
 	}
 }
 
+func TestDefaultRegistry_TimeoutLossWarning(t *testing.T) {
+	registry := DefaultRegistry()
+
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name:        "slow-tool",
+			InputSchema: map[string]any{"type": "object"},
+			Meta:        mcp.Meta{"timeout": "30s"},
+		},
+	}
+
+	tests := []struct {
+		target   string
+		wantLoss bool
+	}{
+		{target: "mcp", wantLoss: false},
+		{target: "openai", wantLoss: true},
+		{target: "anthropic", wantLoss: true},
+		{target: "gemini", wantLoss: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			result, err := registry.Convert(tool, "mcp", tt.target)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			gotLoss := false
+			for _, w := range result.Warnings {
+				if w.Feature == FeatureTimeout {
+					gotLoss = true
+				}
+			}
+			if gotLoss != tt.wantLoss {
+				t.Errorf("timeout warning = %v, want %v (warnings: %v)", gotLoss, tt.wantLoss, result.Warnings)
+			}
+		})
+	}
+}
+
 func TestDefaultRegistry_NoWarningsForSupportedFeatures(t *testing.T) {
 	registry := DefaultRegistry()
 
@@ -418,3 +478,536 @@ func TestDefaultRegistry_FeatureLossWithItems(t *testing.T) {
 		t.Error("Expected warning about pattern feature loss in array items")
 	}
 }
+
+func TestDefaultRegistry_FeatureLossWithTuplesAndConditionals(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "pair",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"pair": map[string]any{
+						"type":        "array",
+						"prefixItems": []any{map[string]any{"type": "string"}, map[string]any{"type": "number"}},
+					},
+				},
+				"if":   map[string]any{"required": []any{"pair"}},
+				"then": map[string]any{"minProperties": 1},
+				"else": map[string]any{"maxProperties": 0},
+			},
+		},
+	}
+
+	for _, target := range []string{"openai", "anthropic", "gemini"} {
+		t.Run(target, func(t *testing.T) {
+			result, err := registry.Convert(tool, "mcp", target)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			lost := map[SchemaFeature]bool{}
+			for _, w := range result.Warnings {
+				lost[w.Feature] = true
+			}
+			for _, f := range []SchemaFeature{FeaturePrefixItems, FeatureIf, FeatureThen, FeatureElse} {
+				if !lost[f] {
+					t.Errorf("missing feature-loss warning for %s", f)
+				}
+			}
+		})
+	}
+
+	result, err := registry.Convert(tool, "mcp", "openapi")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	for _, w := range result.Warnings {
+		switch w.Feature {
+		case FeaturePrefixItems, FeatureIf, FeatureThen, FeatureElse:
+			t.Errorf("unexpected openapi warning for %s", w.Feature)
+		}
+	}
+}
+
+func TestDefaultRegistry_AdditionalPropertiesSchema(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "label",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"labels": map[string]any{
+						"type":                 "object",
+						"additionalProperties": map[string]any{"type": "string"},
+					},
+					"strict": map[string]any{
+						"type":                 "object",
+						"additionalProperties": false,
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		format string
+		kept   bool
+	}{
+		{format: "mcp", kept: true},
+		{format: "openapi", kept: true},
+		{format: "openai"},
+		{format: "anthropic"},
+		{format: "gemini"},
+		{format: "vertex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			result, err := registry.Convert(tool, "mcp", tt.format)
+			if err != nil {
+				t.Fatalf("Convert(mcp -> %s) error = %v", tt.format, err)
+			}
+			var lost []string
+			for _, w := range result.Warnings {
+				if w.Feature == FeatureAdditionalPropertiesSchema {
+					lost = append(lost, w.Path)
+				}
+			}
+			if tt.kept && len(lost) != 0 {
+				t.Errorf("warnings at %v, want none", lost)
+			}
+			if !tt.kept && !reflect.DeepEqual(lost, []string{"/properties/labels"}) {
+				t.Errorf("additionalProperties schema warnings at %v, want /properties/labels", lost)
+			}
+
+			back, err := registry.Convert(result.Tool, tt.format, "mcp")
+			if err != nil {
+				t.Fatalf("Convert(%s -> mcp) error = %v", tt.format, err)
+			}
+			props := back.Tool.(*model.Tool).InputSchema.(map[string]any)["properties"].(map[string]any)
+			labels := props["labels"].(map[string]any)
+			_, isSchema := labels["additionalProperties"].(map[string]any)
+			if isSchema != tt.kept {
+				t.Errorf("labels.additionalProperties = %v, want schema kept = %v", labels["additionalProperties"], tt.kept)
+			}
+			if got := props["strict"].(map[string]any)["additionalProperties"]; got != false {
+				t.Errorf("strict.additionalProperties = %v, want false", got)
+			}
+		})
+	}
+}
+
+func TestDefaultRegistry_FeatureLossWithContains(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "tag",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"tags": map[string]any{
+						"type":        "array",
+						"contains":    map[string]any{"type": "string", "pattern": "^p"},
+						"minContains": 1,
+					},
+				},
+			},
+		},
+	}
+
+	result, err := registry.Convert(tool, "mcp", "openai")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	got := make(map[SchemaFeature]string)
+	for _, w := range result.Warnings {
+		got[w.Feature] = w.Path
+	}
+	want := map[SchemaFeature]string{
+		FeatureContains:    "/properties/tags",
+		FeatureMinContains: "/properties/tags",
+		FeaturePattern:     "/properties/tags/contains",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("warnings = %v, want %v", got, want)
+	}
+
+	tags := result.Tool.(*OpenAITool).Function.Parameters["properties"].(map[string]any)["tags"].(map[string]any)
+	if _, ok := tags["contains"]; ok {
+		t.Errorf("tags = %v, want contains dropped", tags)
+	}
+}
+
+func TestDefaultRegistry_OutputSchemaRoundTrip(t *testing.T) {
+	registry := DefaultRegistry()
+
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name:        "search",
+			Description: "Search things",
+			InputSchema: map[string]any{"type": "object"},
+			OutputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"results": map[string]any{
+						"type":  "array",
+						"items": map[string]any{"type": "string", "format": "uri"},
+					},
+				},
+				"required": []any{"results"},
+			},
+		},
+	}
+
+	for _, format := range []string{"openai", "anthropic", "gemini"} {
+		t.Run(format, func(t *testing.T) {
+			out, err := registry.Convert(tool, "mcp", format)
+			if err != nil {
+				t.Fatalf("Convert(mcp -> %s) error = %v", format, err)
+			}
+			back, err := registry.Convert(out.Tool, format, "mcp")
+			if err != nil {
+				t.Fatalf("Convert(%s -> mcp) error = %v", format, err)
+			}
+
+			mcpTool := back.Tool.(*model.Tool)
+			schema, ok := mcpTool.OutputSchema.(map[string]any)
+			if !ok {
+				t.Fatalf("OutputSchema = %T, want map[string]any", mcpTool.OutputSchema)
+			}
+			props, _ := schema["properties"].(map[string]any)
+			results, _ := props["results"].(map[string]any)
+			items, _ := results["items"].(map[string]any)
+			if items["format"] != "uri" {
+				t.Errorf("OutputSchema items = %v, want format uri preserved", items)
+			}
+		})
+	}
+}
+
+func TestDefaultRegistry_StrictCrossFormatRoundTrip(t *testing.T) {
+	registry := DefaultRegistry()
+	strict := true
+	tool := &OpenAITool{
+		Type: "function",
+		Function: OpenAIFunction{
+			Name:       "lookup",
+			Parameters: map[string]any{"type": "object"},
+			Strict:     &strict,
+		},
+	}
+
+	for _, via := range []string{"anthropic", "gemini", "vertex", "openapi"} {
+		t.Run(via, func(t *testing.T) {
+			out, err := registry.Convert(tool, "openai", via)
+			if err != nil {
+				t.Fatalf("Convert(openai -> %s) error = %v", via, err)
+			}
+			back, err := registry.Convert(out.Tool, via, "openai")
+			if err != nil {
+				t.Fatalf("Convert(%s -> openai) error = %v", via, err)
+			}
+
+			fn := back.Tool.(*OpenAITool).Function
+			if fn.Strict == nil || !*fn.Strict {
+				t.Errorf("Strict = %v, want true preserved through %s", fn.Strict, via)
+			}
+		})
+	}
+}
+
+func TestDefaultRegistry_IntegerTypePreserved(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "paginate",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"limit": map[string]any{"type": "integer"},
+					"ratio": map[string]any{"type": "number"},
+				},
+			},
+		},
+	}
+
+	for _, via := range []string{"openai", "anthropic", "gemini", "vertex", "openapi"} {
+		t.Run(via, func(t *testing.T) {
+			out, err := registry.Convert(tool, "mcp", via)
+			if err != nil {
+				t.Fatalf("Convert(mcp -> %s) error = %v", via, err)
+			}
+			if openai, ok := out.Tool.(*OpenAITool); ok {
+				props := openai.Function.Parameters["properties"].(map[string]any)
+				if got := props["limit"].(map[string]any)["type"]; got != "integer" {
+					t.Errorf("openai limit.type = %v, want integer", got)
+				}
+				if got := props["ratio"].(map[string]any)["type"]; got != "number" {
+					t.Errorf("openai ratio.type = %v, want number", got)
+				}
+			}
+
+			back, err := registry.Convert(out.Tool, via, "mcp")
+			if err != nil {
+				t.Fatalf("Convert(%s -> mcp) error = %v", via, err)
+			}
+			schema := back.Tool.(*model.Tool).InputSchema.(map[string]any)
+			props := schema["properties"].(map[string]any)
+			if got := props["limit"].(map[string]any)["type"]; got != "integer" {
+				t.Errorf("limit.type = %v, want integer through %s", got, via)
+			}
+			if got := props["ratio"].(map[string]any)["type"]; got != "number" {
+				t.Errorf("ratio.type = %v, want number through %s", got, via)
+			}
+		})
+	}
+}
+
+func TestAdapters_PreserveUnknownSourceMeta(t *testing.T) {
+	registry := DefaultRegistry()
+	for _, format := range []string{"openai", "anthropic", "gemini", "vertex", "openapi", "a2a"} {
+		t.Run(format, func(t *testing.T) {
+			a, _ := registry.Get(format)
+			ct := &CanonicalTool{
+				Name:        "lookup",
+				InputSchema: &JSONSchema{Type: "object"},
+				SourceMeta: map[string]any{
+					"custom": "kept",
+					"acme":   map[string]any{"tier": "gold"},
+				},
+			}
+
+			native, err := a.FromCanonical(ct)
+			if err != nil {
+				t.Fatalf("FromCanonical() error = %v", err)
+			}
+			back, err := a.ToCanonical(native)
+			if err != nil {
+				t.Fatalf("ToCanonical() error = %v", err)
+			}
+
+			if back.SourceMeta["custom"] != "kept" {
+				t.Errorf("SourceMeta[custom] = %v, want kept", back.SourceMeta["custom"])
+			}
+			if want := map[string]any{"tier": "gold"}; !reflect.DeepEqual(back.SourceMeta["acme"], want) {
+				t.Errorf("SourceMeta[acme] = %v, want %v", back.SourceMeta["acme"], want)
+			}
+		})
+	}
+}
+
+func TestAdapters_PreserveAnnotations(t *testing.T) {
+	registry := DefaultRegistry()
+	for _, format := range []string{"openai", "anthropic", "gemini", "vertex", "openapi", "a2a"} {
+		t.Run(format, func(t *testing.T) {
+			a, _ := registry.Get(format)
+			annotations := map[string]any{"readOnlyHint": true, "x-policy": "audit"}
+			ct := &CanonicalTool{
+				Name:        "lookup",
+				InputSchema: &JSONSchema{Type: "object"},
+				Annotations: annotations,
+			}
+
+			native, err := a.FromCanonical(ct)
+			if err != nil {
+				t.Fatalf("FromCanonical() error = %v", err)
+			}
+			back, err := a.ToCanonical(native)
+			if err != nil {
+				t.Fatalf("ToCanonical() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(back.Annotations, annotations) {
+				t.Errorf("Annotations = %v, want %v", back.Annotations, annotations)
+			}
+			if _, ok := back.SourceMeta[carriedAnnotationsKey]; ok {
+				t.Errorf("SourceMeta = %v, want carried annotations kept out", back.SourceMeta)
+			}
+			back.Annotations["readOnlyHint"] = false
+			if annotations["readOnlyHint"] != true {
+				t.Error("ToCanonical() Annotations alias the input map")
+			}
+		})
+	}
+}
+
+func TestDefaultRegistry_AnnotationsCrossFormatRoundTrip(t *testing.T) {
+	registry := DefaultRegistry()
+	canonical := &CanonicalTool{
+		Name:        "lookup",
+		InputSchema: &JSONSchema{Type: "object"},
+		Annotations: map[string]any{"readOnlyHint": true},
+	}
+
+	for _, via := range []string{"openai", "anthropic", "gemini", "vertex", "openapi", "a2a"} {
+		t.Run(via, func(t *testing.T) {
+			a, _ := registry.Get(via)
+			native, err := a.FromCanonical(canonical)
+			if err != nil {
+				t.Fatalf("FromCanonical() error = %v", err)
+			}
+
+			back, err := registry.Convert(native, via, "mcp")
+			if err != nil {
+				t.Fatalf("Convert(%s -> mcp) error = %v", via, err)
+			}
+			tool := back.Tool.(*model.Tool)
+			if tool.Annotations == nil || !tool.Annotations.ReadOnlyHint {
+				t.Errorf("Annotations = %+v, want readOnlyHint preserved through %s", tool.Annotations, via)
+			}
+		})
+	}
+}
+
+func TestDefaultRegistry_CarriedMetaIsNotSerialized(t *testing.T) {
+	strict := true
+	tool := &OpenAITool{
+		Type:     "function",
+		Function: OpenAIFunction{Name: "lookup", Parameters: map[string]any{"type": "object"}, Strict: &strict},
+	}
+	out, err := DefaultRegistry().Convert(tool, "openai", "anthropic")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	anthropicTool := out.Tool.(*AnthropicTool)
+	if anthropicTool.SourceMeta["openai"] == nil {
+		t.Fatalf("SourceMeta = %v, want the openai namespace carried", anthropicTool.SourceMeta)
+	}
+	data, err := json.Marshal(anthropicTool)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), "strict") {
+		t.Errorf("Marshal() = %s, want carried metadata omitted", data)
+	}
+}
+
+func TestConversionResult_FidelityScore_Lossless(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "lossless",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"count": map[string]any{"type": "integer", "minimum": 1, "maximum": 10},
+					"mode":  map[string]any{"type": "string", "enum": []any{"a", "b"}},
+				},
+			},
+		},
+	}
+
+	result, err := registry.Convert(tool, "mcp", "openai")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Fatalf("Warnings = %v, want none", result.Warnings)
+	}
+	if got := result.FidelityScore(); got != 100 {
+		t.Errorf("FidelityScore() = %d, want 100", got)
+	}
+}
+
+func TestConversionResult_FidelityScore_CriticalLoss(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "lossy",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"count": map[string]any{"type": "integer", "minimum": 1, "maximum": 10},
+					"value": map[string]any{
+						"oneOf": []any{
+							map[string]any{"type": "string"},
+							map[string]any{"type": "number"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := registry.Convert(tool, "mcp", "openai")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	var critical bool
+	for _, w := range result.Warnings {
+		if w.Feature == FeatureOneOf && w.Severity == SeverityError {
+			critical = true
+		}
+	}
+	if !critical {
+		t.Fatalf("Warnings = %v, want error-severity oneOf loss", result.Warnings)
+	}
+	if got := result.FidelityScore(); got > 50 {
+		t.Errorf("FidelityScore() = %d, want <= 50 after losing oneOf", got)
+	}
+}
+
+func TestDefaultRegistry_ConvertIsDeterministic(t *testing.T) {
+	registry := DefaultRegistry()
+
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name:        "complex",
+			Description: "Exercises many schema features",
+			InputSchema: map[string]any{
+				"type":  "object",
+				"title": "Complex",
+				"$defs": map[string]any{
+					"alpha": map[string]any{"type": "string", "pattern": "^a"},
+					"beta":  map[string]any{"type": "string", "format": "email"},
+					"gamma": map[string]any{"type": "number", "multipleOf": 2},
+				},
+				"properties": map[string]any{
+					"a": map[string]any{"$ref": "#/$defs/alpha"},
+					"b": map[string]any{"type": "string", "pattern": "^b", "format": "uri"},
+					"c": map[string]any{"oneOf": []any{
+						map[string]any{"type": "string", "title": "C1"},
+						map[string]any{"type": "integer", "examples": []any{1}},
+					}},
+					"d": map[string]any{"type": "array", "items": map[string]any{"type": "string", "deprecated": true}},
+					"e": map[string]any{"not": map[string]any{"type": "null"}},
+				},
+			},
+		},
+	}
+
+	for _, format := range []string{"openai", "anthropic", "gemini"} {
+		t.Run(format, func(t *testing.T) {
+			first, err := registry.Convert(tool, "mcp", format)
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			wantJSON, err := json.Marshal(first.Tool)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if len(first.Warnings) == 0 {
+				t.Fatal("expected warnings for lossy conversion")
+			}
+
+			for i := 0; i < 100; i++ {
+				res, err := registry.Convert(tool, "mcp", format)
+				if err != nil {
+					t.Fatalf("Convert() iteration %d error = %v", i, err)
+				}
+				gotJSON, err := json.Marshal(res.Tool)
+				if err != nil {
+					t.Fatalf("json.Marshal() error = %v", err)
+				}
+				if !bytes.Equal(gotJSON, wantJSON) {
+					t.Fatalf("iteration %d output differs:\n%s\nwant:\n%s", i, gotJSON, wantJSON)
+				}
+				if !reflect.DeepEqual(res.Warnings, first.Warnings) {
+					t.Fatalf("iteration %d warnings differ:\n%v\nwant:\n%v", i, res.Warnings, first.Warnings)
+				}
+			}
+		})
+	}
+}
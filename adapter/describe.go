@@ -0,0 +1,175 @@
+package adapter
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Describe renders the schema as an indented plain-text outline for
+// documentation. Each property becomes a line with its name, type, and
+// flags such as required, followed by its description:
+//
+//	id (string, required) - User identifier
+//	  format: uuid
+//	tags (array of string)
+//	  minItems: 1
+//
+// Constraints and enums are listed one per line beneath the property, and
+// the properties of nested objects (or of array items that are objects)
+// are indented beneath them. Properties are listed in sorted order. A
+// schema without properties is described by its type and constraints.
+// Returns "" if the receiver is nil.
+func (s *JSONSchema) Describe() string {
+	if s == nil {
+		return ""
+	}
+	var b strings.Builder
+	if len(describedProperties(s).Properties) == 0 {
+		b.WriteString(schemaLabel(s) + "\n")
+		writeConstraints(&b, s, 1)
+		return b.String()
+	}
+	writeProperties(&b, describedProperties(s), 0)
+	return b.String()
+}
+
+// describedProperties returns the schema whose properties are listed under
+// s: the items schema for arrays, otherwise s itself.
+func describedProperties(s *JSONSchema) *JSONSchema {
+	if s.Type == "array" && s.Items != nil {
+		return s.Items
+	}
+	return s
+}
+
+// writeProperties writes one outline entry per property of s at depth.
+func writeProperties(b *strings.Builder, s *JSONSchema, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, name := range sortedKeys(s.Properties) {
+		prop := s.Properties[name]
+		if prop == nil {
+			continue
+		}
+
+		details := []string{schemaLabel(prop)}
+		if slices.Contains(s.Required, name) {
+			details = append(details, "required")
+		}
+		for _, flag := range []struct {
+			set  *bool
+			name string
+		}{
+			{prop.Nullable, "nullable"},
+			{prop.ReadOnly, "read-only"},
+			{prop.WriteOnly, "write-only"},
+			{prop.Deprecated, "deprecated"},
+		} {
+			if flag.set != nil && *flag.set {
+				details = append(details, flag.name)
+			}
+		}
+
+		line := fmt.Sprintf("%s%s (%s)", indent, name, strings.Join(details, ", "))
+		if prop.Description != "" {
+			line += " - " + prop.Description
+		}
+		b.WriteString(line + "\n")
+
+		writeConstraints(b, prop, depth+1)
+		writeProperties(b, describedProperties(prop), depth+1)
+	}
+}
+
+// schemaLabel names the type of s, e.g. "string", "array of integer",
+// "string | null", or the $ref target.
+func schemaLabel(s *JSONSchema) string {
+	branchLabels := func(branches []*JSONSchema) string {
+		labels := make([]string, 0, len(branches))
+		for _, branch := range branches {
+			if branch != nil {
+				labels = append(labels, schemaLabel(branch))
+			}
+		}
+		return strings.Join(labels, " | ")
+	}
+
+	switch {
+	case s.Ref != "":
+		return s.Ref
+	case len(s.AnyOf) > 0:
+		return branchLabels(s.AnyOf)
+	case len(s.OneOf) > 0:
+		return branchLabels(s.OneOf)
+	case s.Type == "array" && s.Items != nil:
+		return "array of " + schemaLabel(s.Items)
+	case s.Type != "":
+		return s.Type
+	default:
+		return "any"
+	}
+}
+
+// writeConstraints writes one "keyword: value" line per constraint of s.
+func writeConstraints(b *strings.Builder, s *JSONSchema, depth int) {
+	indent := strings.Repeat("  ", depth)
+	line := func(keyword, value string) {
+		b.WriteString(indent + keyword + ": " + value + "\n")
+	}
+	number := func(keyword string, v *float64) {
+		if v != nil {
+			line(keyword, strconv.FormatFloat(*v, 'f', -1, 64))
+		}
+	}
+	count := func(keyword string, v *int) {
+		if v != nil {
+			line(keyword, strconv.Itoa(*v))
+		}
+	}
+
+	if s.Format != "" {
+		line("format", s.Format)
+	}
+	if s.Pattern != "" {
+		line("pattern", s.Pattern)
+	}
+	number("minimum", s.Minimum)
+	number("exclusiveMinimum", s.ExclusiveMinimum)
+	number("maximum", s.Maximum)
+	number("exclusiveMaximum", s.ExclusiveMaximum)
+	number("multipleOf", s.MultipleOf)
+	count("minLength", s.MinLength)
+	count("maxLength", s.MaxLength)
+	count("minItems", s.MinItems)
+	count("maxItems", s.MaxItems)
+	if s.UniqueItems != nil && *s.UniqueItems {
+		line("uniqueItems", "true")
+	}
+	count("minProperties", s.MinProperties)
+	count("maxProperties", s.MaxProperties)
+	if s.hasConst() {
+		line("const", describeValue(s.Const))
+	}
+	if s.Default != nil {
+		line("default", describeValue(s.Default))
+	}
+	if len(s.Enum) > 0 {
+		values := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			values[i] = describeValue(v)
+		}
+		line("enum", strings.Join(values, ", "))
+	}
+}
+
+// describeValue renders a schema value as JSON, falling back to fmt for
+// values that cannot be encoded.
+func describeValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
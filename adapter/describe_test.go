@@ -0,0 +1,96 @@
+package adapter
+
+import "testing"
+
+func TestJSONSchema_Describe(t *testing.T) {
+	schema := &JSONSchema{
+		Type: "object",
+		Properties: map[string]*JSONSchema{
+			"id": {Type: "string", Description: "User identifier", Format: "uuid"},
+			"age": {
+				Type:    "integer",
+				Minimum: floatPtr(0),
+				Maximum: floatPtr(150),
+			},
+			"role": {
+				Type:    "string",
+				Enum:    []any{"admin", "member"},
+				Default: "member",
+			},
+			"nickname": {
+				AnyOf:      []*JSONSchema{{Type: "string"}, {Type: "null"}},
+				Deprecated: boolPtr(true),
+			},
+			"address": {
+				Type: "object",
+				Properties: map[string]*JSONSchema{
+					"street": {Type: "string", MinLength: intPtr(1)},
+					"zip":    {Type: "string", Pattern: "^[0-9]{5}$"},
+				},
+				Required: []string{"street"},
+			},
+			"tags": {
+				Type:     "array",
+				Items:    &JSONSchema{Type: "string"},
+				MinItems: intPtr(1),
+			},
+			"contacts": {
+				Type: "array",
+				Items: &JSONSchema{
+					Type:       "object",
+					Properties: map[string]*JSONSchema{"email": {Type: "string", Format: "email"}},
+				},
+			},
+		},
+		Required: []string{"id", "role"},
+	}
+
+	want := `address (object)
+  street (string, required)
+    minLength: 1
+  zip (string)
+    pattern: ^[0-9]{5}$
+age (integer)
+  minimum: 0
+  maximum: 150
+contacts (array of object)
+  email (string)
+    format: email
+id (string, required) - User identifier
+  format: uuid
+nickname (string | null, deprecated)
+role (string, required)
+  default: "member"
+  enum: "admin", "member"
+tags (array of string)
+  minItems: 1
+`
+	if got := schema.Describe(); got != want {
+		t.Errorf("Describe() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestJSONSchema_Describe_NoProperties(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *JSONSchema
+		want   string
+	}{
+		{name: "nil", schema: nil, want: ""},
+		{name: "empty", schema: &JSONSchema{}, want: "any\n"},
+		{
+			name:   "scalar",
+			schema: &JSONSchema{Type: "number", ExclusiveMinimum: floatPtr(0.5)},
+			want:   "number\n  exclusiveMinimum: 0.5\n",
+		},
+		{name: "ref", schema: &JSONSchema{Ref: "#/$defs/User"}, want: "#/$defs/User\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schema.Describe(); got != tt.want {
+				t.Errorf("Describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package adapter
+
+import (
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jonwraymond/toolfoundation/model"
+)
+
+// DetectFormat guesses which registered adapter understands tool.
+//
+// Concrete types defined by the built-in adapters (and the MCP SDK and model
+// tool types) map directly to their format. For a decoded JSON object
+// (map[string]any) the keys are inspected: "inputSchema" for MCP,
+// "input_schema" for Anthropic, "function" for OpenAI, "functionDeclarations"
+// for Gemini (or Vertex AI when the parameter types are uppercase),
+// "operationId" for OpenAPI, and "id" with "tags" for an A2A skill.
+//
+// It returns false when the shape is unknown, matches more than one format,
+// or names a format that is not registered. Every built-in adapter accepts
+// such a map, so the result can be passed straight to Convert.
+func (r *AdapterRegistry) DetectFormat(tool any) (string, bool) {
+	format := detectFormat(tool)
+	if format == "" || !r.Has(format) {
+		return "", false
+	}
+	return format, true
+}
+
+// detectFormat returns the built-in format name for tool, or "" if it is
+// unknown or ambiguous.
+func detectFormat(tool any) string {
+	switch v := tool.(type) {
+	case *model.Tool, model.Tool, *mcp.Tool, mcp.Tool:
+		return "mcp"
+	case *OpenAITool, OpenAITool, *OpenAIFunction, OpenAIFunction:
+		return "openai"
+	case *AnthropicTool, AnthropicTool:
+		return "anthropic"
+	case *GeminiTool, GeminiTool, *GeminiFunctionDeclaration, GeminiFunctionDeclaration:
+		return "gemini"
+	case *VertexTool, VertexTool, *VertexFunctionDeclaration, VertexFunctionDeclaration:
+		return "vertex"
+	case *OpenAPIOperation, OpenAPIOperation:
+		return "openapi"
+	case *A2AAgentSkill, A2AAgentSkill:
+		return "a2a"
+	case map[string]any:
+		return detectMapFormat(v)
+	default:
+		return ""
+	}
+}
+
+// detectMapFormat matches a decoded JSON object against the distinctive keys
+// of each format. More than one match is ambiguous.
+func detectMapFormat(m map[string]any) string {
+	var matches []string
+	if _, ok := m["inputSchema"]; ok {
+		matches = append(matches, "mcp")
+	}
+	if _, ok := m["input_schema"]; ok {
+		matches = append(matches, "anthropic")
+	}
+	if _, ok := m["function"].(map[string]any); ok {
+		matches = append(matches, "openai")
+	}
+	if decls, ok := m["functionDeclarations"].([]any); ok {
+		if usesVertexTypes(decls) {
+			matches = append(matches, "vertex")
+		} else {
+			matches = append(matches, "gemini")
+		}
+	}
+	if _, ok := m["operationId"]; ok {
+		matches = append(matches, "openapi")
+	}
+	_, hasID := m["id"]
+	_, hasTags := m["tags"]
+	if hasID && hasTags {
+		matches = append(matches, "a2a")
+	}
+
+	if len(matches) != 1 {
+		return ""
+	}
+	return matches[0]
+}
+
+// usesVertexTypes reports whether the first declaration's parameters use
+// Vertex AI's uppercase type names.
+func usesVertexTypes(decls []any) bool {
+	if len(decls) == 0 {
+		return false
+	}
+	decl, _ := decls[0].(map[string]any)
+	params, _ := decl["parameters"].(map[string]any)
+	typ, _ := params["type"].(string)
+	return typ != "" && typ == strings.ToUpper(typ)
+}
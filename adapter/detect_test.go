@@ -0,0 +1,103 @@
+package adapter
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jonwraymond/toolfoundation/model"
+)
+
+func TestRegistry_DetectFormat(t *testing.T) {
+	registry := DefaultRegistry()
+	tests := []struct {
+		name   string
+		tool   any
+		want   string
+		wantOK bool
+	}{
+		{name: "model tool", tool: &model.Tool{Tool: mcp.Tool{Name: "t"}}, want: "mcp", wantOK: true},
+		{name: "mcp tool", tool: mcp.Tool{Name: "t"}, want: "mcp", wantOK: true},
+		{name: "openai tool", tool: &OpenAITool{Type: "function"}, want: "openai", wantOK: true},
+		{name: "openai function", tool: OpenAIFunction{Name: "t"}, want: "openai", wantOK: true},
+		{name: "anthropic tool", tool: &AnthropicTool{Name: "t"}, want: "anthropic", wantOK: true},
+		{name: "gemini tool", tool: &GeminiTool{}, want: "gemini", wantOK: true},
+		{name: "vertex tool", tool: &VertexTool{}, want: "vertex", wantOK: true},
+		{name: "openapi operation", tool: &OpenAPIOperation{OperationID: "t"}, want: "openapi", wantOK: true},
+		{name: "a2a skill", tool: &A2AAgentSkill{ID: "t"}, want: "a2a", wantOK: true},
+		{
+			name:   "mcp map",
+			tool:   map[string]any{"name": "t", "inputSchema": map[string]any{"type": "object"}},
+			want:   "mcp",
+			wantOK: true,
+		},
+		{
+			name:   "anthropic map",
+			tool:   map[string]any{"name": "t", "input_schema": map[string]any{"type": "object"}},
+			want:   "anthropic",
+			wantOK: true,
+		},
+		{
+			name:   "openai map",
+			tool:   map[string]any{"type": "function", "function": map[string]any{"name": "t"}},
+			want:   "openai",
+			wantOK: true,
+		},
+		{
+			name: "gemini map",
+			tool: map[string]any{"functionDeclarations": []any{
+				map[string]any{"name": "t", "parameters": map[string]any{"type": "object"}},
+			}},
+			want:   "gemini",
+			wantOK: true,
+		},
+		{
+			name: "vertex map",
+			tool: map[string]any{"functionDeclarations": []any{
+				map[string]any{"name": "t", "parameters": map[string]any{"type": "OBJECT"}},
+			}},
+			want:   "vertex",
+			wantOK: true,
+		},
+		{name: "openapi map", tool: map[string]any{"operationId": "t"}, want: "openapi", wantOK: true},
+		{name: "a2a map", tool: map[string]any{"id": "t", "name": "t", "tags": []any{}}, want: "a2a", wantOK: true},
+		{
+			name: "ambiguous map",
+			tool: map[string]any{"name": "t", "inputSchema": map[string]any{}, "input_schema": map[string]any{}},
+		},
+		{name: "bare function map", tool: map[string]any{"name": "t", "parameters": map[string]any{}}},
+		{name: "unknown type", tool: "t"},
+		{name: "nil", tool: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := registry.DetectFormat(tt.tool)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("DetectFormat() = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRegistry_DetectFormat_Unregistered(t *testing.T) {
+	registry := NewRegistry()
+	_ = registry.Register(NewMCPAdapter())
+
+	if got, ok := registry.DetectFormat(&OpenAITool{Type: "function"}); ok {
+		t.Errorf("DetectFormat() = %q, want false for an unregistered format", got)
+	}
+}
+
+func TestRegistry_DetectFormat_Convert(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &AnthropicTool{Name: "lookup", InputSchema: map[string]any{"type": "object"}}
+
+	format, ok := registry.DetectFormat(tool)
+	if !ok {
+		t.Fatal("DetectFormat() = false, want anthropic")
+	}
+	if _, err := registry.Convert(tool, format, "mcp"); err != nil {
+		t.Errorf("Convert(%s -> mcp) error = %v", format, err)
+	}
+}
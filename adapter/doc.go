@@ -29,13 +29,23 @@
 //
 //	openaiTool := result.Tool.(*adapter.OpenAITool)
 //
+// For one-off conversions, or adapters that are not registered, the
+// package-level Convert runs the same pipeline on two adapters directly:
+//
+//	result, err := adapter.Convert(mcpTool, adapter.NewMCPAdapter(), myAdapter)
+//
 // # Supported Formats
 //
-// The package includes adapters for three tool formats:
+// The package includes adapters for these tool formats, among others:
 //
 //   - MCP (Model Context Protocol) - Full JSON Schema 2020-12 support
 //   - OpenAI - Function calling format with strict mode support
 //   - Anthropic - Tool use format with anyOf support
+//   - OpenAPI - OpenAPI 3.1 operations; parameters and the request body
+//     are merged into one input schema, and nullable is written as
+//     type: [X, "null"]
+//   - Vertex AI - Gemini-style function declarations with uppercase
+//     schema type names such as "STRING" and "OBJECT"
 //
 // # Feature Loss Warnings
 //
@@ -51,6 +61,45 @@
 //	    }
 //	}
 //
+// # Conversion Options
+//
+// ConvertWithOptions accepts ConvertOptions to tune a conversion. Adapters
+// that implement OptionsAdapter receive the options; others ignore them:
+//
+//	result, err := registry.ConvertWithOptions(tool, "mcp", "openai",
+//	    adapter.ConvertOptions{PreserveExamples: true})
+//
+// PatternToDescription applies to every target: when the target drops
+// pattern or format, the constraint is appended to the property's
+// description so the model still sees it. ExamplesToDescription does the
+// same for examples, e.g. for Anthropic, which has no examples keyword.
+//
+// SchemaDraft set to Draft07 makes MCP emit draft-07 spellings
+// (definitions, boolean exclusiveMinimum/exclusiveMaximum, an items array
+// with additionalItems for prefixItems) for validators that predate 2020-12.
+// SchemaFromJSON reads either spelling.
+//
+// ValidateEnums warns about enum values that contradict the schema's type,
+// such as strings in an integer enum; DropInvalidEnums also removes them.
+//
+// MaxDepth and MaxProperties report schemas that exceed a provider's size
+// limits as error-severity warnings with Limit set, before an API rejects
+// the tool.
+//
+// Simplify collapses degenerate combinators, such as an anyOf with a single
+// member, before conversion (see SimplifySchema). SortRequired sorts
+// required lists for deterministic output.
+//
+// StripDescriptions removes the tool description and every schema
+// description, for a compact variant of a tool in token-constrained calls.
+//
+// MaxDescriptionLength truncates overlong tool descriptions at a word
+// boundary with an ellipsis and reports an info-severity warning.
+//
+// SanitizeToolNames replaces characters MCP does not allow in tool names,
+// such as "/" and spaces, with "_" and warns about each renamed tool (see
+// model.SanitizeToolName). It only renames tools converted to MCP.
+//
 // # Feature Support Matrix
 //
 //	Feature          MCP    OpenAI  Anthropic
@@ -64,6 +113,36 @@
 //	format           Yes    No      Yes
 //	enum/const       Yes    Yes     Yes
 //	min/max          Yes    Yes     Yes
+//	timeout          Yes    No      No
+//
+// The table above is a summary. SupportMatrix computes the authoritative
+// matrix from the registered adapters at runtime:
+//
+//	fmt.Print(adapter.DefaultRegistry().SupportMatrix())
+//
+// # Output Schemas
+//
+// MCP forwards a tool's output schema on the wire as outputSchema, and Gemini
+// as the declaration's response, filtered like its parameters. OpenAI,
+// Anthropic, OpenAPI, and Vertex AI tool definitions have no output schema
+// field, so those adapters keep the canonical OutputSchema in an
+// OutputSchema field tagged `json:"-"`; Gemini keeps the unfiltered schema
+// there too. It survives in-memory round trips such as mcp → openai → mcp
+// but is never serialized in API requests. Feature-loss warnings for the
+// output schema have InOutput set so callers can tell them apart from
+// input-schema losses.
+//
+// A2A skills have no schema fields at all; A2AAgentSkill keeps the canonical
+// input schema the same way, in an InputSchema field tagged `json:"-"`.
+//
+// Provider-specific metadata that must survive conversion through other
+// formats is namespaced in CanonicalTool.SourceMeta under the adapter name,
+// e.g. SourceMeta["openai"]["strict"]. The OpenAI, Anthropic, Gemini,
+// Vertex AI, OpenAPI, and A2A skill types carry every SourceMeta key their
+// adapter does not own in a SourceMeta field tagged `json:"-"`, and
+// ToCanonical merges its own keys into the carried entries, so
+// openai → anthropic → openai keeps strict. MCP tools have no in-memory
+// field to carry them, so foreign keys do not survive a pass through MCP.
 //
 // # Custom Adapters
 //
@@ -82,6 +161,18 @@
 //	registry.Register(adapter.NewMCPAdapter())
 //	registry.Register(myCustomAdapter)
 //
+// Alias registers friendly names for an adapter; Get and Convert accept them:
+//
+//	registry.Alias("gpt", "openai")
+//
+// Adapters whose support for a feature depends on the schema node, such as
+// pattern only on strings, implement PartialFeatureAdapter; feature-loss
+// detection then asks FeatureSupport for each node instead of SupportsFeature.
+//
+// Adapters whose format describes a whole provider, such as A2A agent cards,
+// implement ProviderAdapter and convert through CanonicalProvider with
+// ConvertProvider.
+//
 // # Type Definitions
 //
 // The package defines local types for OpenAI and Anthropic formats to avoid
@@ -10,6 +10,20 @@ type GeminiFunctionDeclaration struct {
 	Name        string         `json:"name"`
 	Description string         `json:"description,omitempty"`
 	Parameters  map[string]any `json:"parameters,omitempty"`
+
+	// Response describes the function's output, filtered to the Gemini
+	// schema subset like Parameters.
+	Response map[string]any `json:"response,omitempty"`
+
+	// OutputSchema carries the unfiltered canonical output schema for
+	// round-trip conversion. It is kept in memory only and never serialized;
+	// ToCanonical falls back to Response when it is unset.
+	OutputSchema map[string]any `json:"-"`
+
+	// SourceMeta carries SourceMeta entries of other formats (see
+	// CanonicalTool.SourceMeta) for round-trip conversion. It is kept in
+	// memory only and never serialized.
+	SourceMeta map[string]any `json:"-"`
 }
 
 // GeminiTool wraps function declarations in the Gemini tools format.
@@ -25,6 +39,9 @@ func NewGeminiAdapter() *GeminiAdapter {
 	return &GeminiAdapter{}
 }
 
+// geminiMetaKeys are the SourceMeta keys the Gemini adapter owns.
+var geminiMetaKeys = []string{"gemini"}
+
 // Name returns the adapter's identifier.
 func (a *GeminiAdapter) Name() string {
 	return "gemini"
@@ -64,7 +81,10 @@ var geminiFeatures = map[SchemaFeature]bool{
 }
 
 // ToCanonical converts a Gemini function declaration to canonical format.
-// Accepts *GeminiFunctionDeclaration, GeminiFunctionDeclaration, *GeminiTool, or GeminiTool.
+// Accepts *GeminiFunctionDeclaration, GeminiFunctionDeclaration, *GeminiTool,
+// GeminiTool, or a decoded JSON object (map[string]any): one with a
+// "functionDeclarations" key is read as a GeminiTool, anything else as a
+// single declaration.
 func (a *GeminiAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 	if raw == nil {
 		return nil, &ConversionError{
@@ -99,6 +119,16 @@ func (a *GeminiAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 			}
 		}
 		fn = &v.FunctionDeclarations[0]
+	case map[string]any:
+		decoded, err := decodeGeminiMap(v)
+		if err != nil {
+			return nil, &ConversionError{
+				Adapter:   a.Name(),
+				Direction: "to_canonical",
+				Cause:     err,
+			}
+		}
+		return a.ToCanonical(decoded)
 	default:
 		return nil, &ConversionError{
 			Adapter:   a.Name(),
@@ -115,20 +145,67 @@ func (a *GeminiAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 		}
 	}
 
+	return canonicalFromGeminiDeclaration(fn), nil
+}
+
+// ToCanonicalBatch converts every function declaration in a Gemini tool to
+// canonical format, preserving declaration order.
+// Returns an error identifying the first unnamed declaration.
+func (a *GeminiAdapter) ToCanonicalBatch(t *GeminiTool) ([]*CanonicalTool, error) {
+	if t == nil {
+		return nil, &ConversionError{
+			Adapter:   a.Name(),
+			Direction: "to_canonical",
+			Cause:     errors.New("input is nil"),
+		}
+	}
+
+	out := make([]*CanonicalTool, 0, len(t.FunctionDeclarations))
+	for i := range t.FunctionDeclarations {
+		fn := &t.FunctionDeclarations[i]
+		if fn.Name == "" {
+			return nil, &ConversionError{
+				Adapter:   a.Name(),
+				Direction: "to_canonical",
+				Cause:     fmt.Errorf("function declaration %d: function name is required", i),
+			}
+		}
+		out = append(out, canonicalFromGeminiDeclaration(fn))
+	}
+	return out, nil
+}
+
+// decodeGeminiMap decodes a generic JSON object into a *GeminiTool or a
+// *GeminiFunctionDeclaration, depending on its shape.
+func decodeGeminiMap(m map[string]any) (any, error) {
+	if _, ok := m["functionDeclarations"]; ok {
+		var tool GeminiTool
+		return &tool, decodeToolMap(m, &tool)
+	}
+	var fn GeminiFunctionDeclaration
+	return &fn, decodeToolMap(m, &fn)
+}
+
+func canonicalFromGeminiDeclaration(fn *GeminiFunctionDeclaration) *CanonicalTool {
 	inputSchema := schemaFromMap(fn.Parameters)
 	if inputSchema == nil {
 		inputSchema = &JSONSchema{Type: "object"}
 	}
+	output := fn.OutputSchema
+	if output == nil {
+		output = fn.Response
+	}
 
 	ct := &CanonicalTool{
 		Name:         fn.Name,
 		Description:  fn.Description,
 		InputSchema:  inputSchema,
+		OutputSchema: schemaFromMap(output),
 		SourceFormat: "gemini",
 		SourceMeta:   make(map[string]any),
 	}
-
-	return ct, nil
+	restoreCarriedMeta(ct, fn.SourceMeta, geminiMetaKeys)
+	return ct
 }
 
 // FromCanonical converts a canonical tool to Gemini format.
@@ -150,6 +227,39 @@ func (a *GeminiAdapter) FromCanonical(ct *CanonicalTool) (any, error) {
 		}
 	}
 
+	return &GeminiTool{
+		FunctionDeclarations: []GeminiFunctionDeclaration{geminiDeclarationFromCanonical(ct)},
+	}, nil
+}
+
+// FromCanonicalBatch converts canonical tools into a single Gemini tool
+// carrying one function declaration per tool, preserving order.
+// Returns an error identifying the first nil or unnamed tool.
+func (a *GeminiAdapter) FromCanonicalBatch(cts []*CanonicalTool) (*GeminiTool, error) {
+	tool := &GeminiTool{
+		FunctionDeclarations: make([]GeminiFunctionDeclaration, 0, len(cts)),
+	}
+	for i, ct := range cts {
+		if ct == nil {
+			return nil, &ConversionError{
+				Adapter:   a.Name(),
+				Direction: "from_canonical",
+				Cause:     fmt.Errorf("tool %d: canonical tool is nil", i),
+			}
+		}
+		if ct.Name == "" {
+			return nil, &ConversionError{
+				Adapter:   a.Name(),
+				Direction: "from_canonical",
+				Cause:     fmt.Errorf("tool %d: tool name is required", i),
+			}
+		}
+		tool.FunctionDeclarations = append(tool.FunctionDeclarations, geminiDeclarationFromCanonical(ct))
+	}
+	return tool, nil
+}
+
+func geminiDeclarationFromCanonical(ct *CanonicalTool) GeminiFunctionDeclaration {
 	fn := GeminiFunctionDeclaration{
 		Name:        ct.Name,
 		Description: ct.Description,
@@ -161,9 +271,15 @@ func (a *GeminiAdapter) FromCanonical(ct *CanonicalTool) (any, error) {
 		fn.Parameters = map[string]any{"type": "object"}
 	}
 
-	return &GeminiTool{
-		FunctionDeclarations: []GeminiFunctionDeclaration{fn},
-	}, nil
+	// Send the filtered output schema as the response, and carry it
+	// unfiltered for round trips
+	if ct.OutputSchema != nil {
+		fn.Response = filterGeminiSchema(ct.OutputSchema).ToMap()
+		fn.OutputSchema = ct.OutputSchema.ToMap()
+	}
+	fn.SourceMeta = carriedMeta(ct, geminiMetaKeys)
+
+	return fn
 }
 
 // SupportsFeature returns whether this adapter supports a schema feature.
@@ -172,12 +288,120 @@ func (a *GeminiAdapter) SupportsFeature(feature SchemaFeature) bool {
 	return ok && supported
 }
 
+// RewriteNote reports schema features Gemini rewrites instead of dropping.
+// A const is emitted as a single-value enum, an anyOf of one type and null
+// is collapsed to that type with nullable set, and an exclusive bound is
+// emitted as an inclusive minimum or maximum.
+func (a *GeminiAdapter) RewriteNote(feature SchemaFeature, node *JSONSchema) string {
+	if node == nil {
+		return ""
+	}
+	if feature == FeatureConst && node.hasConst() {
+		return "emitted as single-value enum"
+	}
+	if feature == FeatureAnyOf {
+		if branch := geminiNullableBranch(node); branch != nil {
+			return fmt.Sprintf("collapsed to type %s with nullable", branch.Type)
+		}
+	}
+	if _, widened := geminiMinimum(node); feature == FeatureMinimum && widened {
+		return "exclusiveMinimum emitted as inclusive minimum; the bound value is now accepted"
+	}
+	if _, widened := geminiMaximum(node); feature == FeatureMaximum && widened {
+		return "exclusiveMaximum emitted as inclusive maximum; the bound value is now accepted"
+	}
+	return ""
+}
+
+// geminiMinimum returns a copy of the inclusive minimum Gemini is sent for
+// schema, and whether it comes from an exclusiveMinimum that is tighter
+// than any minimum, losing its exclusivity.
+func geminiMinimum(schema *JSONSchema) (*float64, bool) {
+	switch {
+	case schema.ExclusiveMinimum == nil && schema.Minimum == nil:
+		return nil, false
+	case schema.ExclusiveMinimum == nil || (schema.Minimum != nil && *schema.Minimum > *schema.ExclusiveMinimum):
+		v := *schema.Minimum
+		return &v, false
+	default:
+		v := *schema.ExclusiveMinimum
+		return &v, true
+	}
+}
+
+// geminiMaximum is geminiMinimum for the upper bound.
+func geminiMaximum(schema *JSONSchema) (*float64, bool) {
+	switch {
+	case schema.ExclusiveMaximum == nil && schema.Maximum == nil:
+		return nil, false
+	case schema.ExclusiveMaximum == nil || (schema.Maximum != nil && *schema.Maximum < *schema.ExclusiveMaximum):
+		v := *schema.Maximum
+		return &v, false
+	default:
+		v := *schema.ExclusiveMaximum
+		return &v, true
+	}
+}
+
+// geminiNullableBranch returns the non-null member of an anyOf of exactly
+// one typed schema and {type: "null"}, or nil if schema has another shape.
+// Besides the anyOf, schema may only carry a title, description, default,
+// or the same type as the member.
+func geminiNullableBranch(schema *JSONSchema) *JSONSchema {
+	if len(schema.AnyOf) != 2 {
+		return nil
+	}
+	var branch *JSONSchema
+	nulls := 0
+	for _, member := range schema.AnyOf {
+		switch {
+		case member == nil:
+			return nil
+		case member.Equal(&JSONSchema{Type: "null"}):
+			nulls++
+		default:
+			branch = member
+		}
+	}
+	if nulls != 1 || branch.Type == "" || branch.Type == "null" {
+		return nil
+	}
+
+	rest := schema.DeepCopy()
+	rest.AnyOf = nil
+	rest.Title, rest.Description, rest.Default = "", "", nil
+	if rest.Type == branch.Type {
+		rest.Type = ""
+	}
+	if !rest.Equal(&JSONSchema{}) {
+		return nil
+	}
+	return branch
+}
+
 // filterGeminiSchema removes unsupported features from a schema for Gemini.
 func filterGeminiSchema(schema *JSONSchema) *JSONSchema {
 	if schema == nil {
 		return nil
 	}
 
+	// Gemini spells a nullable type with nullable, not an anyOf with null.
+	if branch := geminiNullableBranch(schema); branch != nil {
+		collapsed := filterGeminiSchema(branch)
+		nullable := true
+		collapsed.Nullable = &nullable
+		if schema.Title != "" {
+			collapsed.Title = schema.Title
+		}
+		if schema.Description != "" {
+			collapsed.Description = schema.Description
+		}
+		if schema.Default != nil {
+			collapsed.Default = schema.Default
+		}
+		return collapsed
+	}
+
 	filtered := &JSONSchema{
 		Type:        schema.Type,
 		Title:       schema.Title,
@@ -192,14 +416,9 @@ func filterGeminiSchema(schema *JSONSchema) *JSONSchema {
 		v := *schema.Nullable
 		filtered.Nullable = &v
 	}
-	if schema.Minimum != nil {
-		v := *schema.Minimum
-		filtered.Minimum = &v
-	}
-	if schema.Maximum != nil {
-		v := *schema.Maximum
-		filtered.Maximum = &v
-	}
+	// Gemini has no exclusive bounds; they are sent as inclusive ones.
+	filtered.Minimum, _ = geminiMinimum(schema)
+	filtered.Maximum, _ = geminiMaximum(schema)
 	if schema.MinLength != nil {
 		v := *schema.MinLength
 		filtered.MinLength = &v
@@ -233,7 +452,10 @@ func filterGeminiSchema(schema *JSONSchema) *JSONSchema {
 		filtered.Required = make([]string, len(schema.Required))
 		copy(filtered.Required, schema.Required)
 	}
-	if schema.Enum != nil {
+	// Gemini lacks const; a single-value enum is equivalent.
+	if schema.hasConst() {
+		filtered.Enum = []any{schema.Const}
+	} else if schema.Enum != nil {
 		filtered.Enum = make([]any, len(schema.Enum))
 		copy(filtered.Enum, schema.Enum)
 	}
@@ -1,6 +1,15 @@
 package adapter
 
-import "testing"
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jonwraymond/toolfoundation/model"
+)
 
 func TestNewGeminiAdapter(t *testing.T) {
 	adapter := NewGeminiAdapter()
@@ -108,6 +117,29 @@ func TestGeminiAdapter_ToCanonical_ToolWrapper(t *testing.T) {
 	}
 }
 
+func TestGeminiAdapter_ToCanonical_Map(t *testing.T) {
+	decl := map[string]any{"name": "search", "parameters": map[string]any{"type": "object"}}
+	for name, raw := range map[string]map[string]any{
+		"declaration": decl,
+		"tool":        {"functionDeclarations": []any{decl}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ct, err := NewGeminiAdapter().ToCanonical(raw)
+			if err != nil {
+				t.Fatalf("ToCanonical() error = %v", err)
+			}
+			if ct.Name != "search" || ct.InputSchema.Type != "object" {
+				t.Errorf("ToCanonical() = %+v, want search object tool", ct)
+			}
+		})
+	}
+
+	two := map[string]any{"functionDeclarations": []any{decl, decl}}
+	if _, err := NewGeminiAdapter().ToCanonical(two); err == nil {
+		t.Error("ToCanonical() of a map with two declarations should fail")
+	}
+}
+
 func TestGeminiAdapter_ToCanonical_MultipleFunctionsError(t *testing.T) {
 	adapter := NewGeminiAdapter()
 
@@ -145,3 +177,331 @@ func TestGeminiAdapter_FromCanonical(t *testing.T) {
 		t.Errorf("Name = %q, want %q", tool.FunctionDeclarations[0].Name, "lookup")
 	}
 }
+
+func TestGeminiAdapter_ResponseSchemaRoundTrip(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name:        "lookup",
+			InputSchema: map[string]any{"type": "object"},
+			OutputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"id":   map[string]any{"type": "string", "format": "uuid"},
+					"kind": map[string]any{"oneOf": []any{map[string]any{"type": "string"}, map[string]any{"type": "integer"}}},
+				},
+				"required": []any{"id"},
+			},
+		},
+	}
+
+	result, err := registry.Convert(tool, "mcp", "gemini")
+	if err != nil {
+		t.Fatalf("Convert(mcp -> gemini) error = %v", err)
+	}
+	fn := result.Tool.(*GeminiTool).FunctionDeclarations[0]
+	props, _ := fn.Response["properties"].(map[string]any)
+	if id, _ := props["id"].(map[string]any); id["format"] != "uuid" {
+		t.Errorf("Response.properties.id = %v, want format uuid", props["id"])
+	}
+	if kind, _ := props["kind"].(map[string]any); kind["oneOf"] != nil {
+		t.Errorf("Response.properties.kind = %v, want oneOf filtered out", kind)
+	}
+
+	back, err := registry.Convert(result.Tool, "gemini", "mcp")
+	if err != nil {
+		t.Fatalf("Convert(gemini -> mcp) error = %v", err)
+	}
+	output, _ := back.Tool.(*model.Tool).OutputSchema.(map[string]any)
+	kind, _ := output["properties"].(map[string]any)["kind"].(map[string]any)
+	if kind["oneOf"] == nil {
+		t.Errorf("OutputSchema.properties.kind = %v, want the unfiltered oneOf", kind)
+	}
+}
+
+func TestGeminiAdapter_ToCanonical_Response(t *testing.T) {
+	raw := map[string]any{
+		"name":     "lookup",
+		"response": map[string]any{"type": "object", "properties": map[string]any{"id": map[string]any{"type": "string"}}},
+	}
+
+	ct, err := NewGeminiAdapter().ToCanonical(raw)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	if ct.OutputSchema == nil || ct.OutputSchema.Properties["id"] == nil {
+		t.Errorf("OutputSchema = %+v, want the response schema", ct.OutputSchema)
+	}
+}
+
+func TestGeminiAdapter_ToCanonicalBatch(t *testing.T) {
+	adapter := NewGeminiAdapter()
+	tool := &GeminiTool{
+		FunctionDeclarations: []GeminiFunctionDeclaration{
+			{Name: "first", Description: "First", Parameters: map[string]any{"type": "object"}},
+			{Name: "second"},
+			{Name: "third", Parameters: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"q": map[string]any{"type": "string"}},
+			}},
+		},
+	}
+
+	cts, err := adapter.ToCanonicalBatch(tool)
+	if err != nil {
+		t.Fatalf("ToCanonicalBatch() error = %v", err)
+	}
+	if len(cts) != 3 {
+		t.Fatalf("ToCanonicalBatch() len = %d, want 3", len(cts))
+	}
+	for i, want := range []string{"first", "second", "third"} {
+		if cts[i].Name != want {
+			t.Errorf("cts[%d].Name = %q, want %q", i, cts[i].Name, want)
+		}
+		if cts[i].InputSchema == nil {
+			t.Errorf("cts[%d].InputSchema = nil, want default object schema", i)
+		}
+	}
+	if cts[2].InputSchema.Properties["q"] == nil {
+		t.Error("cts[2] lost property q")
+	}
+}
+
+func TestGeminiAdapter_ToCanonicalBatch_UnnamedDeclaration(t *testing.T) {
+	adapter := NewGeminiAdapter()
+	tool := &GeminiTool{
+		FunctionDeclarations: []GeminiFunctionDeclaration{{Name: "ok"}, {Description: "no name"}},
+	}
+
+	_, err := adapter.ToCanonicalBatch(tool)
+	var convErr *ConversionError
+	if !errors.As(err, &convErr) {
+		t.Fatalf("ToCanonicalBatch() error = %v, want *ConversionError", err)
+	}
+	if !strings.Contains(err.Error(), "declaration 1") {
+		t.Errorf("ToCanonicalBatch() error = %q, want index of bad declaration", err)
+	}
+
+	if _, err := adapter.ToCanonicalBatch(nil); err == nil {
+		t.Error("ToCanonicalBatch(nil) error = nil, want error")
+	}
+}
+
+func TestGeminiAdapter_FromCanonicalBatch_RoundTrip(t *testing.T) {
+	adapter := NewGeminiAdapter()
+	cts := []*CanonicalTool{
+		{Name: "alpha", Description: "A", InputSchema: &JSONSchema{Type: "object"}},
+		{Name: "beta", InputSchema: &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{"n": {Type: "integer"}}}},
+	}
+
+	tool, err := adapter.FromCanonicalBatch(cts)
+	if err != nil {
+		t.Fatalf("FromCanonicalBatch() error = %v", err)
+	}
+	if len(tool.FunctionDeclarations) != 2 {
+		t.Fatalf("FunctionDeclarations len = %d, want 2", len(tool.FunctionDeclarations))
+	}
+
+	back, err := adapter.ToCanonicalBatch(tool)
+	if err != nil {
+		t.Fatalf("ToCanonicalBatch() error = %v", err)
+	}
+	for i, ct := range cts {
+		if back[i].Name != ct.Name || back[i].Description != ct.Description {
+			t.Errorf("back[%d] = %q/%q, want %q/%q", i, back[i].Name, back[i].Description, ct.Name, ct.Description)
+		}
+	}
+	if back[1].InputSchema.Properties["n"].Type != "integer" {
+		t.Error("round trip lost property n")
+	}
+}
+
+func TestGeminiAdapter_FromCanonicalBatch_UnnamedTool(t *testing.T) {
+	adapter := NewGeminiAdapter()
+
+	_, err := adapter.FromCanonicalBatch([]*CanonicalTool{{Name: "ok"}, {}})
+	if err == nil || !strings.Contains(err.Error(), "tool 1") {
+		t.Errorf("FromCanonicalBatch() error = %v, want error for tool 1", err)
+	}
+
+	_, err = adapter.FromCanonicalBatch([]*CanonicalTool{nil})
+	if err == nil {
+		t.Error("FromCanonicalBatch() with nil tool error = nil, want error")
+	}
+}
+
+func TestGeminiAdapter_ConstRewrittenAsEnum(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "fixed",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"mode": map[string]any{"type": "string", "const": "fixed"},
+				},
+			},
+		},
+	}
+
+	result, err := registry.Convert(tool, "mcp", "gemini")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	fn := result.Tool.(*GeminiTool).FunctionDeclarations[0]
+	mode := fn.Parameters["properties"].(map[string]any)["mode"].(map[string]any)
+	if _, ok := mode["const"]; ok {
+		t.Error("mode.const should not be emitted for Gemini")
+	}
+	enum, ok := mode["enum"].([]any)
+	if !ok || len(enum) != 1 || enum[0] != "fixed" {
+		t.Errorf("mode.enum = %v, want [fixed]", mode["enum"])
+	}
+
+	if len(result.Warnings) != 1 {
+		t.Fatalf("Warnings = %v, want one rewrite warning", result.Warnings)
+	}
+	w := result.Warnings[0]
+	if w.Feature != FeatureConst || !w.Rewritten || w.Severity != SeverityInfo || w.Path != "/properties/mode" {
+		t.Errorf("Warning = %+v, want info-level const rewrite at /properties/mode", w)
+	}
+	if !strings.Contains(w.String(), "rewritten") {
+		t.Errorf("String() = %q, want rewrite wording", w.String())
+	}
+	if got := result.FidelityScore(); got != 100 {
+		t.Errorf("FidelityScore() = %d, want 100 for a lossless rewrite", got)
+	}
+}
+
+func TestGeminiAdapter_ExclusiveBoundsRewrittenAsInclusive(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "rate",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"score": map[string]any{"type": "number", "exclusiveMinimum": 0.0, "exclusiveMaximum": 10.0},
+					"count": map[string]any{"type": "integer", "minimum": 5.0, "exclusiveMinimum": 1.0},
+				},
+			},
+			OutputSchema: map[string]any{"type": "number", "exclusiveMaximum": 1.0},
+		},
+	}
+
+	result, err := registry.Convert(tool, "mcp", "gemini")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	fn := result.Tool.(*GeminiTool).FunctionDeclarations[0]
+	props := fn.Parameters["properties"].(map[string]any)
+	for _, schema := range []map[string]any{props["score"].(map[string]any), props["count"].(map[string]any), fn.Response} {
+		for _, key := range []string{"exclusiveMinimum", "exclusiveMaximum"} {
+			if _, ok := schema[key]; ok {
+				t.Errorf("%s should not be emitted for Gemini: %v", key, schema)
+			}
+		}
+	}
+	if score := props["score"].(map[string]any); score["minimum"] != 0.0 || score["maximum"] != 10.0 {
+		t.Errorf("score = %v, want minimum 0 and maximum 10", score)
+	}
+	if count := props["count"].(map[string]any); count["minimum"] != 5.0 {
+		t.Errorf("count.minimum = %v, want the tighter inclusive 5", count["minimum"])
+	}
+	if fn.Response["maximum"] != 1.0 {
+		t.Errorf("response.maximum = %v, want 1", fn.Response["maximum"])
+	}
+
+	var got []string
+	for _, w := range result.Warnings {
+		if !w.Rewritten || w.Severity != SeverityInfo {
+			t.Errorf("Warning = %+v, want an info-level rewrite", w)
+		}
+		got = append(got, w.Feature.String()+" "+w.Path)
+	}
+	want := []string{"minimum /properties/score", "maximum /properties/score", "maximum "}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Warnings = %v, want %v", got, want)
+	}
+}
+
+func TestGeminiAdapter_AnyOfNullCollapsedToNullable(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "lookup",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"cursor": map[string]any{
+						"description": "Page cursor",
+						"anyOf": []any{
+							map[string]any{"type": "string", "minLength": 1},
+							map[string]any{"type": "null"},
+						},
+					},
+					"mixed": map[string]any{
+						"anyOf": []any{
+							map[string]any{"type": "string"},
+							map[string]any{"type": "integer"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := registry.Convert(tool, "mcp", "gemini")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	props := result.Tool.(*GeminiTool).FunctionDeclarations[0].Parameters["properties"].(map[string]any)
+	cursor := props["cursor"].(map[string]any)
+	if _, ok := cursor["anyOf"]; ok {
+		t.Error("cursor.anyOf should be collapsed")
+	}
+	if cursor["type"] != "string" || cursor["nullable"] != true || cursor["minLength"] != 1 || cursor["description"] != "Page cursor" {
+		t.Errorf("cursor = %v, want nullable string keeping minLength and description", cursor)
+	}
+	if _, ok := props["mixed"].(map[string]any)["anyOf"]; !ok {
+		t.Error("mixed.anyOf should be kept")
+	}
+
+	if len(result.Warnings) != 1 {
+		t.Fatalf("Warnings = %v, want one rewrite warning", result.Warnings)
+	}
+	w := result.Warnings[0]
+	if w.Feature != FeatureAnyOf || !w.Rewritten || w.Severity != SeverityInfo || w.Path != "/properties/cursor" {
+		t.Errorf("Warning = %+v, want info-level anyOf rewrite at /properties/cursor", w)
+	}
+}
+
+func TestGeminiNullableBranch(t *testing.T) {
+	str := &JSONSchema{Type: "string"}
+	null := &JSONSchema{Type: "null"}
+	tests := []struct {
+		name   string
+		schema *JSONSchema
+		want   *JSONSchema
+	}{
+		{name: "type then null", schema: &JSONSchema{AnyOf: []*JSONSchema{str, null}}, want: str},
+		{name: "null then type", schema: &JSONSchema{AnyOf: []*JSONSchema{null, str}}, want: str},
+		{name: "matching parent type", schema: &JSONSchema{Type: "string", AnyOf: []*JSONSchema{str, null}}, want: str},
+		{name: "two types", schema: &JSONSchema{AnyOf: []*JSONSchema{str, {Type: "integer"}}}},
+		{name: "three members", schema: &JSONSchema{AnyOf: []*JSONSchema{str, null, {Type: "integer"}}}},
+		{name: "untyped member", schema: &JSONSchema{AnyOf: []*JSONSchema{{Ref: "#/$defs/x"}, null}}},
+		{name: "null with constraints", schema: &JSONSchema{AnyOf: []*JSONSchema{str, {Type: "null", Title: "none"}}}},
+		{name: "parent constraints", schema: &JSONSchema{MinLength: intPtr(1), AnyOf: []*JSONSchema{str, null}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := geminiNullableBranch(tt.schema); got != tt.want {
+				t.Errorf("geminiNullableBranch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
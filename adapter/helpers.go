@@ -1,5 +1,17 @@
 package adapter
 
+import "encoding/json"
+
+// decodeToolMap decodes a generic JSON object, such as one produced by
+// json.Unmarshal into any, into the typed tool value out.
+func decodeToolMap(m map[string]any, out any) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
 func canonicalDescription(ct *CanonicalTool) string {
 	if ct == nil {
 		return ""
@@ -10,7 +22,7 @@ func canonicalDescription(ct *CanonicalTool) string {
 	if ct.Summary != "" {
 		return ct.Summary
 	}
-	if ct.DisplayName != "" {
+	if ct.DisplayName != "" && !ct.descriptionsStripped {
 		return ct.DisplayName
 	}
 	return ""
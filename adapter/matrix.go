@@ -0,0 +1,55 @@
+package adapter
+
+import (
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// SupportMatrixType maps adapter names to the schema features each adapter
+// supports. It is computed by AdapterRegistry.SupportMatrix.
+type SupportMatrixType map[string]map[SchemaFeature]bool
+
+// SupportMatrix queries every registered adapter's SupportsFeature across
+// AllFeatures and returns the resulting matrix.
+func (r *AdapterRegistry) SupportMatrix() SupportMatrixType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	features := AllFeatures()
+	matrix := make(SupportMatrixType, len(r.adapters))
+	for name, a := range r.adapters {
+		row := make(map[SchemaFeature]bool, len(features))
+		for _, f := range features {
+			row[f] = a.SupportsFeature(f)
+		}
+		matrix[name] = row
+	}
+	return matrix
+}
+
+// String renders the matrix as an aligned text table with one row per
+// feature and one column per adapter, adapters sorted by name.
+func (m SupportMatrixType) String() string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	w.Write([]byte("Feature\t" + strings.Join(names, "\t") + "\n"))
+	for _, f := range AllFeatures() {
+		cells := make([]string, len(names))
+		for i, name := range names {
+			cells[i] = "No"
+			if m[name][f] {
+				cells[i] = "Yes"
+			}
+		}
+		w.Write([]byte(f.String() + "\t" + strings.Join(cells, "\t") + "\n"))
+	}
+	w.Flush()
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package adapter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAdapterRegistry_SupportMatrix(t *testing.T) {
+	registry := DefaultRegistry()
+	matrix := registry.SupportMatrix()
+
+	if len(matrix) != len(registry.List()) {
+		t.Fatalf("SupportMatrix() has %d adapters, want %d", len(matrix), len(registry.List()))
+	}
+	for _, name := range registry.List() {
+		a, _ := registry.Get(name)
+		row, ok := matrix[name]
+		if !ok {
+			t.Fatalf("SupportMatrix() missing adapter %q", name)
+		}
+		for _, f := range AllFeatures() {
+			if row[f] != a.SupportsFeature(f) {
+				t.Errorf("matrix[%q][%s] = %v, want %v", name, f, row[f], a.SupportsFeature(f))
+			}
+		}
+	}
+}
+
+func TestAdapterRegistry_SupportMatrix_Empty(t *testing.T) {
+	matrix := NewRegistry().SupportMatrix()
+	if len(matrix) != 0 {
+		t.Errorf("SupportMatrix() = %v, want empty", matrix)
+	}
+}
+
+func TestSupportMatrixType_String(t *testing.T) {
+	matrix := SupportMatrixType{
+		"openai": {FeaturePattern: false, FeatureEnum: true},
+		"mcp":    {FeaturePattern: true, FeatureEnum: true},
+	}
+
+	lines := strings.Split(strings.TrimRight(matrix.String(), "\n"), "\n")
+	if len(lines) != len(AllFeatures())+1 {
+		t.Fatalf("String() has %d lines, want %d", len(lines), len(AllFeatures())+1)
+	}
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "Feature mcp openai" {
+		t.Errorf("header = %q, want adapters sorted by name", lines[0])
+	}
+
+	col := strings.Index(lines[0], "openai")
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			t.Fatalf("row %q has %d fields, want 3", line, len(fields))
+		}
+		if line[col:col+len(fields[2])] != fields[2] {
+			t.Errorf("row %q is not aligned with header", line)
+		}
+		switch fields[0] {
+		case "pattern":
+			if fields[1] != "Yes" || fields[2] != "No" {
+				t.Errorf("pattern row = %q, want Yes/No", line)
+			}
+		case "$ref":
+			if fields[1] != "No" || fields[2] != "No" {
+				t.Errorf("$ref row = %q, want No/No for unlisted feature", line)
+			}
+		}
+	}
+}
@@ -1,8 +1,14 @@
 package adapter
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
@@ -24,7 +30,8 @@ func (a *MCPAdapter) Name() string {
 }
 
 // ToCanonical converts an MCP tool to the canonical format.
-// Accepts *model.Tool, model.Tool, *mcp.Tool, or mcp.Tool.
+// Accepts *model.Tool, model.Tool, *mcp.Tool, mcp.Tool, or a decoded JSON
+// object (map[string]any) in the MCP tool shape.
 func (a *MCPAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 	if raw == nil {
 		return nil, &ConversionError{
@@ -45,6 +52,16 @@ func (a *MCPAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 		tool = &model.Tool{Tool: *v}
 	case mcp.Tool:
 		tool = &model.Tool{Tool: v}
+	case map[string]any:
+		var tool model.Tool
+		if err := decodeToolMap(v, &tool); err != nil {
+			return nil, &ConversionError{
+				Adapter:   a.Name(),
+				Direction: "to_canonical",
+				Cause:     err,
+			}
+		}
+		return a.ToCanonical(&tool)
 	default:
 		return nil, &ConversionError{
 			Adapter:   a.Name(),
@@ -135,9 +152,18 @@ func (a *MCPAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 		if requirements := securityRequirementsFromAny(tool.Meta["securityRequirements"]); len(requirements) > 0 {
 			ct.SecurityRequirements = requirements
 		}
+		if scopes := stringSliceFromAny(tool.Meta["requiredScopes"]); len(scopes) > 0 {
+			ct.RequiredScopes = scopes
+		}
+		if len(ct.SecurityRequirements) == 0 {
+			ct.SecurityRequirements = requirementsFromScopes(ct.SecuritySchemes, ct.RequiredScopes)
+		}
 		if hints, ok := tool.Meta["uiHints"].(map[string]any); ok && len(hints) > 0 {
 			ct.UIHints = hints
 		}
+		if timeout, ok := durationFromAny(tool.Meta["timeout"]); ok {
+			ct.Timeout = timeout
+		}
 	}
 
 	// Preserve MCP-specific fields in SourceMeta for round-trip
@@ -159,6 +185,13 @@ func (a *MCPAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 
 // FromCanonical converts a canonical tool to model.Tool.
 func (a *MCPAdapter) FromCanonical(ct *CanonicalTool) (any, error) {
+	return a.FromCanonicalWithOptions(ct, ConvertOptions{})
+}
+
+// FromCanonicalWithOptions converts a canonical tool to model.Tool, honoring
+// opts. Schemas are written in the dialect selected by opts.SchemaDraft, and
+// the name is sanitized when opts.SanitizeToolNames is set.
+func (a *MCPAdapter) FromCanonicalWithOptions(ct *CanonicalTool, opts ConvertOptions) (any, error) {
 	if ct == nil {
 		return nil, &ConversionError{
 			Adapter:   a.Name(),
@@ -175,9 +208,14 @@ func (a *MCPAdapter) FromCanonical(ct *CanonicalTool) (any, error) {
 		}
 	}
 
+	name := ct.Name
+	if opts.SanitizeToolNames {
+		name = model.SanitizeToolName(name)
+	}
+
 	tool := &model.Tool{
 		Tool: mcp.Tool{
-			Name:        ct.Name,
+			Name:        name,
 			Description: ct.Description,
 		},
 		Namespace: ct.Namespace,
@@ -187,12 +225,12 @@ func (a *MCPAdapter) FromCanonical(ct *CanonicalTool) (any, error) {
 
 	// Convert InputSchema
 	if ct.InputSchema != nil {
-		tool.InputSchema = ct.InputSchema.ToMap()
+		tool.InputSchema = ct.InputSchema.ToMapForDraft(opts.SchemaDraft)
 	}
 
 	// Convert OutputSchema
 	if ct.OutputSchema != nil {
-		tool.OutputSchema = ct.OutputSchema.ToMap()
+		tool.OutputSchema = ct.OutputSchema.ToMapForDraft(opts.SchemaDraft)
 	}
 
 	// Restore MCP-specific fields from SourceMeta
@@ -201,9 +239,9 @@ func (a *MCPAdapter) FromCanonical(ct *CanonicalTool) (any, error) {
 			tool.Title = title
 		}
 		if meta, ok := ct.SourceMeta["meta"].(mcp.Meta); ok {
-			tool.Meta = meta
+			tool.Meta = maps.Clone(meta)
 		} else if metaMap, ok := ct.SourceMeta["meta"].(map[string]any); ok {
-			tool.Meta = mcp.Meta(metaMap)
+			tool.Meta = mcp.Meta(maps.Clone(metaMap))
 		}
 		if annotations, ok := ct.SourceMeta["annotations"].(*mcp.ToolAnnotations); ok {
 			tool.Annotations = cloneAnnotations(annotations)
@@ -258,14 +296,24 @@ func (a *MCPAdapter) FromCanonical(ct *CanonicalTool) (any, error) {
 		tool.Meta["securitySchemes"] = ct.SecuritySchemes
 		metaSet = true
 	}
-	if len(ct.SecurityRequirements) > 0 {
+	// Requirements that ToCanonical derives from requiredScopes are left
+	// out, so they are derived again instead of appearing in _meta.
+	if len(ct.SecurityRequirements) > 0 && !reflect.DeepEqual(ct.SecurityRequirements, requirementsFromScopes(ct.SecuritySchemes, ct.RequiredScopes)) {
 		tool.Meta["securityRequirements"] = ct.SecurityRequirements
 		metaSet = true
 	}
+	if len(ct.RequiredScopes) > 0 {
+		tool.Meta["requiredScopes"] = ct.RequiredScopes
+		metaSet = true
+	}
 	if len(ct.UIHints) > 0 {
 		tool.Meta["uiHints"] = ct.UIHints
 		metaSet = true
 	}
+	if ct.Timeout > 0 {
+		tool.Meta["timeout"] = ct.Timeout.String()
+		metaSet = true
+	}
 	if !metaSet && len(tool.Meta) == 0 {
 		tool.Meta = nil
 	}
@@ -280,6 +328,12 @@ func (a *MCPAdapter) SupportsFeature(feature SchemaFeature) bool {
 	return true
 }
 
+// SupportsFeatureWithOptions returns whether a schema feature survives
+// conversion to MCP. Every feature does, in either schema draft.
+func (a *MCPAdapter) SupportsFeatureWithOptions(feature SchemaFeature, _ ConvertOptions) bool {
+	return a.SupportsFeature(feature)
+}
+
 // schemaFromAny converts any schema representation to *JSONSchema.
 // Accepts map[string]any, *JSONSchema, or JSONSchema.
 func schemaFromAny(schema any) (*JSONSchema, error) {
@@ -299,6 +353,87 @@ func schemaFromAny(schema any) (*JSONSchema, error) {
 	}
 }
 
+// ErrUnknownSchemaType is returned by SchemaFromMapStrict when a schema's
+// type is not one of the JSON Schema type names.
+var ErrUnknownSchemaType = errors.New("unknown schema type")
+
+// jsonSchemaTypes are the type names defined by JSON Schema.
+var jsonSchemaTypes = map[string]bool{
+	"object":  true,
+	"array":   true,
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+	"null":    true,
+}
+
+// SchemaFromMapStrict converts a decoded JSON Schema object like the lenient
+// conversion adapters use, but rejects type names outside the JSON Schema
+// set, such as the typo "objet". A type must be a name or an array of
+// names; every bad type is reported with its JSON pointer path in m,
+// wrapping ErrUnknownSchemaType.
+func SchemaFromMapStrict(m map[string]any) (*JSONSchema, error) {
+	if err := errors.Join(schemaTypeErrors(m, "")...); err != nil {
+		return nil, err
+	}
+	return schemaFromMap(m), nil
+}
+
+// schemaTypeErrors checks the type keyword of m and of every subschema
+// schemaFromMap reads, returning one error per bad type.
+func schemaTypeErrors(m map[string]any, path string) []error {
+	var errs []error
+	at := path
+	if at == "" {
+		at = "/"
+	}
+	switch t := m["type"].(type) {
+	case nil:
+	case string:
+		if !jsonSchemaTypes[t] {
+			errs = append(errs, fmt.Errorf("%w %q at %s", ErrUnknownSchemaType, t, at))
+		}
+	case []string:
+		for i, name := range t {
+			if !jsonSchemaTypes[name] {
+				errs = append(errs, fmt.Errorf("%w %q at %s", ErrUnknownSchemaType, name, joinJSONPath(path, "type", indexPath(i))))
+			}
+		}
+	case []any:
+		for i, item := range t {
+			if name, ok := item.(string); !ok || !jsonSchemaTypes[name] {
+				errs = append(errs, fmt.Errorf("%w %#v at %s", ErrUnknownSchemaType, item, joinJSONPath(path, "type", indexPath(i))))
+			}
+		}
+	default:
+		errs = append(errs, fmt.Errorf("%w %#v at %s: type must be a string or an array", ErrUnknownSchemaType, t, at))
+	}
+
+	for _, keyword := range []string{"properties", "$defs", "definitions"} {
+		children, _ := m[keyword].(map[string]any)
+		for _, k := range slices.Sorted(maps.Keys(children)) {
+			if child, ok := children[k].(map[string]any); ok {
+				errs = append(errs, schemaTypeErrors(child, joinJSONPath(path, keyword, k))...)
+			}
+		}
+	}
+	for _, keyword := range []string{"items", "prefixItems", "anyOf", "oneOf", "allOf"} {
+		list, _ := m[keyword].([]any)
+		for i, item := range list {
+			if child, ok := item.(map[string]any); ok {
+				errs = append(errs, schemaTypeErrors(child, joinJSONPath(path, keyword, indexPath(i)))...)
+			}
+		}
+	}
+	for _, keyword := range []string{"items", "additionalItems", "contains", "not", "if", "then", "else", "additionalProperties"} {
+		if child, ok := m[keyword].(map[string]any); ok {
+			errs = append(errs, schemaTypeErrors(child, joinJSONPath(path, keyword))...)
+		}
+	}
+	return errs
+}
+
 // schemaFromMap converts a map[string]any to *JSONSchema.
 func schemaFromMap(m map[string]any) *JSONSchema {
 	if m == nil {
@@ -325,11 +460,15 @@ func schemaFromMap(m map[string]any) *JSONSchema {
 	}
 	if v, ok := m["$ref"].(string); ok {
 		s.Ref = v
+		if strings.HasPrefix(v, "#/definitions/") {
+			s.Ref = "#/$defs/" + strings.TrimPrefix(v, "#/definitions/")
+		}
 	}
 
 	// Any fields
 	if v, ok := m["const"]; ok {
 		s.Const = v
+		s.HasConst = true
 	}
 	if v, ok := m["default"]; ok {
 		s.Default = v
@@ -354,6 +493,8 @@ func schemaFromMap(m map[string]any) *JSONSchema {
 	if v, ok := asFloat(m["maximum"]); ok {
 		s.Maximum = &v
 	}
+	s.ExclusiveMinimum = exclusiveBound(m, "exclusiveMinimum", &s.Minimum)
+	s.ExclusiveMaximum = exclusiveBound(m, "exclusiveMaximum", &s.Maximum)
 	if v, ok := m["minLength"]; ok {
 		if i, ok := asInt(v); ok {
 			s.MinLength = &i
@@ -374,6 +515,16 @@ func schemaFromMap(m map[string]any) *JSONSchema {
 			s.MaxItems = &i
 		}
 	}
+	if v, ok := m["minContains"]; ok {
+		if i, ok := asInt(v); ok {
+			s.MinContains = &i
+		}
+	}
+	if v, ok := m["maxContains"]; ok {
+		if i, ok := asInt(v); ok {
+			s.MaxContains = &i
+		}
+	}
 	if v, ok := m["minProperties"]; ok {
 		if i, ok := asInt(v); ok {
 			s.MinProperties = &i
@@ -417,6 +568,7 @@ func schemaFromMap(m map[string]any) *JSONSchema {
 	if v, ok := m["required"].([]string); ok {
 		s.Required = v
 	}
+	s.Required = dedupeRequired(s.Required)
 	if v, ok := m["enum"].([]any); ok {
 		s.Enum = v
 	}
@@ -436,18 +588,40 @@ func schemaFromMap(m map[string]any) *JSONSchema {
 			}
 		}
 	}
-	if v, ok := m["$defs"].(map[string]any); ok {
-		s.Defs = make(map[string]*JSONSchema, len(v))
+	for _, keyword := range []string{"$defs", "definitions"} {
+		v, ok := m[keyword].(map[string]any)
+		if !ok {
+			continue
+		}
+		if s.Defs == nil {
+			s.Defs = make(map[string]*JSONSchema, len(v))
+		}
 		for k, def := range v {
+			if _, exists := s.Defs[k]; exists {
+				continue
+			}
 			if defMap, ok := def.(map[string]any); ok {
 				s.Defs[k] = schemaFromMap(defMap)
 			}
 		}
 	}
 
-	// Items
-	if v, ok := m["items"].(map[string]any); ok {
+	// Items, reading the draft-07 tuple form (an items array followed by
+	// additionalItems) as prefixItems and items
+	switch v := m["items"].(type) {
+	case map[string]any:
 		s.Items = schemaFromMap(v)
+	case []any:
+		s.PrefixItems = schemaListFromAny(v)
+		if additional, ok := m["additionalItems"].(map[string]any); ok {
+			s.Items = schemaFromMap(additional)
+		}
+	}
+	if v, ok := m["prefixItems"].([]any); ok {
+		s.PrefixItems = schemaListFromAny(v)
+	}
+	if v, ok := m["contains"].(map[string]any); ok {
+		s.Contains = schemaFromMap(v)
 	}
 
 	// Combinators
@@ -478,10 +652,71 @@ func schemaFromMap(m map[string]any) *JSONSchema {
 	if v, ok := m["not"].(map[string]any); ok {
 		s.Not = schemaFromMap(v)
 	}
+	if v, ok := m["if"].(map[string]any); ok {
+		s.If = schemaFromMap(v)
+	}
+	if v, ok := m["then"].(map[string]any); ok {
+		s.Then = schemaFromMap(v)
+	}
+	if v, ok := m["else"].(map[string]any); ok {
+		s.Else = schemaFromMap(v)
+	}
+	if v, ok := m["additionalProperties"].(map[string]any); ok {
+		s.AdditionalPropertiesSchema = schemaFromMap(v)
+	}
 
 	return s
 }
 
+// schemaListFromAny converts the schema objects in list, skipping anything
+// else.
+func schemaListFromAny(list []any) []*JSONSchema {
+	out := make([]*JSONSchema, 0, len(list))
+	for _, item := range list {
+		if itemMap, ok := item.(map[string]any); ok {
+			out = append(out, schemaFromMap(itemMap))
+		}
+	}
+	return out
+}
+
+// dedupeRequired returns required without repeated names, keeping the first
+// occurrence of each. The input slice is not modified.
+func dedupeRequired(required []string) []string {
+	if len(required) == 0 {
+		return required
+	}
+	seen := make(map[string]bool, len(required))
+	out := make([]string, 0, len(required))
+	for _, name := range required {
+		if !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// exclusiveBound reads an exclusive numeric bound in either spelling: the
+// 2020-12 number, or the draft-07 boolean flag that makes the inclusive
+// bound exclusive. A true flag moves the inclusive bound into the result.
+func exclusiveBound(m map[string]any, keyword string, inclusive **float64) *float64 {
+	switch v := m[keyword].(type) {
+	case bool:
+		if !v || *inclusive == nil {
+			return nil
+		}
+		bound := **inclusive
+		*inclusive = nil
+		return &bound
+	default:
+		if f, ok := asFloat(v); ok {
+			return &f
+		}
+		return nil
+	}
+}
+
 func asFloat(v any) (float64, bool) {
 	switch t := v.(type) {
 	case float64:
@@ -588,6 +823,51 @@ func stringSliceFromAny(v any) []string {
 	}
 }
 
+// durationFromAny reads a positive duration given as a Go duration string
+// (e.g., "30s") or as a number of milliseconds.
+func durationFromAny(v any) (time.Duration, bool) {
+	var d time.Duration
+	switch t := v.(type) {
+	case string:
+		parsed, err := time.ParseDuration(t)
+		if err != nil {
+			return 0, false
+		}
+		d = parsed
+	case float64:
+		d = time.Duration(t * float64(time.Millisecond))
+	case int:
+		d = time.Duration(t) * time.Millisecond
+	case int64:
+		d = time.Duration(t) * time.Millisecond
+	case json.Number:
+		ms, err := t.Float64()
+		if err != nil {
+			return 0, false
+		}
+		d = time.Duration(ms * float64(time.Millisecond))
+	default:
+		return 0, false
+	}
+	if d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// requirementsFromScopes derives a security requirement when a tool lists
+// required scopes and defines exactly one security scheme, so the scopes are
+// unambiguously granted by that scheme. It returns nil otherwise.
+func requirementsFromScopes(schemes map[string]SecurityScheme, scopes []string) []SecurityRequirement {
+	if len(scopes) == 0 || len(schemes) != 1 {
+		return nil
+	}
+	for name := range schemes {
+		return []SecurityRequirement{{name: append([]string(nil), scopes...)}}
+	}
+	return nil
+}
+
 func securitySchemesFromAny(v any) map[string]SecurityScheme {
 	switch t := v.(type) {
 	case map[string]SecurityScheme:
@@ -1,8 +1,12 @@
 package adapter
 
 import (
+	"encoding/json"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
@@ -150,6 +154,29 @@ func TestMCPAdapter_ToCanonical(t *testing.T) {
 	}
 }
 
+func TestMCPAdapter_ToCanonical_Map(t *testing.T) {
+	raw := map[string]any{
+		"name":        "search",
+		"description": "Search things",
+		"inputSchema": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"query": map[string]any{"type": "string"}},
+		},
+		"namespace": "docs",
+	}
+
+	ct, err := NewMCPAdapter().ToCanonical(raw)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	if ct.Name != "search" || ct.Namespace != "docs" || ct.InputSchema.Properties["query"] == nil {
+		t.Errorf("ToCanonical() = %+v, want name, namespace, and query property", ct)
+	}
+	if _, err := NewMCPAdapter().ToCanonical(map[string]any{"name": 42}); err == nil {
+		t.Error("ToCanonical() with a mistyped map should fail")
+	}
+}
+
 func TestMCPAdapter_ToCanonical_SchemaConversion(t *testing.T) {
 	adapter := NewMCPAdapter()
 
@@ -643,6 +670,292 @@ func TestMCPAdapter_RoundTrip(t *testing.T) {
 	}
 }
 
+func TestMCPAdapter_ToCanonical_BuilderSecurity(t *testing.T) {
+	tool := model.NewTool("secure").
+		InputSchema(map[string]any{"type": "object"}).
+		DefineSecurityScheme("bearerAuth", map[string]any{"type": "http", "scheme": "bearer"}).
+		RequireSecurity("bearerAuth", "read").
+		MustBuild()
+
+	ct, err := NewMCPAdapter().ToCanonical(tool)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	if ct.SecuritySchemes["bearerAuth"]["scheme"] != "bearer" {
+		t.Errorf("SecuritySchemes = %v, want bearerAuth bearer scheme", ct.SecuritySchemes)
+	}
+	if len(ct.SecurityRequirements) != 1 {
+		t.Fatalf("SecurityRequirements length = %d, want 1", len(ct.SecurityRequirements))
+	}
+	if scopes := ct.SecurityRequirements[0]["bearerAuth"]; len(scopes) != 1 || scopes[0] != "read" {
+		t.Errorf("SecurityRequirements[0][bearerAuth] = %v, want [read]", scopes)
+	}
+}
+
+func TestMCPAdapter_ToCanonical_BuilderDiscoveryMeta(t *testing.T) {
+	tool := model.NewTool("search").
+		InputSchema(map[string]any{"type": "object"}).
+		Summary("Search documents").
+		Category("retrieval").
+		InputModes("application/json").
+		OutputModes("text/plain").
+		Examples("find the latest report").
+		MustBuild()
+
+	ct, err := NewMCPAdapter().ToCanonical(tool)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	if ct.Summary != "Search documents" {
+		t.Errorf("Summary = %q, want %q", ct.Summary, "Search documents")
+	}
+	if ct.Category != "retrieval" {
+		t.Errorf("Category = %q, want %q", ct.Category, "retrieval")
+	}
+	if len(ct.InputModes) != 1 || ct.InputModes[0] != "application/json" {
+		t.Errorf("InputModes = %v, want [application/json]", ct.InputModes)
+	}
+	if len(ct.OutputModes) != 1 || ct.OutputModes[0] != "text/plain" {
+		t.Errorf("OutputModes = %v, want [text/plain]", ct.OutputModes)
+	}
+	if len(ct.Examples) != 1 || ct.Examples[0] != "find the latest report" {
+		t.Errorf("Examples = %v, want [find the latest report]", ct.Examples)
+	}
+}
+
+func TestMCPAdapter_ToCanonical_BuilderHints(t *testing.T) {
+	tests := []struct {
+		name          string
+		deterministic bool
+		streaming     bool
+	}{
+		{name: "both true", deterministic: true, streaming: true},
+		{name: "both false", deterministic: false, streaming: false},
+		{name: "mixed", deterministic: true, streaming: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := model.NewTool("hinted").
+				InputSchema(map[string]any{"type": "object"}).
+				Deterministic(tt.deterministic).
+				Streaming(tt.streaming).
+				MustBuild()
+
+			ct, err := NewMCPAdapter().ToCanonical(tool)
+			if err != nil {
+				t.Fatalf("ToCanonical() error = %v", err)
+			}
+			if ct.Deterministic == nil || *ct.Deterministic != tt.deterministic {
+				t.Errorf("Deterministic = %v, want %v", ct.Deterministic, tt.deterministic)
+			}
+			if ct.Streaming == nil || *ct.Streaming != tt.streaming {
+				t.Errorf("Streaming = %v, want %v", ct.Streaming, tt.streaming)
+			}
+		})
+	}
+}
+
+func TestMCPAdapter_Timeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout any
+		want    time.Duration
+	}{
+		{name: "duration string", timeout: "30s", want: 30 * time.Second},
+		{name: "milliseconds float", timeout: float64(1500), want: 1500 * time.Millisecond},
+		{name: "milliseconds int", timeout: 250, want: 250 * time.Millisecond},
+		{name: "invalid string", timeout: "soon", want: 0},
+		{name: "negative", timeout: -5, want: 0},
+	}
+
+	adapter := NewMCPAdapter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := &model.Tool{
+				Tool: mcp.Tool{
+					Name:        "timed",
+					InputSchema: map[string]any{"type": "object"},
+					Meta:        mcp.Meta{"timeout": tt.timeout},
+				},
+			}
+			ct, err := adapter.ToCanonical(tool)
+			if err != nil {
+				t.Fatalf("ToCanonical() error = %v", err)
+			}
+			if ct.Timeout != tt.want {
+				t.Errorf("Timeout = %v, want %v", ct.Timeout, tt.want)
+			}
+		})
+	}
+}
+
+func TestMCPAdapter_Timeout_RoundTrip(t *testing.T) {
+	adapter := NewMCPAdapter()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name:        "timed",
+			InputSchema: map[string]any{"type": "object"},
+			Meta:        mcp.Meta{"timeout": "30s"},
+		},
+	}
+
+	ct, err := adapter.ToCanonical(tool)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	if ct.Timeout != 30*time.Second {
+		t.Fatalf("Timeout = %v, want 30s", ct.Timeout)
+	}
+
+	// Drop SourceMeta so the timeout must be written from ct.Timeout.
+	ct.SourceMeta = nil
+	raw, err := adapter.FromCanonical(ct)
+	if err != nil {
+		t.Fatalf("FromCanonical() error = %v", err)
+	}
+	back := raw.(*model.Tool)
+	if back.Meta["timeout"] != "30s" {
+		t.Errorf("Meta[timeout] = %v, want 30s", back.Meta["timeout"])
+	}
+}
+
+func TestMCPAdapter_RequiredScopes_RoundTrip(t *testing.T) {
+	adapter := NewMCPAdapter()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name:        "scoped",
+			InputSchema: map[string]any{"type": "object"},
+			Meta:        mcp.Meta{"requiredScopes": []any{"files:read", "files:write"}},
+		},
+	}
+
+	ct, err := adapter.ToCanonical(tool)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	want := []string{"files:read", "files:write"}
+	if !reflect.DeepEqual(ct.RequiredScopes, want) {
+		t.Fatalf("RequiredScopes = %v, want %v", ct.RequiredScopes, want)
+	}
+	if len(ct.SecurityRequirements) != 0 {
+		t.Errorf("SecurityRequirements = %v, want none without a scheme", ct.SecurityRequirements)
+	}
+
+	// Drop SourceMeta so the scopes must be written from ct.RequiredScopes.
+	ct.SourceMeta = nil
+	raw, err := adapter.FromCanonical(ct)
+	if err != nil {
+		t.Fatalf("FromCanonical() error = %v", err)
+	}
+	back := raw.(*model.Tool)
+	if got := back.Meta["requiredScopes"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Meta[requiredScopes] = %v, want %v", got, want)
+	}
+}
+
+func TestMCPAdapter_RequiredScopes_DeriveRequirements(t *testing.T) {
+	tests := []struct {
+		name string
+		meta mcp.Meta
+		want []SecurityRequirement
+	}{
+		{
+			name: "single scheme",
+			meta: mcp.Meta{
+				"requiredScopes":  []any{"read"},
+				"securitySchemes": map[string]any{"oauth": map[string]any{"type": "oauth2"}},
+			},
+			want: []SecurityRequirement{{"oauth": {"read"}}},
+		},
+		{
+			name: "ambiguous schemes",
+			meta: mcp.Meta{
+				"requiredScopes": []any{"read"},
+				"securitySchemes": map[string]any{
+					"oauth":  map[string]any{"type": "oauth2"},
+					"apiKey": map[string]any{"type": "apiKey"},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "explicit requirements win",
+			meta: mcp.Meta{
+				"requiredScopes":       []any{"read"},
+				"securitySchemes":      map[string]any{"oauth": map[string]any{"type": "oauth2"}},
+				"securityRequirements": []any{map[string]any{"oauth": []any{"admin"}}},
+			},
+			want: []SecurityRequirement{{"oauth": {"admin"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct, err := NewMCPAdapter().ToCanonical(&model.Tool{
+				Tool: mcp.Tool{
+					Name:        "scoped",
+					InputSchema: map[string]any{"type": "object"},
+					Meta:        tt.meta,
+				},
+			})
+			if err != nil {
+				t.Fatalf("ToCanonical() error = %v", err)
+			}
+			if !reflect.DeepEqual(ct.SecurityRequirements, tt.want) {
+				t.Errorf("SecurityRequirements = %v, want %v", ct.SecurityRequirements, tt.want)
+			}
+		})
+	}
+}
+
+func TestMCPAdapter_RequiredScopes_DerivedRequirementsNotEmitted(t *testing.T) {
+	adapter := NewMCPAdapter()
+	newTool := func() *model.Tool {
+		return &model.Tool{
+			Tool: mcp.Tool{
+				Name:        "scoped",
+				InputSchema: map[string]any{"type": "object"},
+				Meta: mcp.Meta{
+					"requiredScopes":  []any{"read"},
+					"securitySchemes": map[string]any{"oauth": map[string]any{"type": "oauth2"}},
+				},
+			},
+		}
+	}
+	want, err := json.Marshal(newTool().Meta)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	for _, keepSourceMeta := range []bool{true, false} {
+		tool := newTool()
+		ct, err := adapter.ToCanonical(tool)
+		if err != nil {
+			t.Fatalf("ToCanonical() error = %v", err)
+		}
+		if len(ct.SecurityRequirements) != 1 {
+			t.Fatalf("SecurityRequirements = %v, want one derived requirement", ct.SecurityRequirements)
+		}
+		if !keepSourceMeta {
+			ct.SourceMeta = nil
+		}
+		raw, err := adapter.FromCanonical(ct)
+		if err != nil {
+			t.Fatalf("FromCanonical() error = %v", err)
+		}
+		for name, meta := range map[string]mcp.Meta{"output": raw.(*model.Tool).Meta, "input": tool.Meta} {
+			got, err := json.Marshal(meta)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("SourceMeta kept = %v: %s _meta = %s, want %s", keepSourceMeta, name, got, want)
+			}
+		}
+	}
+}
+
 func TestMCPAdapter_ToCanonical_MetaFields(t *testing.T) {
 	adapter := NewMCPAdapter()
 
@@ -702,6 +1015,21 @@ func TestMCPAdapter_ToCanonical_MetaFields(t *testing.T) {
 	}
 }
 
+func TestMCPAdapter_FromCanonicalWithOptions_SanitizeToolNames(t *testing.T) {
+	ct := &CanonicalTool{Name: "files/read all", InputSchema: &JSONSchema{Type: "object"}}
+
+	out, err := NewMCPAdapter().FromCanonicalWithOptions(ct, ConvertOptions{SanitizeToolNames: true})
+	if err != nil {
+		t.Fatalf("FromCanonicalWithOptions() error = %v", err)
+	}
+	if got := out.(*model.Tool).Name; got != "files_read_all" {
+		t.Errorf("Name = %q, want %q", got, "files_read_all")
+	}
+	if ct.Name != "files/read all" {
+		t.Errorf("canonical Name modified to %q", ct.Name)
+	}
+}
+
 func TestMCPAdapter_FromCanonical_MetaFields(t *testing.T) {
 	adapter := NewMCPAdapter()
 
@@ -914,6 +1242,124 @@ func TestSchemaFromAny_JSONSchemaTypes(t *testing.T) {
 	})
 }
 
+func TestSchemaFromMapStrict(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    map[string]any
+		wantPaths []string
+	}{
+		{
+			name: "known types",
+			schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"tags": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+					"any":  map[string]any{},
+				},
+			},
+		},
+		{
+			name:      "typo at root",
+			schema:    map[string]any{"type": "objet"},
+			wantPaths: []string{`"objet" at /`},
+		},
+		{
+			name: "nested typos",
+			schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"count": map[string]any{"type": "int"},
+					"tags":  map[string]any{"type": "array", "items": map[string]any{"type": "str"}},
+				},
+			},
+			wantPaths: []string{`"int" at /properties/count`, `"str" at /properties/tags/items`},
+		},
+		{
+			name:   "type arrays",
+			schema: map[string]any{"type": []any{"string", "null"}},
+		},
+		{
+			name: "bad type array elements",
+			schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{"type": []any{"string", "nul", 3.0}},
+				},
+			},
+			wantPaths: []string{`"nul" at /properties/name/type/1`, `3 at /properties/name/type/2`},
+		},
+		{
+			name:      "type neither string nor array",
+			schema:    map[string]any{"type": "object", "$defs": map[string]any{"N": map[string]any{"type": 5.0}}},
+			wantPaths: []string{`5 at /$defs/N`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SchemaFromMapStrict(tt.schema)
+			if len(tt.wantPaths) == 0 {
+				if err != nil || got == nil {
+					t.Fatalf("SchemaFromMapStrict() = %v, %v, want schema", got, err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrUnknownSchemaType) {
+				t.Fatalf("SchemaFromMapStrict() error = %v, want ErrUnknownSchemaType", err)
+			}
+			for _, want := range tt.wantPaths {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %s", err, want)
+				}
+			}
+			if lenient := schemaFromMap(tt.schema); lenient == nil {
+				t.Error("schemaFromMap() should stay lenient")
+			}
+		})
+	}
+}
+
+func TestSchemaFromMap_DedupesRequired(t *testing.T) {
+	direct := []string{"b", "a", "b", "c", "a"}
+	tests := []struct {
+		name     string
+		required any
+	}{
+		{name: "[]any", required: []any{"b", "a", "b", "c", "a"}},
+		{name: "[]string", required: direct},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := schemaFromMap(map[string]any{"type": "object", "required": tt.required})
+			if want := []string{"b", "a", "c"}; !reflect.DeepEqual(s.Required, want) {
+				t.Errorf("Required = %v, want %v", s.Required, want)
+			}
+		})
+	}
+	if want := []string{"b", "a", "b", "c", "a"}; !reflect.DeepEqual(direct, want) {
+		t.Errorf("schemaFromMap() modified the input slice: %v", direct)
+	}
+}
+
+func TestSchemaFromMap_AdditionalProperties(t *testing.T) {
+	closed := schemaFromMap(map[string]any{"type": "object", "additionalProperties": false})
+	if closed.AdditionalProperties == nil || *closed.AdditionalProperties || closed.AdditionalPropertiesSchema != nil {
+		t.Errorf("bool form = %+v, want AdditionalProperties false only", closed)
+	}
+
+	typed := schemaFromMap(map[string]any{
+		"type":                 "object",
+		"additionalProperties": map[string]any{"type": "integer", "minimum": 0},
+	})
+	if typed.AdditionalProperties != nil {
+		t.Errorf("AdditionalProperties = %v, want nil for the schema form", *typed.AdditionalProperties)
+	}
+	extra := typed.AdditionalPropertiesSchema
+	if extra == nil || extra.Type != "integer" || extra.Minimum == nil || *extra.Minimum != 0 {
+		t.Errorf("AdditionalPropertiesSchema = %+v, want integer with minimum 0", extra)
+	}
+}
+
 func TestSchemaFromMap_AllFields(t *testing.T) {
 	// Test nil map
 	t.Run("nil map", func(t *testing.T) {
@@ -974,3 +1420,86 @@ func floatPtr(v float64) *float64 {
 func boolPtr(v bool) *bool {
 	return &v
 }
+
+func TestMCPAdapter_RoundTrip_Contains(t *testing.T) {
+	adapter := NewMCPAdapter()
+	tags := map[string]any{
+		"type":        "array",
+		"items":       map[string]any{"type": "string"},
+		"contains":    map[string]any{"const": "primary"},
+		"minContains": 1,
+		"maxContains": 2,
+	}
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "tag",
+			InputSchema: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"tags": tags},
+			},
+		},
+	}
+
+	ct, err := adapter.ToCanonical(tool)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	got := ct.InputSchema.Properties["tags"]
+	if got.Contains == nil || got.Contains.Const != "primary" {
+		t.Errorf("Contains = %+v, want const primary", got.Contains)
+	}
+	if got.MinContains == nil || *got.MinContains != 1 || got.MaxContains == nil || *got.MaxContains != 2 {
+		t.Errorf("MinContains, MaxContains = %v, %v, want 1, 2", got.MinContains, got.MaxContains)
+	}
+
+	result, err := adapter.FromCanonical(ct)
+	if err != nil {
+		t.Fatalf("FromCanonical() error = %v", err)
+	}
+	props := result.(*model.Tool).InputSchema.(map[string]any)["properties"].(map[string]any)
+	if !reflect.DeepEqual(props["tags"], tags) {
+		t.Errorf("tags = %v, want %v", props["tags"], tags)
+	}
+}
+
+func TestMCPAdapter_RoundTrip_ConstNull(t *testing.T) {
+	adapter := NewMCPAdapter()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "nullable_const",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"cleared": map[string]any{"const": nil},
+					"plain":   map[string]any{"type": "string"},
+				},
+			},
+		},
+	}
+
+	ct, err := adapter.ToCanonical(tool)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	cleared := ct.InputSchema.Properties["cleared"]
+	if !cleared.HasConst || cleared.Const != nil {
+		t.Errorf("cleared = {Const: %v, HasConst: %v}, want explicit null const", cleared.Const, cleared.HasConst)
+	}
+	if ct.InputSchema.Properties["plain"].HasConst {
+		t.Error("plain.HasConst = true, want false")
+	}
+
+	result, err := adapter.FromCanonical(ct)
+	if err != nil {
+		t.Fatalf("FromCanonical() error = %v", err)
+	}
+	schema := result.(*model.Tool).InputSchema.(map[string]any)
+	props := schema["properties"].(map[string]any)
+	c, ok := props["cleared"].(map[string]any)["const"]
+	if !ok || c != nil {
+		t.Errorf("cleared.const = %v (present %v), want explicit null", c, ok)
+	}
+	if _, ok := props["plain"].(map[string]any)["const"]; ok {
+		t.Error("plain.const should be absent")
+	}
+}
@@ -12,6 +12,16 @@ type OpenAIFunction struct {
 	Description string         `json:"description,omitempty"`
 	Parameters  map[string]any `json:"parameters"`
 	Strict      *bool          `json:"strict,omitempty"`
+
+	// OutputSchema carries the canonical output schema for round-trip
+	// conversion. OpenAI function calling has no output schema, so it is
+	// never serialized.
+	OutputSchema map[string]any `json:"-"`
+
+	// SourceMeta carries SourceMeta entries of other formats (see
+	// CanonicalTool.SourceMeta) for round-trip conversion. It is kept in
+	// memory only and never serialized.
+	SourceMeta map[string]any `json:"-"`
 }
 
 // OpenAITool wraps a function for the tools array format.
@@ -28,6 +38,9 @@ func NewOpenAIAdapter() *OpenAIAdapter {
 	return &OpenAIAdapter{}
 }
 
+// openAIMetaKeys are the SourceMeta keys the OpenAI adapter owns.
+var openAIMetaKeys = []string{"openai", "strict"}
+
 // Name returns the adapter's identifier.
 func (a *OpenAIAdapter) Name() string {
 	return "openai"
@@ -69,7 +82,9 @@ var openAIFeatures = map[SchemaFeature]bool{
 }
 
 // ToCanonical converts an OpenAI tool to the canonical format.
-// Accepts *OpenAITool, OpenAITool, *OpenAIFunction, or OpenAIFunction.
+// Accepts *OpenAITool, OpenAITool, *OpenAIFunction, OpenAIFunction, or a
+// decoded JSON object (map[string]any): one with a "function" key is read as
+// an OpenAITool, anything else as an OpenAIFunction.
 func (a *OpenAIAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 	if raw == nil {
 		return nil, &ConversionError{
@@ -90,6 +105,20 @@ func (a *OpenAIAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 		fn = v
 	case OpenAIFunction:
 		fn = &v
+	case map[string]any:
+		var tool OpenAITool
+		target := any(&tool.Function)
+		if _, ok := v["function"]; ok {
+			target = &tool
+		}
+		if err := decodeToolMap(v, target); err != nil {
+			return nil, &ConversionError{
+				Adapter:   a.Name(),
+				Direction: "to_canonical",
+				Cause:     err,
+			}
+		}
+		fn = &tool.Function
 	default:
 		return nil, &ConversionError{
 			Adapter:   a.Name(),
@@ -108,18 +137,25 @@ func (a *OpenAIAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 
 	// Convert Parameters to JSONSchema
 	inputSchema := schemaFromMap(fn.Parameters)
+	if inputSchema == nil {
+		inputSchema = &JSONSchema{Type: "object"}
+	}
 
 	ct := &CanonicalTool{
 		Name:         fn.Name,
 		Description:  fn.Description,
 		InputSchema:  inputSchema,
+		OutputSchema: schemaFromMap(fn.OutputSchema),
 		SourceFormat: "openai",
 		SourceMeta:   make(map[string]any),
 	}
 
-	// Preserve OpenAI-specific fields in SourceMeta for round-trip
+	// Preserve OpenAI-specific fields in SourceMeta for round-trip. strict is
+	// also namespaced so it survives conversion through other formats.
+	restoreCarriedMeta(ct, fn.SourceMeta, openAIMetaKeys)
 	if fn.Strict != nil {
 		ct.SourceMeta["strict"] = *fn.Strict
+		ct.SourceMeta[a.Name()] = map[string]any{"strict": *fn.Strict}
 	}
 
 	return ct, nil
@@ -128,6 +164,13 @@ func (a *OpenAIAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
 // FromCanonical converts a canonical tool to OpenAI format.
 // Returns *OpenAITool.
 func (a *OpenAIAdapter) FromCanonical(ct *CanonicalTool) (any, error) {
+	return a.FromCanonicalWithOptions(ct, ConvertOptions{})
+}
+
+// FromCanonicalWithOptions converts a canonical tool to OpenAI format, honoring opts.
+// When opts.PreserveExamples is set, schema examples are kept in the parameters.
+// Returns *OpenAITool.
+func (a *OpenAIAdapter) FromCanonicalWithOptions(ct *CanonicalTool, opts ConvertOptions) (any, error) {
 	if ct == nil {
 		return nil, &ConversionError{
 			Adapter:   a.Name(),
@@ -151,17 +194,27 @@ func (a *OpenAIAdapter) FromCanonical(ct *CanonicalTool) (any, error) {
 
 	// Convert InputSchema to parameters map, filtering unsupported features
 	if ct.InputSchema != nil {
-		fn.Parameters = filterOpenAISchema(ct.InputSchema).ToMap()
+		fn.Parameters = filterOpenAISchema(ct.InputSchema, opts).ToMap()
 	} else {
 		fn.Parameters = map[string]any{"type": "object"}
 	}
 
-	// Restore strict from SourceMeta
+	// Carry OutputSchema unfiltered; it is not sent to the API
+	if ct.OutputSchema != nil {
+		fn.OutputSchema = ct.OutputSchema.ToMap()
+	}
+
+	// Restore strict from SourceMeta, preferring the top-level key set by a
+	// direct OpenAI round trip over the namespaced copy
 	if ct.SourceMeta != nil {
+		ns, _ := ct.SourceMeta[a.Name()].(map[string]any)
 		if strict, ok := ct.SourceMeta["strict"].(bool); ok {
 			fn.Strict = &strict
+		} else if strict, ok := ns["strict"].(bool); ok {
+			fn.Strict = &strict
 		}
 	}
+	fn.SourceMeta = carriedMeta(ct, openAIMetaKeys)
 
 	return &OpenAITool{
 		Type:     "function",
@@ -175,8 +228,18 @@ func (a *OpenAIAdapter) SupportsFeature(feature SchemaFeature) bool {
 	return ok && supported
 }
 
+// SupportsFeatureWithOptions returns whether a schema feature survives
+// conversion to OpenAI when opts are applied.
+func (a *OpenAIAdapter) SupportsFeatureWithOptions(feature SchemaFeature, opts ConvertOptions) bool {
+	if feature == FeatureExamples && opts.PreserveExamples {
+		return true
+	}
+	return a.SupportsFeature(feature)
+}
+
 // filterOpenAISchema removes unsupported features from a schema for OpenAI.
-func filterOpenAISchema(schema *JSONSchema) *JSONSchema {
+// Examples are kept only when opts.PreserveExamples is set.
+func filterOpenAISchema(schema *JSONSchema, opts ConvertOptions) *JSONSchema {
 	if schema == nil {
 		return nil
 	}
@@ -186,6 +249,7 @@ func filterOpenAISchema(schema *JSONSchema) *JSONSchema {
 		Description: schema.Description,
 		Default:     schema.Default,
 		Const:       schema.Const,
+		HasConst:    schema.HasConst,
 	}
 
 	// Copy supported pointer fields
@@ -201,6 +265,14 @@ func filterOpenAISchema(schema *JSONSchema) *JSONSchema {
 		v := *schema.Maximum
 		filtered.Maximum = &v
 	}
+	if schema.ExclusiveMinimum != nil {
+		v := *schema.ExclusiveMinimum
+		filtered.ExclusiveMinimum = &v
+	}
+	if schema.ExclusiveMaximum != nil {
+		v := *schema.ExclusiveMaximum
+		filtered.ExclusiveMaximum = &v
+	}
 	if schema.MinLength != nil {
 		v := *schema.MinLength
 		filtered.MinLength = &v
@@ -243,24 +315,29 @@ func filterOpenAISchema(schema *JSONSchema) *JSONSchema {
 		filtered.Enum = make([]any, len(schema.Enum))
 		copy(filtered.Enum, schema.Enum)
 	}
+	if opts.PreserveExamples && schema.Examples != nil {
+		filtered.Examples = make([]any, len(schema.Examples))
+		copy(filtered.Examples, schema.Examples)
+	}
 
 	// Recursively filter properties
 	if schema.Properties != nil {
 		filtered.Properties = make(map[string]*JSONSchema, len(schema.Properties))
 		for k, v := range schema.Properties {
-			filtered.Properties[k] = filterOpenAISchema(v)
+			filtered.Properties[k] = filterOpenAISchema(v, opts)
 		}
 	}
 
 	// Recursively filter items
 	if schema.Items != nil {
-		filtered.Items = filterOpenAISchema(schema.Items)
+		filtered.Items = filterOpenAISchema(schema.Items, opts)
 	}
 
 	// Note: Explicitly NOT copying unsupported fields:
 	// - Ref, Defs ($ref, $defs)
 	// - AnyOf, OneOf, AllOf, Not (combinators)
 	// - Pattern, Format (string validation)
+	// - Title, and Examples unless opts.PreserveExamples is set
 
 	return filtered
 }
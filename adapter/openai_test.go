@@ -1,7 +1,9 @@
 package adapter
 
 import (
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -188,6 +190,74 @@ func TestOpenAIAdapter_ToCanonical(t *testing.T) {
 	}
 }
 
+func TestOpenAIAdapter_ToCanonical_Map(t *testing.T) {
+	params := map[string]any{"type": "object", "properties": map[string]any{"q": map[string]any{"type": "string"}}}
+	tests := []struct {
+		name string
+		raw  map[string]any
+	}{
+		{
+			name: "tool wrapper",
+			raw: map[string]any{
+				"type":     "function",
+				"function": map[string]any{"name": "search", "parameters": params, "strict": true},
+			},
+		},
+		{
+			name: "bare function",
+			raw:  map[string]any{"name": "search", "parameters": params, "strict": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct, err := NewOpenAIAdapter().ToCanonical(tt.raw)
+			if err != nil {
+				t.Fatalf("ToCanonical() error = %v", err)
+			}
+			if ct.Name != "search" || ct.InputSchema.Properties["q"] == nil {
+				t.Errorf("ToCanonical() = %+v, want search with q property", ct)
+			}
+			if strict, _ := ct.SourceMeta["strict"].(bool); !strict {
+				t.Error("SourceMeta[strict] should be true")
+			}
+		})
+	}
+}
+
+func TestOpenAIAdapter_ToCanonical_NoParameters(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  any
+	}{
+		{name: "omitted", raw: &OpenAITool{Type: "function", Function: OpenAIFunction{Name: "ping"}}},
+		{name: "null map", raw: map[string]any{"name": "ping", "parameters": nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct, err := NewOpenAIAdapter().ToCanonical(tt.raw)
+			if err != nil {
+				t.Fatalf("ToCanonical() error = %v", err)
+			}
+			if ct.InputSchema == nil || ct.InputSchema.Type != "object" {
+				t.Errorf("InputSchema = %+v, want empty object schema", ct.InputSchema)
+			}
+			if err := ct.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+
+	result, err := DefaultRegistry().Convert(&OpenAITool{Type: "function", Function: OpenAIFunction{Name: "ping"}}, "openai", "mcp")
+	if err != nil {
+		t.Fatalf("Convert(openai -> mcp) error = %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("Convert(openai -> mcp) warnings = %v, want none", result.Warnings)
+	}
+}
+
 func TestOpenAIAdapter_ToCanonical_StrictPreserved(t *testing.T) {
 	adapter := NewOpenAIAdapter()
 
@@ -582,7 +652,7 @@ func TestOpenAIAdapter_ImplementsInterface(t *testing.T) {
 
 func TestOpenAIAdapter_FilterSchema_NilInput(t *testing.T) {
 	// Test that filterOpenAISchema handles nil input
-	result := filterOpenAISchema(nil)
+	result := filterOpenAISchema(nil, ConvertOptions{})
 	if result != nil {
 		t.Error("filterOpenAISchema(nil) should return nil")
 	}
@@ -623,3 +693,29 @@ func TestOpenAIAdapter_FilterSchema_WithItems(t *testing.T) {
 		t.Errorf("items.type = %v, want string", items["type"])
 	}
 }
+
+func TestOpenAIAdapter_OutputSchemaNotSerialized(t *testing.T) {
+	adapter := NewOpenAIAdapter()
+	ct := &CanonicalTool{
+		Name:         "test",
+		InputSchema:  &JSONSchema{Type: "object"},
+		OutputSchema: &JSONSchema{Type: "string"},
+	}
+
+	result, err := adapter.FromCanonical(ct)
+	if err != nil {
+		t.Fatalf("FromCanonical() error = %v", err)
+	}
+	tool := result.(*OpenAITool)
+	if tool.Function.OutputSchema["type"] != "string" {
+		t.Errorf("OutputSchema = %v, want type string", tool.Function.OutputSchema)
+	}
+
+	data, err := json.Marshal(tool)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), "OutputSchema") || strings.Contains(string(data), "outputSchema") {
+		t.Errorf("json.Marshal() = %s, want no output schema", data)
+	}
+}
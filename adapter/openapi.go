@@ -0,0 +1,484 @@
+package adapter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// OpenAPIOperation represents an OpenAPI 3.1 operation object (simplified for
+// adapter use). Component $refs are kept as-is; the adapter does not resolve
+// them against a document.
+type OpenAPIOperation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []OpenAPIParameter  `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody `json:"requestBody,omitempty"`
+
+	// OutputSchema carries the canonical output schema for round-trip
+	// conversion. It is kept in memory only and never serialized.
+	OutputSchema map[string]any `json:"-"`
+
+	// SourceMeta carries SourceMeta entries of other formats (see
+	// CanonicalTool.SourceMeta) for round-trip conversion. It is kept in
+	// memory only and never serialized.
+	SourceMeta map[string]any `json:"-"`
+}
+
+// OpenAPIParameter describes a single operation parameter.
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      map[string]any `json:"schema,omitempty"`
+}
+
+// OpenAPIRequestBody describes an operation's request body.
+type OpenAPIRequestBody struct {
+	Description string                      `json:"description,omitempty"`
+	Required    bool                        `json:"required,omitempty"`
+	Content     map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIMediaType holds the schema for one request body media type.
+type OpenAPIMediaType struct {
+	Schema map[string]any `json:"schema,omitempty"`
+}
+
+// openAPIMediaType is the media type preferred when reading a request body
+// and used when writing one.
+const openAPIMediaType = "application/json"
+
+// openAPIBodyProperty names the input property holding a request body that
+// cannot be merged with the operation's parameters.
+const openAPIBodyProperty = "body"
+
+// OpenAPIAdapter converts between OpenAPI 3.1 operations and CanonicalTool.
+//
+// The canonical input schema is the request body schema with each parameter
+// added as a property. When the body is not a plain object schema, it is
+// nested under a "body" property instead.
+type OpenAPIAdapter struct{}
+
+// NewOpenAPIAdapter creates a new OpenAPI adapter.
+func NewOpenAPIAdapter() *OpenAPIAdapter {
+	return &OpenAPIAdapter{}
+}
+
+// openAPIMetaKeys are the SourceMeta keys the OpenAPI adapter owns.
+var openAPIMetaKeys = []string{"openapi", "parameters", "requestBody", "bodyWrapped"}
+
+// Name returns the adapter's identifier.
+func (a *OpenAPIAdapter) Name() string {
+	return "openapi"
+}
+
+// openAPIFeatures defines which JSON Schema features OpenAPI 3.1 supports.
+// OpenAPI 3.1 schemas are JSON Schema 2020-12, so only the OpenAPI 3.0
+// nullable keyword is unsupported; it is rewritten as a "null" type.
+var openAPIFeatures = map[SchemaFeature]bool{
+	FeatureRef:                        true,
+	FeatureDefs:                       true,
+	FeatureAnyOf:                      true,
+	FeatureOneOf:                      true,
+	FeatureAllOf:                      true,
+	FeatureNot:                        true,
+	FeaturePattern:                    true,
+	FeatureFormat:                     true,
+	FeatureAdditionalProperties:       true,
+	FeatureAdditionalPropertiesSchema: true,
+	FeatureMinimum:                    true,
+	FeatureMaximum:                    true,
+	FeatureMinLength:                  true,
+	FeatureMaxLength:                  true,
+	FeatureEnum:                       true,
+	FeatureConst:                      true,
+	FeatureDefault:                    true,
+	FeatureTitle:                      true,
+	FeatureExamples:                   true,
+	FeatureMultipleOf:                 true,
+	FeatureMinItems:                   true,
+	FeatureMaxItems:                   true,
+	FeatureContains:                   true,
+	FeatureMinContains:                true,
+	FeatureMaxContains:                true,
+	FeaturePrefixItems:                true,
+	FeatureIf:                         true,
+	FeatureThen:                       true,
+	FeatureElse:                       true,
+	FeatureMinProperties:              true,
+	FeatureMaxProperties:              true,
+	FeatureUniqueItems:                true,
+	FeatureDeprecated:                 true,
+	FeatureReadOnly:                   true,
+	FeatureWriteOnly:                  true,
+
+	FeatureNullable: false,
+	FeatureTimeout:  false,
+}
+
+// ToCanonical converts an OpenAPI operation to the canonical format.
+// Accepts *OpenAPIOperation, OpenAPIOperation, or a decoded JSON object.
+func (a *OpenAPIAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
+	if raw == nil {
+		return nil, &ConversionError{
+			Adapter:   a.Name(),
+			Direction: "to_canonical",
+			Cause:     errors.New("input is nil"),
+		}
+	}
+
+	var op *OpenAPIOperation
+
+	switch v := raw.(type) {
+	case *OpenAPIOperation:
+		op = v
+	case OpenAPIOperation:
+		op = &v
+	case map[string]any:
+		op = &OpenAPIOperation{}
+		if err := decodeToolMap(v, op); err != nil {
+			return nil, &ConversionError{
+				Adapter:   a.Name(),
+				Direction: "to_canonical",
+				Cause:     err,
+			}
+		}
+	default:
+		return nil, &ConversionError{
+			Adapter:   a.Name(),
+			Direction: "to_canonical",
+			Cause:     fmt.Errorf("unsupported type: %T", raw),
+		}
+	}
+
+	if op.OperationID == "" {
+		return nil, &ConversionError{
+			Adapter:   a.Name(),
+			Direction: "to_canonical",
+			Cause:     errors.New("operationId is required"),
+		}
+	}
+
+	ct := &CanonicalTool{
+		Name:         op.OperationID,
+		Summary:      op.Summary,
+		Description:  op.Description,
+		Tags:         op.Tags,
+		OutputSchema: schemaFromMap(normalizeOpenAPISchema(op.OutputSchema)),
+		SourceFormat: "openapi",
+		SourceMeta:   map[string]any{},
+	}
+
+	input, wrapped := openAPIInputSchema(op)
+	ct.InputSchema = input
+	restoreCarriedMeta(ct, op.SourceMeta, openAPIMetaKeys)
+	if len(op.Parameters) > 0 {
+		ct.SourceMeta["parameters"] = op.Parameters
+	}
+	if op.RequestBody != nil {
+		ct.SourceMeta["requestBody"] = *op.RequestBody
+	}
+	if wrapped {
+		ct.SourceMeta["bodyWrapped"] = true
+	}
+
+	return ct, nil
+}
+
+// openAPIInputSchema merges the request body schema and parameters into a
+// single canonical input schema. It reports whether the body was nested
+// under openAPIBodyProperty.
+func openAPIInputSchema(op *OpenAPIOperation) (*JSONSchema, bool) {
+	var body *JSONSchema
+	bodyRequired := false
+	if op.RequestBody != nil {
+		body = schemaFromMap(normalizeOpenAPISchema(requestBodySchema(op.RequestBody)))
+		bodyRequired = op.RequestBody.Required
+	}
+
+	if len(op.Parameters) == 0 {
+		if body == nil {
+			return &JSONSchema{Type: "object"}, false
+		}
+		return body, false
+	}
+
+	input := body
+	wrapped := false
+	if input == nil || input.Type != "object" || input.Ref != "" {
+		input = &JSONSchema{Type: "object"}
+		if body != nil {
+			input.Properties = map[string]*JSONSchema{openAPIBodyProperty: body}
+			if bodyRequired {
+				input.Required = []string{openAPIBodyProperty}
+			}
+			wrapped = true
+		}
+	}
+	if input.Properties == nil {
+		input.Properties = make(map[string]*JSONSchema, len(op.Parameters))
+	}
+
+	for _, param := range op.Parameters {
+		if param.Name == "" {
+			continue
+		}
+		prop := schemaFromMap(normalizeOpenAPISchema(param.Schema))
+		if prop == nil {
+			prop = &JSONSchema{}
+		}
+		if prop.Description == "" {
+			prop.Description = param.Description
+		}
+		input.Properties[param.Name] = prop
+		if param.Required {
+			input.Required = append(input.Required, param.Name)
+		}
+	}
+
+	return input, wrapped
+}
+
+// requestBodySchema returns the application/json schema of a request body,
+// or the schema of the first media type in sorted order.
+func requestBodySchema(body *OpenAPIRequestBody) map[string]any {
+	if media, ok := body.Content[openAPIMediaType]; ok {
+		return media.Schema
+	}
+	types := make([]string, 0, len(body.Content))
+	for mediaType := range body.Content {
+		types = append(types, mediaType)
+	}
+	sort.Strings(types)
+	if len(types) == 0 {
+		return nil
+	}
+	return body.Content[types[0]].Schema
+}
+
+// FromCanonical converts a canonical tool to an OpenAPI operation.
+// Returns *OpenAPIOperation.
+//
+// Parameters recorded in SourceMeta by ToCanonical are split back out of the
+// input schema; everything else becomes an application/json request body.
+// Nullable schemas are written in the OpenAPI 3.1 form, type: [X, "null"].
+func (a *OpenAPIAdapter) FromCanonical(ct *CanonicalTool) (any, error) {
+	if ct == nil {
+		return nil, &ConversionError{
+			Adapter:   a.Name(),
+			Direction: "from_canonical",
+			Cause:     errors.New("canonical tool is nil"),
+		}
+	}
+
+	if ct.Name == "" {
+		return nil, &ConversionError{
+			Adapter:   a.Name(),
+			Direction: "from_canonical",
+			Cause:     errors.New("tool name is required"),
+		}
+	}
+
+	op := &OpenAPIOperation{
+		OperationID: ct.Name,
+		Summary:     ct.Summary,
+		Description: ct.Description,
+		Tags:        ct.Tags,
+		SourceMeta:  carriedMeta(ct, openAPIMetaKeys),
+	}
+
+	// Carry OutputSchema; it is not serialized
+	if ct.OutputSchema != nil {
+		op.OutputSchema = openAPISchemaMap(ct.OutputSchema)
+	}
+
+	input := openAPISchemaMap(ct.InputSchema)
+	if input == nil {
+		input = map[string]any{"type": "object"}
+	}
+
+	var params []OpenAPIParameter
+	var body *OpenAPIRequestBody
+	wrapped := false
+	if ct.SourceMeta != nil {
+		params, _ = ct.SourceMeta["parameters"].([]OpenAPIParameter)
+		if rb, ok := ct.SourceMeta["requestBody"].(OpenAPIRequestBody); ok {
+			body = &OpenAPIRequestBody{Description: rb.Description, Required: rb.Required}
+		}
+		wrapped, _ = ct.SourceMeta["bodyWrapped"].(bool)
+	}
+
+	props, _ := input["properties"].(map[string]any)
+	for _, param := range params {
+		prop, ok := props[param.Name].(map[string]any)
+		if !ok {
+			continue
+		}
+		out := param
+		out.Schema = prop
+		op.Parameters = append(op.Parameters, out)
+		delete(props, param.Name)
+		removeRequired(input, param.Name)
+	}
+
+	bodySchema := input
+	if wrapped {
+		bodySchema, _ = props[openAPIBodyProperty].(map[string]any)
+	} else if len(op.Parameters) > 0 && len(props) == 0 && body == nil {
+		bodySchema = nil
+	}
+	if bodySchema != nil {
+		if body == nil {
+			body = &OpenAPIRequestBody{}
+		}
+		body.Content = map[string]OpenAPIMediaType{
+			openAPIMediaType: {Schema: bodySchema},
+		}
+		op.RequestBody = body
+	}
+
+	return op, nil
+}
+
+// openAPISchemaMap converts a canonical schema to an OpenAPI 3.1 schema map.
+func openAPISchemaMap(schema *JSONSchema) map[string]any {
+	if schema == nil {
+		return nil
+	}
+	m := schema.ToMap()
+	rewriteNullable(m)
+	return m
+}
+
+// rewriteNullable replaces the OpenAPI 3.0 nullable keyword throughout m
+// with the 3.1 spelling, adding "null" to the type.
+func rewriteNullable(v any) {
+	switch node := v.(type) {
+	case map[string]any:
+		if nullable, ok := node["nullable"].(bool); ok {
+			delete(node, "nullable")
+			if typ, ok := node["type"].(string); ok && nullable && typ != "null" {
+				node["type"] = []any{typ, "null"}
+			}
+		}
+		for _, child := range node {
+			rewriteNullable(child)
+		}
+	case []any:
+		for _, child := range node {
+			rewriteNullable(child)
+		}
+	}
+}
+
+// normalizeOpenAPISchema returns a copy of m with OpenAPI 3.1 type arrays
+// turned into the canonical form: ["X", "null"] becomes type X with
+// nullable, and other multi-type arrays become an anyOf of single types.
+// An existing anyOf alongside a multi-type array is replaced.
+func normalizeOpenAPISchema(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	out, _ := normalizeOpenAPIValue(m).(map[string]any)
+	return out
+}
+
+func normalizeOpenAPIValue(v any) any {
+	switch node := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(node))
+		for k, child := range node {
+			out[k] = normalizeOpenAPIValue(child)
+		}
+		if types, ok := node["type"].([]any); ok {
+			normalizeTypeArray(out, types)
+		}
+		return out
+	case []any:
+		out := make([]any, len(node))
+		for i, child := range node {
+			out[i] = normalizeOpenAPIValue(child)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// normalizeTypeArray rewrites a type array on schema into canonical form.
+func normalizeTypeArray(schema map[string]any, types []any) {
+	var names []string
+	nullable := false
+	for _, t := range types {
+		name, ok := t.(string)
+		switch {
+		case !ok:
+		case name == "null":
+			nullable = true
+		default:
+			names = append(names, name)
+		}
+	}
+
+	delete(schema, "type")
+	switch {
+	case len(names) == 0 && nullable:
+		schema["type"] = "null"
+	case len(names) == 1:
+		schema["type"] = names[0]
+		if nullable {
+			schema["nullable"] = true
+		}
+	case len(names) > 1:
+		if nullable {
+			names = append(names, "null")
+		}
+		branches := make([]any, len(names))
+		for i, name := range names {
+			branches[i] = map[string]any{"type": name}
+		}
+		schema["anyOf"] = branches
+	}
+}
+
+// removeRequired drops name from schema's required list, deleting the list
+// when it becomes empty.
+func removeRequired(schema map[string]any, name string) {
+	required, ok := schema["required"].([]string)
+	if !ok {
+		return
+	}
+	out := make([]string, 0, len(required))
+	for _, r := range required {
+		if r != name {
+			out = append(out, r)
+		}
+	}
+	if len(out) == 0 {
+		delete(schema, "required")
+		return
+	}
+	schema["required"] = out
+}
+
+// SupportsFeature returns whether this adapter supports a schema feature.
+func (a *OpenAPIAdapter) SupportsFeature(feature SchemaFeature) bool {
+	supported, ok := openAPIFeatures[feature]
+	return ok && supported
+}
+
+// RewriteNote reports schema features OpenAPI 3.1 rewrites instead of
+// dropping. The 3.0-style nullable keyword is emitted as a "null" type.
+func (a *OpenAPIAdapter) RewriteNote(feature SchemaFeature, node *JSONSchema) string {
+	if feature != FeatureNullable || node == nil || node.Nullable == nil {
+		return ""
+	}
+	if *node.Nullable {
+		return `emitted as type [X, "null"]`
+	}
+	return "omitted; types are non-null by default"
+}
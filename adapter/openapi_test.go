@@ -0,0 +1,279 @@
+package adapter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jonwraymond/toolfoundation/model"
+)
+
+var _ FeatureRewriter = (*OpenAPIAdapter)(nil)
+
+func testOpenAPIOperation() *OpenAPIOperation {
+	return &OpenAPIOperation{
+		OperationID: "createIssue",
+		Summary:     "Create an issue",
+		Description: "Opens a new issue in a repository.",
+		Tags:        []string{"issues"},
+		Parameters: []OpenAPIParameter{
+			{Name: "repo", In: "path", Required: true, Schema: map[string]any{"type": "string"}},
+			{Name: "dryRun", In: "query", Description: "Validate only", Schema: map[string]any{"type": "boolean"}},
+		},
+		RequestBody: &OpenAPIRequestBody{
+			Required: true,
+			Content: map[string]OpenAPIMediaType{
+				"application/json": {Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"title": map[string]any{"type": "string"},
+						"body":  map[string]any{"type": []any{"string", "null"}},
+					},
+					"required": []any{"title"},
+				}},
+			},
+		},
+	}
+}
+
+func TestOpenAPIAdapter_Name(t *testing.T) {
+	adapter := NewOpenAPIAdapter()
+	if adapter.Name() != "openapi" {
+		t.Errorf("Name() = %q, want %q", adapter.Name(), "openapi")
+	}
+}
+
+func TestOpenAPIAdapter_SupportsFeature(t *testing.T) {
+	adapter := NewOpenAPIAdapter()
+	for _, feature := range AllFeatures() {
+		want := feature != FeatureNullable && feature != FeatureTimeout
+		if got := adapter.SupportsFeature(feature); got != want {
+			t.Errorf("SupportsFeature(%v) = %v, want %v", feature, got, want)
+		}
+	}
+}
+
+func TestOpenAPIAdapter_ToCanonical(t *testing.T) {
+	ct, err := NewOpenAPIAdapter().ToCanonical(testOpenAPIOperation())
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+
+	if ct.Name != "createIssue" || ct.Summary != "Create an issue" || ct.SourceFormat != "openapi" {
+		t.Errorf("ToCanonical() = %+v, want name, summary, and source format mapped", ct)
+	}
+	if ct.InputSchema.Type != "object" {
+		t.Fatalf("InputSchema.Type = %q, want object", ct.InputSchema.Type)
+	}
+	for _, name := range []string{"title", "body", "repo", "dryRun"} {
+		if ct.InputSchema.Properties[name] == nil {
+			t.Errorf("InputSchema.Properties[%q] missing", name)
+		}
+	}
+	if got := ct.InputSchema.Properties["dryRun"].Description; got != "Validate only" {
+		t.Errorf("dryRun.Description = %q, want parameter description", got)
+	}
+	if want := []string{"title", "repo"}; !reflect.DeepEqual(ct.InputSchema.Required, want) {
+		t.Errorf("Required = %v, want %v", ct.InputSchema.Required, want)
+	}
+
+	body := ct.InputSchema.Properties["body"]
+	if body.Type != "string" || body.Nullable == nil || !*body.Nullable {
+		t.Errorf("body = %+v, want nullable string from type array", body)
+	}
+}
+
+func TestOpenAPIAdapter_ToCanonical_TypeArrays(t *testing.T) {
+	op := &OpenAPIOperation{
+		OperationID: "search",
+		RequestBody: &OpenAPIRequestBody{
+			Content: map[string]OpenAPIMediaType{
+				"application/json": {Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"limit": map[string]any{"type": []any{"integer", "string", "null"}},
+						"none":  map[string]any{"type": []any{"null"}},
+					},
+				}},
+			},
+		},
+	}
+
+	ct, err := NewOpenAPIAdapter().ToCanonical(op)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+
+	limit := ct.InputSchema.Properties["limit"]
+	if limit.Type != "" || len(limit.AnyOf) != 3 {
+		t.Fatalf("limit = %+v, want anyOf of three single types", limit)
+	}
+	var types []string
+	for _, branch := range limit.AnyOf {
+		types = append(types, branch.Type)
+	}
+	if want := []string{"integer", "string", "null"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("limit anyOf types = %v, want %v", types, want)
+	}
+	if got := ct.InputSchema.Properties["none"].Type; got != "null" {
+		t.Errorf("none.Type = %q, want null", got)
+	}
+}
+
+func TestOpenAPIAdapter_ToCanonical_WrapsNonObjectBody(t *testing.T) {
+	op := &OpenAPIOperation{
+		OperationID: "upload",
+		Parameters:  []OpenAPIParameter{{Name: "id", In: "path", Required: true, Schema: map[string]any{"type": "string"}}},
+		RequestBody: &OpenAPIRequestBody{
+			Required: true,
+			Content: map[string]OpenAPIMediaType{
+				"application/json": {Schema: map[string]any{"$ref": "#/components/schemas/Upload"}},
+			},
+		},
+	}
+
+	ct, err := NewOpenAPIAdapter().ToCanonical(op)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+
+	body := ct.InputSchema.Properties[openAPIBodyProperty]
+	if body == nil || body.Ref != "#/components/schemas/Upload" {
+		t.Fatalf("body property = %+v, want the $ref body schema", body)
+	}
+	if want := []string{"body", "id"}; !reflect.DeepEqual(ct.InputSchema.Required, want) {
+		t.Errorf("Required = %v, want %v", ct.InputSchema.Required, want)
+	}
+	if wrapped, _ := ct.SourceMeta["bodyWrapped"].(bool); !wrapped {
+		t.Error("SourceMeta[bodyWrapped] should be true")
+	}
+}
+
+func TestOpenAPIAdapter_ToCanonical_Map(t *testing.T) {
+	raw := map[string]any{
+		"operationId": "ping",
+		"summary":     "Ping the service",
+		"parameters": []any{
+			map[string]any{"name": "verbose", "in": "query", "schema": map[string]any{"type": "boolean"}},
+		},
+	}
+
+	ct, err := NewOpenAPIAdapter().ToCanonical(raw)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	if ct.Name != "ping" || ct.InputSchema.Properties["verbose"] == nil {
+		t.Errorf("ToCanonical() = %+v, want ping with verbose property", ct)
+	}
+}
+
+func TestOpenAPIAdapter_ToCanonical_Errors(t *testing.T) {
+	adapter := NewOpenAPIAdapter()
+	tests := []struct {
+		name string
+		raw  any
+	}{
+		{name: "nil", raw: nil},
+		{name: "missing operationId", raw: &OpenAPIOperation{Summary: "no id"}},
+		{name: "unsupported type", raw: "createIssue"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := adapter.ToCanonical(tt.raw)
+			if err == nil {
+				t.Fatal("ToCanonical() error = nil, want error")
+			}
+			if _, ok := err.(*ConversionError); !ok {
+				t.Errorf("error type = %T, want *ConversionError", err)
+			}
+		})
+	}
+}
+
+func TestOpenAPIAdapter_RoundTrip(t *testing.T) {
+	adapter := NewOpenAPIAdapter()
+	ct, err := adapter.ToCanonical(testOpenAPIOperation())
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	out, err := adapter.FromCanonical(ct)
+	if err != nil {
+		t.Fatalf("FromCanonical() error = %v", err)
+	}
+	op := out.(*OpenAPIOperation)
+
+	if op.OperationID != "createIssue" || op.Summary != "Create an issue" {
+		t.Errorf("FromCanonical() = %+v, want operationId and summary preserved", op)
+	}
+	if len(op.Parameters) != 2 || op.Parameters[0].Name != "repo" || op.Parameters[0].In != "path" {
+		t.Fatalf("Parameters = %+v, want repo and dryRun restored", op.Parameters)
+	}
+	if op.RequestBody == nil || !op.RequestBody.Required {
+		t.Fatalf("RequestBody = %+v, want required body", op.RequestBody)
+	}
+
+	schema := op.RequestBody.Content["application/json"].Schema
+	props := schema["properties"].(map[string]any)
+	if _, ok := props["repo"]; ok {
+		t.Error("repo parameter should not remain in the body schema")
+	}
+	if want := []string{"title"}; !reflect.DeepEqual(schema["required"], want) {
+		t.Errorf("body required = %v, want %v", schema["required"], want)
+	}
+	bodyProp := props["body"].(map[string]any)
+	if want := []any{"string", "null"}; !reflect.DeepEqual(bodyProp["type"], want) {
+		t.Errorf("body.type = %v, want %v", bodyProp["type"], want)
+	}
+	if _, ok := bodyProp["nullable"]; ok {
+		t.Error("nullable keyword should not be emitted for OpenAPI 3.1")
+	}
+}
+
+func TestOpenAPIAdapter_NullableRewritten(t *testing.T) {
+	nullable := true
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "lookup",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"cursor": map[string]any{"type": "string", "nullable": nullable},
+				},
+			},
+		},
+	}
+
+	result, err := DefaultRegistry().Convert(tool, "mcp", "openapi")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	op := result.Tool.(*OpenAPIOperation)
+	cursor := op.RequestBody.Content["application/json"].Schema["properties"].(map[string]any)["cursor"].(map[string]any)
+	if want := []any{"string", "null"}; !reflect.DeepEqual(cursor["type"], want) {
+		t.Errorf("cursor.type = %v, want %v", cursor["type"], want)
+	}
+
+	if len(result.Warnings) != 1 {
+		t.Fatalf("Warnings = %v, want one rewrite warning", result.Warnings)
+	}
+	w := result.Warnings[0]
+	if w.Feature != FeatureNullable || !w.Rewritten || w.Severity != SeverityInfo || w.Path != "/properties/cursor" {
+		t.Errorf("Warning = %+v, want info-level nullable rewrite at /properties/cursor", w)
+	}
+	if !strings.Contains(w.String(), `"null"`) {
+		t.Errorf("String() = %q, want the 3.1 spelling", w.String())
+	}
+}
+
+func TestOpenAPIAdapter_FromCanonical_Errors(t *testing.T) {
+	adapter := NewOpenAPIAdapter()
+	if _, err := adapter.FromCanonical(nil); err == nil {
+		t.Error("FromCanonical(nil) error = nil, want error")
+	}
+	if _, err := adapter.FromCanonical(&CanonicalTool{}); err == nil {
+		t.Error("FromCanonical(unnamed) error = nil, want error")
+	}
+}
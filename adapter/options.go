@@ -0,0 +1,497 @@
+package adapter
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jonwraymond/toolfoundation/model"
+)
+
+// SchemaDraft selects the JSON Schema dialect used when writing schemas.
+type SchemaDraft int
+
+const (
+	// Draft202012 writes JSON Schema 2020-12 keywords. It is the default.
+	Draft202012 SchemaDraft = iota
+
+	// Draft07 writes draft-07 spellings: definitions instead of $defs,
+	// boolean exclusiveMinimum/exclusiveMaximum flags alongside
+	// minimum/maximum, and an items array with additionalItems instead of
+	// prefixItems and items.
+	Draft07
+)
+
+// ConvertOptions tunes how a conversion is performed.
+// The zero value matches the behavior of AdapterRegistry.Convert.
+type ConvertOptions struct {
+	// PreserveExamples carries schema examples into OpenAI parameters as
+	// "examples" arrays. OpenAI does not document the keyword but tolerates it.
+	PreserveExamples bool
+
+	// PatternToDescription appends a note to a schema's description for each
+	// pattern, format, or multipleOf keyword the target cannot represent, e.g.
+	// "(must match regex: ^[a-z]+$)", "(format: email)", or
+	// "(must be a multiple of 5)", so the model still learns the constraint.
+	PatternToDescription bool
+
+	// ExamplesToDescription appends the JSON-encoded schema examples to a
+	// schema's description when the target cannot carry the examples
+	// keyword, e.g. `(Examples: "Ada", "Grace")`.
+	ExamplesToDescription bool
+
+	// SchemaDraft selects the JSON Schema dialect of the emitted schemas for
+	// adapters that write JSON Schema verbatim, such as MCP.
+	SchemaDraft SchemaDraft
+
+	// ValidateEnums checks that every enum value matches the schema's
+	// declared type and reports each mismatch as an enum warning.
+	ValidateEnums bool
+
+	// DropInvalidEnums implies ValidateEnums and also removes mismatched
+	// values from the enum before conversion.
+	DropInvalidEnums bool
+
+	// MaxDepth, when positive, reports an error-severity warning at each
+	// schema nested deeper than this many levels (see JSONSchema.Depth).
+	MaxDepth int
+
+	// MaxProperties, when positive, reports an error-severity warning when a
+	// schema declares more properties in total (see JSONSchema.PropertyCount).
+	MaxProperties int
+
+	// MaxDescriptionLength, when positive, truncates a tool description
+	// (and summary) longer than this many characters at a word boundary,
+	// appends an ellipsis, and reports an info-severity warning. OpenAI, for
+	// example, caps function descriptions at 1024 characters.
+	MaxDescriptionLength int
+
+	// Simplify collapses degenerate combinators (see SimplifySchema) before
+	// feature-loss detection, so a single-member anyOf is not reported as
+	// lost by targets that lack anyOf.
+	Simplify bool
+
+	// SortRequired sorts the names in every required list, so schemas that
+	// differ only in required ordering convert to identical output.
+	SortRequired bool
+
+	// StripDescriptions blanks the tool's description and summary and the
+	// description of every schema node before conversion, producing a
+	// compact tool for token-constrained calls. Adapters do not fall back to
+	// the display name for a description, and a summary carried in MCP
+	// _meta is dropped. Notes requested by PatternToDescription or
+	// ExamplesToDescription are still added.
+	StripDescriptions bool
+
+	// SanitizeToolNames replaces characters that MCP does not allow in tool
+	// names with '_' (see model.SanitizeToolName) and reports a warning when
+	// the name changes, so that a function imported from a format with
+	// laxer name rules, such as "files/read", passes model.Tool.Validate.
+	// It applies only when converting to MCP; other targets keep the name.
+	SanitizeToolNames bool
+}
+
+// OptionsAdapter is an optional interface for adapters whose output can be
+// tuned by ConvertOptions. The registry prefers these methods over
+// FromCanonical and SupportsFeature when an adapter implements them.
+type OptionsAdapter interface {
+	Adapter
+
+	// FromCanonicalWithOptions converts a canonical tool to the
+	// protocol-specific format, honoring opts.
+	FromCanonicalWithOptions(ct *CanonicalTool, opts ConvertOptions) (any, error)
+
+	// SupportsFeatureWithOptions reports whether a schema feature survives
+	// conversion when opts are applied.
+	SupportsFeatureWithOptions(feature SchemaFeature, opts ConvertOptions) bool
+}
+
+// fromCanonical converts ct with the target adapter, passing opts when the
+// adapter implements OptionsAdapter.
+func fromCanonical(target Adapter, ct *CanonicalTool, opts ConvertOptions) (any, error) {
+	if oa, ok := target.(OptionsAdapter); ok {
+		return oa.FromCanonicalWithOptions(ct, opts)
+	}
+	return target.FromCanonical(ct)
+}
+
+// supportsFeature reports whether target supports feature under opts.
+func supportsFeature(target Adapter, feature SchemaFeature, opts ConvertOptions) bool {
+	if oa, ok := target.(OptionsAdapter); ok {
+		return oa.SupportsFeatureWithOptions(feature, opts)
+	}
+	return target.SupportsFeature(feature)
+}
+
+// supportsFeatureAt reports whether target supports feature as used on node,
+// asking a PartialFeatureAdapter first and falling back to supportsFeature.
+func supportsFeatureAt(target Adapter, feature SchemaFeature, node *JSONSchema, opts ConvertOptions) bool {
+	if pa, ok := target.(PartialFeatureAdapter); ok {
+		return pa.FeatureSupport(feature, node)
+	}
+	return supportsFeature(target, feature, opts)
+}
+
+// describedFeatures maps features that can be preserved as description text
+// to the note rendered for a schema node.
+var describedFeatures = map[SchemaFeature]func(*JSONSchema) string{
+	FeaturePattern: func(s *JSONSchema) string {
+		return fmt.Sprintf("(must match regex: %s)", s.Pattern)
+	},
+	FeatureFormat: func(s *JSONSchema) string {
+		return fmt.Sprintf("(format: %s)", s.Format)
+	},
+	FeatureMultipleOf: func(s *JSONSchema) string {
+		return fmt.Sprintf("(must be a multiple of %v)", *s.MultipleOf)
+	},
+	FeatureExamples: func(s *JSONSchema) string {
+		values := make([]string, len(s.Examples))
+		for i, example := range s.Examples {
+			data, err := json.Marshal(example)
+			if err != nil {
+				data = []byte(fmt.Sprint(example))
+			}
+			values[i] = string(data)
+		}
+		return fmt.Sprintf("(Examples: %s)", strings.Join(values, ", "))
+	},
+}
+
+// describesFeature reports whether opts ask for feature to be kept as
+// description text when the target drops it.
+func (o ConvertOptions) describesFeature(feature SchemaFeature) bool {
+	switch feature {
+	case FeaturePattern, FeatureFormat, FeatureMultipleOf:
+		return o.PatternToDescription
+	case FeatureExamples:
+		return o.ExamplesToDescription
+	default:
+		return false
+	}
+}
+
+// applyConvertOptions returns the canonical tool to hand to target after
+// applying the registry-level transforms requested by opts.
+// The input tool is never mutated; a copy is returned when a transform applies.
+func applyConvertOptions(ct *CanonicalTool, target Adapter, opts ConvertOptions) *CanonicalTool {
+	if ct == nil || !(opts.PatternToDescription || opts.ExamplesToDescription || opts.StripDescriptions) {
+		return ct
+	}
+
+	out := *ct
+	out.InputSchema = ct.InputSchema.DeepCopy()
+	out.OutputSchema = ct.OutputSchema.DeepCopy()
+
+	if opts.StripDescriptions {
+		out.Description = ""
+		out.Summary = ""
+		out.descriptionsStripped = true
+		out.SourceMeta = withoutCarriedSummary(ct.SourceMeta)
+		strip := func(_ string, node *JSONSchema) {
+			node.Description = ""
+		}
+		walkSchema(out.InputSchema, "", strip)
+		walkSchema(out.OutputSchema, "", strip)
+	}
+
+	describe := func(_ string, node *JSONSchema) {
+		used := nodeFeatures(node)
+		var notes []string
+		for _, feature := range AllFeatures() {
+			render, ok := describedFeatures[feature]
+			if !ok || !used[feature] || !opts.describesFeature(feature) {
+				continue
+			}
+			if supportsFeatureAt(target, feature, node, opts) {
+				continue
+			}
+			notes = append(notes, render(node))
+		}
+		if len(notes) == 0 {
+			return
+		}
+		if node.Description != "" {
+			notes = append([]string{node.Description}, notes...)
+		}
+		node.Description = strings.Join(notes, " ")
+	}
+	walkSchema(out.InputSchema, "", describe)
+	walkSchema(out.OutputSchema, "", describe)
+
+	return &out
+}
+
+// normalizeSchemas returns ct with its input and output schemas simplified
+// (see SimplifySchema) and their required lists sorted, as opts ask. The
+// input tool is never mutated.
+func normalizeSchemas(ct *CanonicalTool, opts ConvertOptions) *CanonicalTool {
+	if ct == nil || !(opts.Simplify || opts.SortRequired) {
+		return ct
+	}
+	out := *ct
+	out.InputSchema = normalizeSchema(ct.InputSchema, opts)
+	out.OutputSchema = normalizeSchema(ct.OutputSchema, opts)
+	return &out
+}
+
+// normalizeSchema applies the schema normalizations opts ask for to s.
+func normalizeSchema(s *JSONSchema, opts ConvertOptions) *JSONSchema {
+	if opts.Simplify {
+		s = SimplifySchema(s)
+	}
+	if opts.SortRequired {
+		s = Transform(s, sortRequired)
+	}
+	return s
+}
+
+// sortRequired returns a copy of node with its required names sorted.
+func sortRequired(node *JSONSchema) *JSONSchema {
+	if slices.IsSorted(node.Required) {
+		return node
+	}
+	out := *node
+	out.Required = slices.Sorted(slices.Values(node.Required))
+	return &out
+}
+
+// checkEnumTypes reports enum values that do not match their schema's type
+// when opts ask for it, returning the tool to convert and one warning per
+// mismatch. With DropInvalidEnums the mismatched values are removed from a
+// copy of the tool; the input tool is never mutated.
+func checkEnumTypes(ct *CanonicalTool, source, target Adapter, opts ConvertOptions) (*CanonicalTool, []FeatureLossWarning) {
+	if ct == nil || !(opts.ValidateEnums || opts.DropInvalidEnums) {
+		return ct, nil
+	}
+
+	out := ct
+	if opts.DropInvalidEnums {
+		copied := *ct
+		copied.InputSchema = ct.InputSchema.DeepCopy()
+		copied.OutputSchema = ct.OutputSchema.DeepCopy()
+		out = &copied
+	}
+
+	var warnings []FeatureLossWarning
+	check := func(inOutput bool) func(string, *JSONSchema) {
+		return func(path string, node *JSONSchema) {
+			if node.Type == "" || len(node.Enum) == 0 {
+				return
+			}
+			var kept []any
+			for _, v := range node.Enum {
+				if enumValueMatchesType(v, node) {
+					kept = append(kept, v)
+					continue
+				}
+				msg := fmt.Sprintf("enum value %#v is not of type %s", v, node.Type)
+				if opts.DropInvalidEnums {
+					msg += "; dropped"
+				}
+				warnings = append(warnings, FeatureLossWarning{
+					Feature:     FeatureEnum,
+					Severity:    featureSeverity(FeatureEnum),
+					Path:        path,
+					InOutput:    inOutput,
+					FromAdapter: source.Name(),
+					ToAdapter:   target.Name(),
+					Message:     msg,
+				})
+			}
+			if opts.DropInvalidEnums && len(kept) != len(node.Enum) {
+				node.Enum = kept
+			}
+		}
+	}
+	walkSchema(out.InputSchema, "", check(false))
+	walkSchema(out.OutputSchema, "", check(true))
+
+	return out, warnings
+}
+
+// enumValueMatchesType reports whether v is an instance of the schema's
+// declared type. Null is accepted for nullable schemas.
+func enumValueMatchesType(v any, schema *JSONSchema) bool {
+	if v == nil {
+		return schema.Type == "null" || (schema.Nullable != nil && *schema.Nullable)
+	}
+	switch schema.Type {
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "number":
+		_, ok := enumNumber(v)
+		return ok
+	case "integer":
+		n, ok := enumNumber(v)
+		return ok && n == math.Trunc(n)
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	case "null":
+		return false
+	default:
+		return true
+	}
+}
+
+// enumNumber returns v as a float64 if it is a Go or JSON number.
+func enumNumber(v any) (float64, bool) {
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return asFloat(v)
+}
+
+// Limit names reported in FeatureLossWarning.Limit.
+const (
+	limitMaxDepth      = "maxDepth"
+	limitMaxProperties = "maxProperties"
+
+	limitMaxDescriptionLength = "maxDescriptionLength"
+	limitToolName             = "toolName"
+)
+
+// truncateDescriptions shortens the tool's description and summary to
+// opts.MaxDescriptionLength, returning the tool to convert and an info
+// warning for each truncated field. The input tool is never mutated.
+func truncateDescriptions(ct *CanonicalTool, source, target Adapter, opts ConvertOptions) (*CanonicalTool, []FeatureLossWarning) {
+	limit := opts.MaxDescriptionLength
+	if ct == nil || limit <= 0 ||
+		(utf8.RuneCountInString(ct.Description) <= limit && utf8.RuneCountInString(ct.Summary) <= limit) {
+		return ct, nil
+	}
+
+	out := *ct
+	var warnings []FeatureLossWarning
+	truncate := func(field string, text *string) {
+		n := utf8.RuneCountInString(*text)
+		if n <= limit {
+			return
+		}
+		*text = truncateText(*text, limit)
+		warnings = append(warnings, FeatureLossWarning{
+			Severity:    SeverityInfo,
+			FromAdapter: source.Name(),
+			ToAdapter:   target.Name(),
+			Limit:       limitMaxDescriptionLength,
+			Message:     fmt.Sprintf("%s truncated from %d to %d characters", field, n, limit),
+		})
+	}
+	truncate("description", &out.Description)
+	truncate("summary", &out.Summary)
+
+	return &out, warnings
+}
+
+// withoutCarriedSummary returns meta without the summary in the MCP _meta
+// entry it carries, copying what it changes.
+func withoutCarriedSummary(meta map[string]any) map[string]any {
+	replace := func(carried any) map[string]any {
+		out := maps.Clone(meta)
+		out["meta"] = carried
+		return out
+	}
+	switch m := meta["meta"].(type) {
+	case mcp.Meta:
+		if _, ok := m["summary"]; ok {
+			m = maps.Clone(m)
+			delete(m, "summary")
+			return replace(m)
+		}
+	case map[string]any:
+		if _, ok := m["summary"]; ok {
+			m = maps.Clone(m)
+			delete(m, "summary")
+			return replace(m)
+		}
+	}
+	return meta
+}
+
+// sanitizeToolName applies opts.SanitizeToolNames when target is the MCP
+// adapter, returning the tool to convert and a warning when its name is
+// changed.
+func sanitizeToolName(ct *CanonicalTool, source, target Adapter, opts ConvertOptions) (*CanonicalTool, []FeatureLossWarning) {
+	if ct == nil || !opts.SanitizeToolNames {
+		return ct, nil
+	}
+	if _, ok := target.(*MCPAdapter); !ok {
+		return ct, nil
+	}
+	name := model.SanitizeToolName(ct.Name)
+	if name == ct.Name {
+		return ct, nil
+	}
+
+	out := *ct
+	out.Name = name
+	return &out, []FeatureLossWarning{{
+		Severity:    SeverityWarning,
+		FromAdapter: source.Name(),
+		ToAdapter:   target.Name(),
+		Limit:       limitToolName,
+		Message:     fmt.Sprintf("name %q sanitized to %q", ct.Name, name),
+	}}
+}
+
+// truncateText shortens s to at most limit characters, including a trailing
+// ellipsis. It cuts at the last whitespace that fits, or mid-word when the
+// first word alone is too long.
+func truncateText(s string, limit int) string {
+	const ellipsis = "…"
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	cut := string(runes[:limit-1])
+	if i := strings.LastIndexFunc(cut, unicode.IsSpace); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRightFunc(cut, unicode.IsSpace) + ellipsis
+}
+
+// checkSchemaLimits reports where schema exceeds the MaxDepth and
+// MaxProperties limits in opts. Depth is reported at each node one level
+// past the limit; the property count is reported at the root.
+func checkSchemaLimits(schema *JSONSchema, source, target Adapter, opts ConvertOptions) []FeatureLossWarning {
+	var warnings []FeatureLossWarning
+	warn := func(limit, path, msg string) {
+		warnings = append(warnings, FeatureLossWarning{
+			Severity:    SeverityError,
+			Path:        path,
+			FromAdapter: source.Name(),
+			ToAdapter:   target.Name(),
+			Limit:       limit,
+			Message:     msg,
+		})
+	}
+
+	if opts.MaxDepth > 0 {
+		walkSchemaDepth(schema, "", 1, func(path string, depth int, _ *JSONSchema) {
+			if depth == opts.MaxDepth+1 {
+				warn(limitMaxDepth, path, fmt.Sprintf("nested deeper than %d levels", opts.MaxDepth))
+			}
+		})
+	}
+	if opts.MaxProperties > 0 {
+		if n := schema.PropertyCount(); n > opts.MaxProperties {
+			warn(limitMaxProperties, "", fmt.Sprintf("%d properties exceed the limit of %d", n, opts.MaxProperties))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,817 @@
+package adapter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jonwraymond/toolfoundation/model"
+)
+
+var (
+	_ OptionsAdapter = (*OpenAIAdapter)(nil)
+	_ OptionsAdapter = (*MCPAdapter)(nil)
+)
+
+func examplesTool() *model.Tool {
+	return &model.Tool{
+		Tool: mcp.Tool{
+			Name: "greet",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":     "string",
+						"title":    "Name",
+						"examples": []any{"Ada", "Grace"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestConvertWithOptions_PreserveExamples(t *testing.T) {
+	registry := DefaultRegistry()
+
+	result, err := registry.ConvertWithOptions(examplesTool(), "mcp", "openai", ConvertOptions{PreserveExamples: true})
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+
+	fn := result.Tool.(*OpenAITool).Function
+	props := fn.Parameters["properties"].(map[string]any)
+	name := props["name"].(map[string]any)
+	examples, ok := name["examples"].([]any)
+	if !ok || len(examples) != 2 || examples[0] != "Ada" {
+		t.Errorf("name.examples = %v, want [Ada Grace]", name["examples"])
+	}
+	if _, ok := name["title"]; ok {
+		t.Error("name.title should still be filtered")
+	}
+
+	for _, w := range result.Warnings {
+		if w.Feature == FeatureExamples {
+			t.Errorf("unexpected examples warning with PreserveExamples: %s", w)
+		}
+	}
+}
+
+func TestConvertWithOptions_PreserveExamplesDisabled(t *testing.T) {
+	registry := DefaultRegistry()
+
+	result, err := registry.Convert(examplesTool(), "mcp", "openai")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	fn := result.Tool.(*OpenAITool).Function
+	props := fn.Parameters["properties"].(map[string]any)
+	name := props["name"].(map[string]any)
+	if _, ok := name["examples"]; ok {
+		t.Errorf("name.examples = %v, want dropped by default", name["examples"])
+	}
+
+	found := false
+	for _, w := range result.Warnings {
+		if w.Feature == FeatureExamples {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected examples warning when PreserveExamples is disabled")
+	}
+}
+
+func TestConvertWithOptions_PlainAdapterIgnoresOptions(t *testing.T) {
+	r := NewRegistry()
+	var called bool
+	_ = r.Register(&mockAdapter{
+		name: "source",
+		toCanonicalFunc: func(any) (*CanonicalTool, error) {
+			return &CanonicalTool{Name: "x", InputSchema: &JSONSchema{Type: "object"}}, nil
+		},
+	})
+	_ = r.Register(&mockAdapter{
+		name: "target",
+		fromCanonicalFunc: func(ct *CanonicalTool) (any, error) {
+			called = true
+			return ct.Name, nil
+		},
+	})
+
+	result, err := r.ConvertWithOptions("in", "source", "target", ConvertOptions{PreserveExamples: true})
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+	if !called || result.Tool != "x" {
+		t.Errorf("ConvertWithOptions() = %v, want FromCanonical to be used", result.Tool)
+	}
+}
+
+func patternTool() *model.Tool {
+	return &model.Tool{
+		Tool: mcp.Tool{
+			Name: "signup",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"user": map[string]any{
+						"type":        "string",
+						"description": "Username",
+						"pattern":     "^[a-z]+$",
+					},
+					"email": map[string]any{"type": "string", "format": "email"},
+				},
+			},
+		},
+	}
+}
+
+func TestConvertWithOptions_PatternToDescription(t *testing.T) {
+	registry := DefaultRegistry()
+	opts := ConvertOptions{PatternToDescription: true}
+
+	for _, format := range []string{"openai", "anthropic"} {
+		t.Run(format, func(t *testing.T) {
+			result, err := registry.ConvertWithOptions(patternTool(), "mcp", format, opts)
+			if err != nil {
+				t.Fatalf("ConvertWithOptions() error = %v", err)
+			}
+
+			var params map[string]any
+			switch v := result.Tool.(type) {
+			case *OpenAITool:
+				params = v.Function.Parameters
+			case *AnthropicTool:
+				params = v.InputSchema
+			}
+			props := params["properties"].(map[string]any)
+			user := props["user"].(map[string]any)
+			if got, want := user["description"], "Username (must match regex: ^[a-z]+$)"; got != want {
+				t.Errorf("user.description = %q, want %q", got, want)
+			}
+			if _, ok := user["pattern"]; ok {
+				t.Error("user.pattern should still be dropped")
+			}
+			email := props["email"].(map[string]any)
+			if got, want := email["description"], "(format: email)"; got != want {
+				t.Errorf("email.description = %q, want %q", got, want)
+			}
+
+			for _, w := range result.Warnings {
+				if (w.Feature == FeaturePattern || w.Feature == FeatureFormat) && w.Message == "" {
+					t.Errorf("warning %s should note the description fallback", w)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertWithOptions_PatternToDescription_MultipleOf(t *testing.T) {
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "order",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"quantity": map[string]any{"type": "integer", "multipleOf": 3, "description": "Units"},
+					"price":    map[string]any{"type": "number", "multipleOf": 0.1},
+				},
+			},
+		},
+	}
+	registry := DefaultRegistry()
+
+	result, err := registry.ConvertWithOptions(tool, "mcp", "gemini", ConvertOptions{PatternToDescription: true})
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+	props := result.Tool.(*GeminiTool).FunctionDeclarations[0].Parameters["properties"].(map[string]any)
+	quantity := props["quantity"].(map[string]any)
+	if got, want := quantity["description"], "Units (must be a multiple of 3)"; got != want {
+		t.Errorf("quantity.description = %q, want %q", got, want)
+	}
+	if _, ok := quantity["multipleOf"]; ok {
+		t.Error("quantity.multipleOf should still be dropped for gemini")
+	}
+	if got, want := props["price"].(map[string]any)["description"], "(must be a multiple of 0.1)"; got != want {
+		t.Errorf("price.description = %q, want %q", got, want)
+	}
+
+	// OpenAI keeps multipleOf, so no note is added.
+	result, err = registry.ConvertWithOptions(tool, "mcp", "openai", ConvertOptions{PatternToDescription: true})
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+	props = result.Tool.(*OpenAITool).Function.Parameters["properties"].(map[string]any)
+	if got := props["quantity"].(map[string]any)["description"]; got != "Units" {
+		t.Errorf("openai quantity.description = %q, want unchanged", got)
+	}
+}
+
+func TestConvertWithOptions_PatternToDescription_SupportedTarget(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := patternTool()
+
+	result, err := registry.ConvertWithOptions(tool, "mcp", "mcp", ConvertOptions{PatternToDescription: true})
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+
+	schema := result.Tool.(*model.Tool).InputSchema.(map[string]any)
+	user := schema["properties"].(map[string]any)["user"].(map[string]any)
+	if user["description"] != "Username" {
+		t.Errorf("user.description = %q, want unchanged for a target supporting pattern", user["description"])
+	}
+	if user["pattern"] != "^[a-z]+$" {
+		t.Errorf("user.pattern = %v, want preserved", user["pattern"])
+	}
+
+	original := tool.InputSchema.(map[string]any)["properties"].(map[string]any)["user"].(map[string]any)
+	if original["description"] != "Username" {
+		t.Error("source tool was mutated")
+	}
+}
+
+func TestConvertWithOptions_PatternToDescription_Disabled(t *testing.T) {
+	registry := DefaultRegistry()
+
+	result, err := registry.Convert(patternTool(), "mcp", "openai")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	props := result.Tool.(*OpenAITool).Function.Parameters["properties"].(map[string]any)
+	if got := props["user"].(map[string]any)["description"]; got != "Username" {
+		t.Errorf("user.description = %q, want unchanged by default", got)
+	}
+}
+
+func TestConvertWithOptions_SchemaDraft07(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "rate",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"score": map[string]any{"$ref": "#/$defs/Score"},
+				},
+				"$defs": map[string]any{
+					"Score": map[string]any{"type": "number", "exclusiveMinimum": 0.0},
+				},
+			},
+		},
+	}
+
+	result, err := registry.ConvertWithOptions(tool, "mcp", "mcp", ConvertOptions{SchemaDraft: Draft07})
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+
+	schema := result.Tool.(*model.Tool).InputSchema.(map[string]any)
+	if _, ok := schema["$defs"]; ok {
+		t.Error("$defs should be written as definitions for draft-07")
+	}
+	score := schema["definitions"].(map[string]any)["Score"].(map[string]any)
+	if score["minimum"] != 0.0 || score["exclusiveMinimum"] != true {
+		t.Errorf("Score = %v, want minimum 0 with exclusiveMinimum true", score)
+	}
+	ref := schema["properties"].(map[string]any)["score"].(map[string]any)["$ref"]
+	if ref != "#/definitions/Score" {
+		t.Errorf("score.$ref = %v, want #/definitions/Score", ref)
+	}
+
+	back, err := registry.Convert(result.Tool, "mcp", "mcp")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if !reflect.DeepEqual(back.Tool.(*model.Tool).InputSchema, tool.InputSchema) {
+		t.Errorf("round trip = %v, want %v", back.Tool.(*model.Tool).InputSchema, tool.InputSchema)
+	}
+}
+
+func enumTool() *model.Tool {
+	return &model.Tool{
+		Tool: mcp.Tool{
+			Name: "level",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"level": map[string]any{"type": "integer", "enum": []any{"a", 2.0, "b"}},
+				},
+			},
+		},
+	}
+}
+
+func TestConvertWithOptions_ValidateEnums(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     ConvertOptions
+		warnings int
+		wantEnum []any
+	}{
+		{name: "disabled", opts: ConvertOptions{}, warnings: 0, wantEnum: []any{"a", 2.0, "b"}},
+		{name: "validate", opts: ConvertOptions{ValidateEnums: true}, warnings: 2, wantEnum: []any{"a", 2.0, "b"}},
+		{name: "drop", opts: ConvertOptions{DropInvalidEnums: true}, warnings: 2, wantEnum: []any{2.0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := enumTool()
+			result, err := DefaultRegistry().ConvertWithOptions(source, "mcp", "mcp", tt.opts)
+			if err != nil {
+				t.Fatalf("ConvertWithOptions() error = %v", err)
+			}
+
+			if len(result.Warnings) != tt.warnings {
+				t.Fatalf("Warnings = %v, want %d", result.Warnings, tt.warnings)
+			}
+			for _, w := range result.Warnings {
+				if w.Feature != FeatureEnum || w.Path != "/properties/level" || !strings.Contains(w.Message, "not of type integer") {
+					t.Errorf("Warning = %+v, want enum type mismatch at /properties/level", w)
+				}
+			}
+
+			props := result.Tool.(*model.Tool).InputSchema.(map[string]any)["properties"].(map[string]any)
+			if got := props["level"].(map[string]any)["enum"]; !reflect.DeepEqual(got, tt.wantEnum) {
+				t.Errorf("level.enum = %v, want %v", got, tt.wantEnum)
+			}
+
+			srcProps := source.InputSchema.(map[string]any)["properties"].(map[string]any)
+			if got := srcProps["level"].(map[string]any)["enum"].([]any); len(got) != 3 {
+				t.Errorf("source enum mutated: %v", got)
+			}
+		})
+	}
+}
+
+func TestEnumValueMatchesType(t *testing.T) {
+	nullable := true
+	tests := []struct {
+		name   string
+		value  any
+		schema *JSONSchema
+		want   bool
+	}{
+		{name: "string", value: "a", schema: &JSONSchema{Type: "string"}, want: true},
+		{name: "string on integer", value: "a", schema: &JSONSchema{Type: "integer"}, want: false},
+		{name: "whole float on integer", value: 3.0, schema: &JSONSchema{Type: "integer"}, want: true},
+		{name: "fraction on integer", value: 3.5, schema: &JSONSchema{Type: "integer"}, want: false},
+		{name: "int on number", value: 3, schema: &JSONSchema{Type: "number"}, want: true},
+		{name: "bool on string", value: true, schema: &JSONSchema{Type: "string"}, want: false},
+		{name: "null on string", value: nil, schema: &JSONSchema{Type: "string"}, want: false},
+		{name: "null on nullable string", value: nil, schema: &JSONSchema{Type: "string", Nullable: &nullable}, want: true},
+		{name: "object", value: map[string]any{}, schema: &JSONSchema{Type: "object"}, want: true},
+		{name: "array on object", value: []any{}, schema: &JSONSchema{Type: "object"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := enumValueMatchesType(tt.value, tt.schema); got != tt.want {
+				t.Errorf("enumValueMatchesType(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertWithOptions_SchemaLimits(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name:        "deep",
+			InputSchema: nestedSchema(6).ToMap(),
+		},
+	}
+
+	result, err := registry.ConvertWithOptions(tool, "mcp", "openai", ConvertOptions{MaxDepth: 3, MaxProperties: 10})
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+
+	var limits []FeatureLossWarning
+	for _, w := range result.Warnings {
+		if w.Limit != "" {
+			limits = append(limits, w)
+		}
+	}
+	if len(limits) != 3 {
+		t.Fatalf("limit warnings = %v, want 3", limits)
+	}
+
+	wantPaths := map[string]string{
+		"/properties/child/properties/child/properties/child": limitMaxDepth,
+		"/properties/child/properties/child/properties/name":  limitMaxDepth,
+		"": limitMaxProperties,
+	}
+	for _, w := range limits {
+		if w.Severity != SeverityError {
+			t.Errorf("Severity = %v, want error for %s", w.Severity, w)
+		}
+		if limit, ok := wantPaths[w.Path]; !ok || limit != w.Limit {
+			t.Errorf("unexpected limit warning %s", w)
+		}
+	}
+	if got := limits[2].String(); !strings.Contains(got, "schema exceeds maxProperties") || !strings.Contains(got, "12 properties") {
+		t.Errorf("String() = %q, want the property limit", got)
+	}
+	if got := result.FidelityScore(); got != 100 {
+		t.Errorf("FidelityScore() = %d, want limits not to count as loss", got)
+	}
+}
+
+func TestConvertWithOptions_SchemaLimitsNotExceeded(t *testing.T) {
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name:        "shallow",
+			InputSchema: nestedSchema(2).ToMap(),
+		},
+	}
+
+	result, err := DefaultRegistry().ConvertWithOptions(tool, "mcp", "mcp", ConvertOptions{MaxDepth: 3, MaxProperties: 4})
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("Warnings = %v, want none", result.Warnings)
+	}
+}
+
+func TestConvertWithOptions_MaxDescriptionLength(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		want        string
+		wantWarning bool
+	}{
+		{
+			name:        "under limit",
+			description: "Looks up a user.",
+			want:        "Looks up a user.",
+		},
+		{
+			name:        "at limit",
+			description: "Looks up a user by id",
+			want:        "Looks up a user by id",
+		},
+		{
+			name:        "over limit",
+			description: "Looks up a user by id and returns the profile.",
+			want:        "Looks up a user by id…",
+			wantWarning: true,
+		},
+		{
+			name:        "single long word",
+			description: "Supercalifragilisticexpialidocious",
+			want:        "Supercalifragilisticex…",
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := &model.Tool{
+				Tool: mcp.Tool{
+					Name:        "lookup",
+					Description: tt.description,
+					InputSchema: map[string]any{"type": "object"},
+				},
+			}
+			result, err := DefaultRegistry().ConvertWithOptions(tool, "mcp", "openai", ConvertOptions{MaxDescriptionLength: 23})
+			if err != nil {
+				t.Fatalf("ConvertWithOptions() error = %v", err)
+			}
+			if got := result.Tool.(*OpenAITool).Function.Description; got != tt.want {
+				t.Errorf("Description = %q, want %q", got, tt.want)
+			}
+			if tool.Description != tt.description {
+				t.Error("input tool was mutated")
+			}
+
+			if !tt.wantWarning {
+				if len(result.Warnings) != 0 {
+					t.Errorf("Warnings = %v, want none", result.Warnings)
+				}
+				return
+			}
+			if len(result.Warnings) != 1 {
+				t.Fatalf("Warnings = %v, want one truncation warning", result.Warnings)
+			}
+			w := result.Warnings[0]
+			if w.Limit != limitMaxDescriptionLength || w.Severity != SeverityInfo {
+				t.Errorf("Warning = %+v, want info-level maxDescriptionLength", w)
+			}
+			if got := w.String(); !strings.Contains(got, "tool exceeds maxDescriptionLength") {
+				t.Errorf("String() = %q, want the description limit", got)
+			}
+		})
+	}
+}
+
+func TestConvertWithOptions_Simplify(t *testing.T) {
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "tag",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"label": map[string]any{"anyOf": []any{map[string]any{"type": "string"}}},
+				},
+			},
+		},
+	}
+	registry := DefaultRegistry()
+
+	plain, err := registry.ConvertWithOptions(tool, "mcp", "openai", ConvertOptions{})
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+	if len(plain.Warnings) == 0 {
+		t.Fatal("Warnings = empty, want anyOf loss without Simplify")
+	}
+
+	result, err := registry.ConvertWithOptions(tool, "mcp", "openai", ConvertOptions{Simplify: true})
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("Warnings = %v, want none after simplification", result.Warnings)
+	}
+	label := result.Tool.(*OpenAITool).Function.Parameters["properties"].(map[string]any)["label"].(map[string]any)
+	if label["type"] != "string" {
+		t.Errorf("label = %v, want type string", label)
+	}
+}
+
+func TestConvertWithOptions_SortRequired(t *testing.T) {
+	tool := func(required ...any) *model.Tool {
+		return &model.Tool{
+			Tool: mcp.Tool{
+				Name: "lookup",
+				InputSchema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"id":   map[string]any{"type": "string"},
+						"name": map[string]any{"type": "string"},
+					},
+					"required": required,
+				},
+			},
+		}
+	}
+	registry := DefaultRegistry()
+	opts := ConvertOptions{SortRequired: true}
+
+	var outputs []any
+	for _, input := range []*model.Tool{tool("name", "id"), tool("id", "name", "id")} {
+		result, err := registry.ConvertWithOptions(input, "mcp", "openai", opts)
+		if err != nil {
+			t.Fatalf("ConvertWithOptions() error = %v", err)
+		}
+		outputs = append(outputs, result.Tool.(*OpenAITool).Function.Parameters["required"])
+	}
+	want := []string{"id", "name"}
+	for i, got := range outputs {
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("output %d required = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestConvertWithOptions_StripDescriptions(t *testing.T) {
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name:        "search",
+			Title:       "Catalog Search",
+			Description: "Searches the catalog for matching products.",
+			Meta:        mcp.Meta{"summary": "Find products", "category": "catalog"},
+			InputSchema: map[string]any{
+				"type":        "object",
+				"description": "Search input",
+				"properties": map[string]any{
+					"filter": map[string]any{
+						"type":        "object",
+						"description": "Narrow the results",
+						"properties": map[string]any{
+							"tags": map[string]any{
+								"type":        "array",
+								"description": "Tags to match",
+								"items":       map[string]any{"type": "string", "description": "A tag"},
+							},
+						},
+					},
+				},
+				"$defs": map[string]any{
+					"Price": map[string]any{"type": "number", "description": "Price in cents"},
+				},
+			},
+		},
+	}
+	registry := DefaultRegistry()
+	opts := ConvertOptions{StripDescriptions: true}
+
+	tests := []struct {
+		target string
+		// describe returns the converted tool's description and input schema.
+		describe func(any) (string, map[string]any)
+	}{
+		{
+			target: "mcp",
+			describe: func(out any) (string, map[string]any) {
+				tool := out.(*model.Tool)
+				return tool.Description, tool.InputSchema.(map[string]any)
+			},
+		},
+		{
+			target: "openai",
+			describe: func(out any) (string, map[string]any) {
+				fn := out.(*OpenAITool).Function
+				return fn.Description, fn.Parameters
+			},
+		},
+		{
+			target: "anthropic",
+			describe: func(out any) (string, map[string]any) {
+				tool := out.(*AnthropicTool)
+				return tool.Description, tool.InputSchema
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			result, err := registry.ConvertWithOptions(tool, "mcp", tt.target, opts)
+			if err != nil {
+				t.Fatalf("ConvertWithOptions() error = %v", err)
+			}
+			description, input := tt.describe(result.Tool)
+			if description != "" {
+				t.Errorf("Description = %q, want stripped", description)
+			}
+			nodes := 0
+			Walk(schemaFromMap(input), func(path string, node *JSONSchema) {
+				nodes++
+				if node.Description != "" {
+					t.Errorf("description at %q = %q, want stripped", path, node.Description)
+				}
+			})
+			if tt.target == "mcp" && nodes != 5 {
+				t.Errorf("walked %d schema nodes, want 5", nodes)
+			}
+		})
+	}
+
+	result, err := registry.ConvertWithOptions(tool, "mcp", "mcp", opts)
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+	out := result.Tool.(*model.Tool)
+	if _, ok := out.Meta["summary"]; ok {
+		t.Errorf("Meta = %v, want summary stripped", out.Meta)
+	}
+	if out.Meta["category"] != "catalog" || out.Title != "Catalog Search" {
+		t.Errorf("Meta = %v, Title = %q, want category and title kept", out.Meta, out.Title)
+	}
+
+	if tool.Description == "" || tool.InputSchema.(map[string]any)["description"] != "Search input" ||
+		tool.Meta["summary"] != "Find products" {
+		t.Error("StripDescriptions modified the input tool")
+	}
+}
+
+func TestConvertWithOptions_ExamplesToDescription(t *testing.T) {
+	registry := DefaultRegistry()
+	opts := ConvertOptions{ExamplesToDescription: true}
+
+	result, err := registry.ConvertWithOptions(examplesTool(), "mcp", "anthropic", opts)
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+	props := result.Tool.(*AnthropicTool).InputSchema["properties"].(map[string]any)
+	name := props["name"].(map[string]any)
+	if got, want := name["description"], `(Examples: "Ada", "Grace")`; got != want {
+		t.Errorf("name.description = %q, want %q", got, want)
+	}
+	if _, ok := name["examples"]; ok {
+		t.Error("name.examples should still be dropped for Anthropic")
+	}
+	for _, w := range result.Warnings {
+		if w.Feature == FeatureExamples && w.Message != "noted in description" {
+			t.Errorf("examples warning = %s, want noted in description", w)
+		}
+	}
+
+	result, err = registry.ConvertWithOptions(examplesTool(), "mcp", "mcp", opts)
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+	props = result.Tool.(*model.Tool).InputSchema.(map[string]any)["properties"].(map[string]any)
+	name = props["name"].(map[string]any)
+	if _, ok := name["description"]; ok {
+		t.Errorf("name.description = %q, want none for MCP", name["description"])
+	}
+	if examples, ok := name["examples"].([]any); !ok || len(examples) != 2 {
+		t.Errorf("name.examples = %v, want kept for MCP", name["examples"])
+	}
+}
+
+func TestConvertWithOptions_ExamplesToDescription_Disabled(t *testing.T) {
+	result, err := DefaultRegistry().Convert(examplesTool(), "mcp", "anthropic")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	props := result.Tool.(*AnthropicTool).InputSchema["properties"].(map[string]any)
+	if _, ok := props["name"].(map[string]any)["description"]; ok {
+		t.Error("name.description should not be added by default")
+	}
+}
+
+func TestConvertWithOptions_SanitizeToolNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		toolName string
+		want     string
+	}{
+		{name: "dots", toolName: "files.read", want: "files.read"},
+		{name: "slashes", toolName: "files/read", want: "files_read"},
+		{name: "spaces", toolName: "read all files", want: "read_all_files"},
+	}
+
+	registry := DefaultRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := OpenAIFunction{Name: tt.toolName, Parameters: map[string]any{"type": "object"}}
+
+			result, err := registry.ConvertWithOptions(tool, "openai", "mcp", ConvertOptions{SanitizeToolNames: true})
+			if err != nil {
+				t.Fatalf("ConvertWithOptions() error = %v", err)
+			}
+			converted := result.Tool.(*model.Tool)
+			if converted.Name != tt.want {
+				t.Errorf("Name = %q, want %q", converted.Name, tt.want)
+			}
+			if err := converted.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+
+			wantWarnings := 0
+			if tt.want != tt.toolName {
+				wantWarnings = 1
+			}
+			if len(result.Warnings) != wantWarnings {
+				t.Fatalf("Warnings = %v, want %d", result.Warnings, wantWarnings)
+			}
+			if wantWarnings > 0 {
+				w := result.Warnings[0]
+				if w.Limit != limitToolName || w.Severity != SeverityWarning {
+					t.Errorf("warning = %+v, want toolName warning", w)
+				}
+				if !strings.Contains(w.String(), tt.want) {
+					t.Errorf("warning %q does not mention %q", w.String(), tt.want)
+				}
+			}
+
+			plain, err := registry.Convert(tool, "openai", "mcp")
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if got := plain.Tool.(*model.Tool).Name; got != tt.toolName {
+				t.Errorf("Convert() without option Name = %q, want %q", got, tt.toolName)
+			}
+		})
+	}
+}
+
+func TestConvertWithOptions_SanitizeToolNames_OnlyMCP(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := OpenAIFunction{Name: "files/read", Parameters: map[string]any{"type": "object"}}
+
+	for _, target := range []string{"openai", "anthropic", "gemini", "openapi"} {
+		t.Run(target, func(t *testing.T) {
+			result, err := registry.ConvertWithOptions(tool, "openai", target, ConvertOptions{SanitizeToolNames: true})
+			if err != nil {
+				t.Fatalf("ConvertWithOptions() error = %v", err)
+			}
+			for _, w := range result.Warnings {
+				if w.Limit == limitToolName {
+					t.Errorf("unexpected rename warning %v", w)
+				}
+			}
+			a, err := registry.Get(target)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			back, err := a.ToCanonical(result.Tool)
+			if err != nil {
+				t.Fatalf("ToCanonical() error = %v", err)
+			}
+			if back.Name != "files/read" {
+				t.Errorf("Name = %q, want %q", back.Name, "files/read")
+			}
+		})
+	}
+}
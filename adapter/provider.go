@@ -0,0 +1,73 @@
+package adapter
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrProviderUnsupported is returned by ConvertProvider when an adapter does
+// not implement ProviderAdapter.
+var ErrProviderUnsupported = errors.New("provider conversion unsupported")
+
+// ProviderAdapter is an optional interface for adapters whose format
+// describes a whole provider (e.g., an A2A AgentCard) rather than a single
+// tool. The registry uses it for ConvertProvider.
+type ProviderAdapter interface {
+	Adapter
+
+	// ToCanonicalProvider converts a protocol-specific provider to canonical form.
+	ToCanonicalProvider(raw any) (*CanonicalProvider, error)
+
+	// ProviderFromCanonical converts a canonical provider to the
+	// protocol-specific format. Adapters typically also offer a typed
+	// FromCanonicalProvider, as A2AAdapter does.
+	ProviderFromCanonical(provider *CanonicalProvider) (any, error)
+}
+
+// ConvertProvider transforms a provider from one format to another through
+// CanonicalProvider. It returns the canonical form along with the converted
+// provider. Both adapters must implement ProviderAdapter; otherwise the error
+// wraps ErrProviderUnsupported.
+func (r *AdapterRegistry) ConvertProvider(provider any, fromFormat, toFormat string) (*CanonicalProvider, any, error) {
+	source, err := r.providerAdapter(fromFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+	target, err := r.providerAdapter(toFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	canonical, err := source.ToCanonicalProvider(provider)
+	if err != nil {
+		return nil, nil, &ConversionError{
+			Adapter:   fromFormat,
+			Direction: "to_canonical_provider",
+			Cause:     err,
+		}
+	}
+
+	output, err := target.ProviderFromCanonical(canonical)
+	if err != nil {
+		return nil, nil, &ConversionError{
+			Adapter:   toFormat,
+			Direction: "from_canonical_provider",
+			Cause:     err,
+		}
+	}
+
+	return canonical, output, nil
+}
+
+// providerAdapter looks up the named adapter and asserts ProviderAdapter.
+func (r *AdapterRegistry) providerAdapter(name string) (ProviderAdapter, error) {
+	a, err := r.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	pa, ok := a.(ProviderAdapter)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProviderUnsupported, name)
+	}
+	return pa, nil
+}
@@ -0,0 +1,103 @@
+package adapter
+
+import (
+	"errors"
+	"testing"
+)
+
+var _ ProviderAdapter = (*A2AAdapter)(nil)
+
+func testAgentCard() *A2AAgentCard {
+	return &A2AAgentCard{
+		Name:        "Test Agent",
+		Description: "Handles test workflows",
+		Version:     "1.0.0",
+		SupportedInterfaces: []A2AAgentInterface{
+			{URL: "https://example.com/a2a", ProtocolBinding: "JSONRPC", ProtocolVersion: "1.0"},
+		},
+		Skills: []A2AAgentSkill{
+			{ID: "tools:search:1.0.0", Name: "Search", Description: "Search skill", Tags: []string{"search"}},
+		},
+	}
+}
+
+func TestRegistry_ConvertProvider(t *testing.T) {
+	r := DefaultRegistry()
+	_ = r.Alias("agent-card", "a2a")
+
+	canonical, out, err := r.ConvertProvider(testAgentCard(), "a2a", "agent-card")
+	if err != nil {
+		t.Fatalf("ConvertProvider() error = %v", err)
+	}
+	if canonical.Name != "Test Agent" || len(canonical.Skills) != 1 {
+		t.Errorf("canonical = %+v, want Test Agent with 1 skill", canonical)
+	}
+
+	card, ok := out.(*A2AAgentCard)
+	if !ok {
+		t.Fatalf("ConvertProvider() output = %T, want *A2AAgentCard", out)
+	}
+	if card.Name != "Test Agent" || len(card.Skills) != 1 {
+		t.Errorf("card = %+v, want Test Agent with 1 skill", card)
+	}
+}
+
+func TestRegistry_ConvertProvider_Unsupported(t *testing.T) {
+	r := DefaultRegistry()
+
+	tests := []struct {
+		name string
+		from string
+		to   string
+	}{
+		{name: "unsupported source", from: "mcp", to: "a2a"},
+		{name: "unsupported target", from: "a2a", to: "openai"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := r.ConvertProvider(testAgentCard(), tt.from, tt.to)
+			if !errors.Is(err, ErrProviderUnsupported) {
+				t.Errorf("ConvertProvider() error = %v, want ErrProviderUnsupported", err)
+			}
+		})
+	}
+}
+
+func TestRegistry_ConvertProvider_Errors(t *testing.T) {
+	r := DefaultRegistry()
+
+	if _, _, err := r.ConvertProvider(testAgentCard(), "missing", "a2a"); err == nil {
+		t.Error("ConvertProvider() with missing adapter = nil, want error")
+	}
+
+	_, _, err := r.ConvertProvider(&A2AAgentCard{Name: "incomplete"}, "a2a", "a2a")
+	var convErr *ConversionError
+	if !errors.As(err, &convErr) {
+		t.Fatalf("ConvertProvider() error = %v, want *ConversionError", err)
+	}
+	if convErr.Direction != "to_canonical_provider" {
+		t.Errorf("Direction = %q, want to_canonical_provider", convErr.Direction)
+	}
+}
+
+func TestA2AAdapter_ProviderFromCanonical(t *testing.T) {
+	a := NewA2AAdapter()
+	provider, err := a.ToCanonicalProvider(testAgentCard())
+	if err != nil {
+		t.Fatalf("ToCanonicalProvider() error = %v", err)
+	}
+
+	out, err := a.ProviderFromCanonical(provider)
+	if err != nil {
+		t.Fatalf("ProviderFromCanonical() error = %v", err)
+	}
+	if card, ok := out.(*A2AAgentCard); !ok || card.Name != "Test Agent" {
+		t.Errorf("ProviderFromCanonical() = %#v, want *A2AAgentCard for Test Agent", out)
+	}
+
+	out, err = a.ProviderFromCanonical(nil)
+	if err == nil || out != nil {
+		t.Errorf("ProviderFromCanonical(nil) = %#v, %v, want untyped nil and error", out, err)
+	}
+}
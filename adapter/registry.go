@@ -13,19 +13,110 @@ type ConversionResult struct {
 
 	// Warnings lists features that may have been lost during conversion
 	Warnings []FeatureLossWarning
+
+	// Stats summarizes how much the conversion changed the input schema.
+	Stats ConversionStats
+
+	// usageWeight is the severity-weighted count of feature occurrences in
+	// the source tool, recorded by Convert for FidelityScore.
+	usageWeight int
+}
+
+// ConversionStats quantifies how a conversion changed a tool's input schema.
+// The "Out" counts are measured on the canonical input schema handed to the
+// target, without the subschemas behind features the target drops.
+type ConversionStats struct {
+	// FeaturesLost counts Warnings that report a dropped feature, excluding
+	// rewrites and size-limit reports (the losses FidelityScore weighs).
+	FeaturesLost int
+
+	// PropertiesIn and PropertiesOut count the properties declared anywhere
+	// in the input schema before and after conversion
+	// (see JSONSchema.PropertyCount).
+	PropertiesIn  int
+	PropertiesOut int
+
+	// DepthIn and DepthOut give the input schema's nesting depth before and
+	// after conversion (see JSONSchema.Depth).
+	DepthIn  int
+	DepthOut int
+}
+
+// severityWeights assigns a scoring weight to each warning severity.
+var severityWeights = map[WarningSeverity]int{
+	SeverityInfo:    1,
+	SeverityWarning: 3,
+	SeverityError:   10,
+}
+
+// FidelityScore returns a 0-100 score for how faithfully the conversion
+// preserved the source tool, where 100 means lossless.
+//
+// Every feature occurrence is weighted by the severity of losing it
+// (info=1, warning=3, error=10). The score is
+//
+//	100 - ceil(100 * lostWeight / usedWeight)
+//
+// where lostWeight sums the weights of Warnings that are neither rewrites nor
+// size-limit reports, and usedWeight sums the weights of every feature
+// occurrence in the source tool's schemas. When usedWeight is unknown (e.g.,
+// a hand-built result) it is taken to be lostWeight, so any loss scores 0.
+func (res *ConversionResult) FidelityScore() int {
+	if res == nil {
+		return 100
+	}
+
+	lost := 0
+	for _, w := range res.Warnings {
+		if w.Rewritten || w.Limit != "" {
+			continue
+		}
+		lost += severityWeights[w.Severity]
+	}
+	if lost == 0 {
+		return 100
+	}
+
+	used := res.usageWeight
+	if used < lost {
+		used = lost
+	}
+	return 100 - (100*lost+used-1)/used
 }
 
 // AdapterRegistry is a thread-safe registry of protocol adapters.
 type AdapterRegistry struct {
 	mu       sync.RWMutex
 	adapters map[string]Adapter
+	aliases  map[string]string
 }
 
 // NewRegistry creates a new empty adapter registry.
 func NewRegistry() *AdapterRegistry {
 	return &AdapterRegistry{
 		adapters: make(map[string]Adapter),
+		aliases:  make(map[string]string),
+	}
+}
+
+// Clone returns a snapshot of the registry. The clone is independent for
+// registration: adapters and aliases registered or removed on either registry
+// do not affect the other. Adapter instances are shared, not copied.
+func (r *AdapterRegistry) Clone() *AdapterRegistry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	clone := &AdapterRegistry{
+		adapters: make(map[string]Adapter, len(r.adapters)),
+		aliases:  make(map[string]string, len(r.aliases)),
 	}
+	for name, a := range r.adapters {
+		clone.adapters[name] = a
+	}
+	for alias, name := range r.aliases {
+		clone.aliases[alias] = name
+	}
+	return clone
 }
 
 // Register adds an adapter to the registry.
@@ -38,24 +129,91 @@ func (r *AdapterRegistry) Register(a Adapter) error {
 	if _, exists := r.adapters[name]; exists {
 		return errors.New("adapter already registered: " + name)
 	}
+	if _, exists := r.aliases[name]; exists {
+		return errors.New("adapter name already registered as alias: " + name)
+	}
 	r.adapters[name] = a
 	return nil
 }
 
-// Get retrieves an adapter by name.
+// Alias registers alias as another name for the registered adapter target,
+// so Get and Convert accept either name. If target is itself an alias, the
+// new alias points at the same adapter. Returns an error if target is not
+// registered or alias is already an adapter name or alias.
+func (r *AdapterRegistry) Alias(alias, target string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if alias == "" {
+		return errors.New("alias name is required")
+	}
+	if _, exists := r.adapters[alias]; exists {
+		return errors.New("alias collides with registered adapter: " + alias)
+	}
+	if _, exists := r.aliases[alias]; exists {
+		return errors.New("alias already registered: " + alias)
+	}
+	name := r.resolveLocked(target)
+	if _, exists := r.adapters[name]; !exists {
+		return errors.New("adapter not found: " + target)
+	}
+	r.aliases[alias] = name
+	return nil
+}
+
+// Aliases returns a map from each registered alias to the adapter name it
+// resolves to.
+func (r *AdapterRegistry) Aliases() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	aliases := make(map[string]string, len(r.aliases))
+	for alias, name := range r.aliases {
+		aliases[alias] = name
+	}
+	return aliases
+}
+
+// resolveLocked maps an alias to its adapter name; other names are returned
+// unchanged. The lock must be held.
+func (r *AdapterRegistry) resolveLocked(name string) string {
+	if target, ok := r.aliases[name]; ok {
+		return target
+	}
+	return name
+}
+
+// Get retrieves an adapter by name or alias.
 // Returns an error if the adapter is not found.
 func (r *AdapterRegistry) Get(name string) (Adapter, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	adapter, exists := r.adapters[name]
+	adapter, exists := r.adapters[r.resolveLocked(name)]
 	if !exists {
 		return nil, errors.New("adapter not found: " + name)
 	}
 	return adapter, nil
 }
 
-// List returns the names of all registered adapters.
+// Has reports whether an adapter is registered under name or an alias.
+func (r *AdapterRegistry) Has(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.adapters[r.resolveLocked(name)]
+	return exists
+}
+
+// Count returns the number of registered adapters, not counting aliases.
+func (r *AdapterRegistry) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.adapters)
+}
+
+// List returns the names of all registered adapters. Aliases are not
+// included; use Aliases to list them.
 func (r *AdapterRegistry) List() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -67,7 +225,7 @@ func (r *AdapterRegistry) List() []string {
 	return names
 }
 
-// Unregister removes an adapter from the registry.
+// Unregister removes an adapter and any aliases pointing at it.
 // Returns an error if the adapter is not found.
 func (r *AdapterRegistry) Unregister(name string) error {
 	r.mu.Lock()
@@ -77,6 +235,11 @@ func (r *AdapterRegistry) Unregister(name string) error {
 		return errors.New("adapter not found: " + name)
 	}
 	delete(r.adapters, name)
+	for alias, target := range r.aliases {
+		if target == name {
+			delete(r.aliases, alias)
+		}
+	}
 	return nil
 }
 
@@ -84,6 +247,58 @@ func (r *AdapterRegistry) Unregister(name string) error {
 // It uses the source adapter's ToCanonical and the target adapter's FromCanonical.
 // Returns warnings if schema features are lost during conversion.
 func (r *AdapterRegistry) Convert(tool any, fromFormat, toFormat string) (*ConversionResult, error) {
+	return r.ConvertWithOptions(tool, fromFormat, toFormat, ConvertOptions{})
+}
+
+// RoundTrip converts a tool to canonical form and back with the same adapter,
+// returning the re-encoded tool and any warnings. Callers compare the output
+// with the input to check that the adapter is lossless for that tool.
+func (r *AdapterRegistry) RoundTrip(tool any, format string) (any, []FeatureLossWarning, error) {
+	result, err := r.Convert(tool, format, format)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result.Tool, result.Warnings, nil
+}
+
+// CanConvert reports whether converting tool from one format to another
+// would be lossless, without building the target output. It reads the tool
+// with the source adapter and runs the same feature-loss detection as
+// Convert, returning its warnings; the conversion is lossless when every
+// warning is a rewrite. The error is non-nil if an adapter is not
+// registered or the source tool cannot be read.
+func (r *AdapterRegistry) CanConvert(tool any, fromFormat, toFormat string) (bool, []FeatureLossWarning, error) {
+	source, err := r.Get(fromFormat)
+	if err != nil {
+		return false, nil, err
+	}
+	target, err := r.Get(toFormat)
+	if err != nil {
+		return false, nil, err
+	}
+
+	canonical, err := source.ToCanonical(tool)
+	if err != nil {
+		return false, nil, &ConversionError{
+			Adapter:   fromFormat,
+			Direction: "to_canonical",
+			Cause:     err,
+		}
+	}
+
+	warnings := detectFeatureLoss(canonical, source, target, ConvertOptions{})
+	lossless := true
+	for _, w := range warnings {
+		if !w.Rewritten {
+			lossless = false
+		}
+	}
+	return lossless, warnings, nil
+}
+
+// ConvertWithOptions transforms a tool from one format to another, applying opts.
+// Targets implementing OptionsAdapter receive opts; other adapters convert as in Convert.
+func (r *AdapterRegistry) ConvertWithOptions(tool any, fromFormat, toFormat string, opts ConvertOptions) (*ConversionResult, error) {
 	// Get source adapter
 	source, err := r.Get(fromFormat)
 	if err != nil {
@@ -96,128 +311,281 @@ func (r *AdapterRegistry) Convert(tool any, fromFormat, toFormat string) (*Conve
 		return nil, err
 	}
 
+	return convert(tool, source, target, opts)
+}
+
+// Convert transforms a tool from source's format to target's without a
+// registry, running the same pipeline as AdapterRegistry.Convert. It is
+// meant for tests and one-off conversions between adapters that need not be
+// registered.
+func Convert(tool any, source, target Adapter) (*ConversionResult, error) {
+	if source == nil || target == nil {
+		return nil, errors.New("source and target adapters are required")
+	}
+	return convert(tool, source, target, ConvertOptions{})
+}
+
+// convert runs the conversion pipeline from source to target.
+func convert(tool any, source, target Adapter, opts ConvertOptions) (*ConversionResult, error) {
 	// Convert to canonical
 	canonical, err := source.ToCanonical(tool)
 	if err != nil {
 		return nil, &ConversionError{
-			Adapter:   fromFormat,
+			Adapter:   source.Name(),
 			Direction: "to_canonical",
 			Cause:     err,
 		}
 	}
 
+	// Simplify schemas and sort required names
+	canonical = normalizeSchemas(canonical, opts)
+
+	// Check enum values against their declared types
+	canonical, enumWarnings := checkEnumTypes(canonical, source, target, opts)
+
 	// Check for feature loss
-	warnings := detectFeatureLoss(canonical, source, target)
+	warnings := append(enumWarnings, detectFeatureLoss(canonical, source, target, opts)...)
+
+	// Shorten overlong descriptions
+	canonical, truncated := truncateDescriptions(canonical, source, target, opts)
+	warnings = append(warnings, truncated...)
+
+	// Replace characters MCP does not allow in tool names
+	canonical, renamed := sanitizeToolName(canonical, source, target, opts)
+	warnings = append(warnings, renamed...)
 
 	// Convert from canonical
-	output, err := target.FromCanonical(canonical)
+	prepared := applyConvertOptions(canonical, target, opts)
+	output, err := fromCanonical(target, prepared, opts)
 	if err != nil {
 		return nil, &ConversionError{
-			Adapter:   toFormat,
+			Adapter:   target.Name(),
 			Direction: "from_canonical",
 			Cause:     err,
 		}
 	}
 
 	return &ConversionResult{
-		Tool:     output,
-		Warnings: warnings,
+		Tool:        output,
+		Warnings:    warnings,
+		Stats:       conversionStats(canonical, prepared, target, opts, warnings),
+		usageWeight: featureUsageWeight(canonical),
 	}, nil
 }
 
+// conversionStats measures the input schema of the canonical tool and of
+// prepared, the tool handed to target, as target will keep it.
+func conversionStats(canonical, prepared *CanonicalTool, target Adapter, opts ConvertOptions, warnings []FeatureLossWarning) ConversionStats {
+	stats := ConversionStats{
+		PropertiesIn: canonical.InputSchema.PropertyCount(),
+		DepthIn:      canonical.InputSchema.Depth(),
+	}
+	for _, w := range warnings {
+		if !w.Rewritten && w.Limit == "" {
+			stats.FeaturesLost++
+		}
+	}
+	kept := supportedSubschemas(prepared.InputSchema, target, opts)
+	stats.PropertiesOut = kept.PropertyCount()
+	stats.DepthOut = kept.Depth()
+	return stats
+}
+
+// supportedSubschemas returns a copy of schema without the subschemas
+// behind features target neither supports nor rewrites on their node.
+func supportedSubschemas(schema *JSONSchema, target Adapter, opts ConvertOptions) *JSONSchema {
+	return Transform(schema, func(node *JSONSchema) *JSONSchema {
+		drops := func(feature SchemaFeature) bool {
+			return rewriteNote(target, feature, node) == "" && !supportsFeatureAt(target, feature, node, opts)
+		}
+		out := *node
+		if drops(FeatureDefs) {
+			out.Defs = nil
+		}
+		if drops(FeatureAnyOf) {
+			out.AnyOf = nil
+		}
+		if drops(FeatureOneOf) {
+			out.OneOf = nil
+		}
+		if drops(FeatureAllOf) {
+			out.AllOf = nil
+		}
+		if drops(FeatureNot) {
+			out.Not = nil
+		}
+		if drops(FeatureIf) {
+			out.If = nil
+		}
+		if drops(FeatureThen) {
+			out.Then = nil
+		}
+		if drops(FeatureElse) {
+			out.Else = nil
+		}
+		if drops(FeatureContains) {
+			out.Contains = nil
+		}
+		if drops(FeaturePrefixItems) {
+			out.PrefixItems = nil
+		}
+		if drops(FeatureAdditionalPropertiesSchema) {
+			out.AdditionalPropertiesSchema = nil
+		}
+		return &out
+	})
+}
+
+// featureUsageWeight sums the severity weights of every feature occurrence
+// in the tool's input and output schemas.
+func featureUsageWeight(tool *CanonicalTool) int {
+	weight := 0
+	count := func(_ string, node *JSONSchema) {
+		for feature, used := range nodeFeatures(node) {
+			if used {
+				weight += severityWeights[featureSeverity(feature)]
+			}
+		}
+	}
+	walkSchema(tool.InputSchema, "", count)
+	walkSchema(tool.OutputSchema, "", count)
+	if tool.Timeout > 0 {
+		weight += severityWeights[featureSeverity(FeatureTimeout)]
+	}
+	return weight
+}
+
 // detectFeatureLoss checks which features in the canonical tool are not
 // supported by the target adapter.
-func detectFeatureLoss(tool *CanonicalTool, source, target Adapter) []FeatureLossWarning {
+func detectFeatureLoss(tool *CanonicalTool, source, target Adapter, opts ConvertOptions) []FeatureLossWarning {
 	var warnings []FeatureLossWarning
 
+	if tool.Timeout > 0 && !supportsFeature(target, FeatureTimeout, opts) {
+		warnings = append(warnings, FeatureLossWarning{
+			Feature:     FeatureTimeout,
+			Severity:    featureSeverity(FeatureTimeout),
+			FromAdapter: source.Name(),
+			ToAdapter:   target.Name(),
+			Message:     "tool timeout " + tool.Timeout.String(),
+		})
+	}
+
 	if tool.InputSchema != nil {
-		warnings = append(warnings, detectSchemaFeatureLoss(tool.InputSchema, source, target, "")...)
+		warnings = append(warnings, detectSchemaFeatureLoss(tool.InputSchema, source, target, opts, "")...)
+		warnings = append(warnings, checkSchemaLimits(tool.InputSchema, source, target, opts)...)
 	}
 	if tool.OutputSchema != nil {
-		warnings = append(warnings, detectSchemaFeatureLoss(tool.OutputSchema, source, target, "")...)
+		output := detectSchemaFeatureLoss(tool.OutputSchema, source, target, opts, "")
+		output = append(output, checkSchemaLimits(tool.OutputSchema, source, target, opts)...)
+		for _, w := range output {
+			w.InOutput = true
+			warnings = append(warnings, w)
+		}
 	}
 
 	return warnings
 }
 
 // detectSchemaFeatureLoss checks which features in a schema are not supported.
-func detectSchemaFeatureLoss(schema *JSONSchema, source, target Adapter, path string) []FeatureLossWarning {
+// Warnings are ordered deterministically: nodes depth-first with object keys
+// sorted, and features within a node in AllFeatures order.
+func detectSchemaFeatureLoss(schema *JSONSchema, source, target Adapter, opts ConvertOptions, path string) []FeatureLossWarning {
 	var warnings []FeatureLossWarning
 
-	// Check each feature that's used in the schema
-	featureUsage := map[SchemaFeature]bool{
-		FeatureRef:                  schema.Ref != "",
-		FeatureDefs:                 len(schema.Defs) > 0,
-		FeatureAnyOf:                len(schema.AnyOf) > 0,
-		FeatureOneOf:                len(schema.OneOf) > 0,
-		FeatureAllOf:                len(schema.AllOf) > 0,
-		FeatureNot:                  schema.Not != nil,
-		FeatureTitle:                schema.Title != "",
-		FeatureExamples:             len(schema.Examples) > 0,
-		FeatureMultipleOf:           schema.MultipleOf != nil,
-		FeaturePattern:              schema.Pattern != "",
-		FeatureFormat:               schema.Format != "",
-		FeatureAdditionalProperties: schema.AdditionalProperties != nil,
-		FeatureMinimum:              schema.Minimum != nil,
-		FeatureMaximum:              schema.Maximum != nil,
-		FeatureMinLength:            schema.MinLength != nil,
-		FeatureMaxLength:            schema.MaxLength != nil,
-		FeatureMinItems:             schema.MinItems != nil,
-		FeatureMaxItems:             schema.MaxItems != nil,
-		FeatureMinProperties:        schema.MinProperties != nil,
-		FeatureMaxProperties:        schema.MaxProperties != nil,
-		FeatureUniqueItems:          schema.UniqueItems != nil,
-		FeatureNullable:             schema.Nullable != nil,
-		FeatureDeprecated:           schema.Deprecated != nil,
-		FeatureReadOnly:             schema.ReadOnly != nil,
-		FeatureWriteOnly:            schema.WriteOnly != nil,
-		FeatureEnum:                 len(schema.Enum) > 0,
-		FeatureConst:                schema.Const != nil,
-		FeatureDefault:              schema.Default != nil,
-	}
-
-	for feature, used := range featureUsage {
-		if used && !target.SupportsFeature(feature) {
-			warnings = append(warnings, FeatureLossWarning{
-				Feature:     feature,
-				Path:        path,
-				FromAdapter: source.Name(),
-				ToAdapter:   target.Name(),
-			})
-		}
-	}
-
-	// Recursively check nested schemas
-	if schema.Properties != nil {
-		for name, prop := range schema.Properties {
-			propPath := joinJSONPath(path, "properties", name)
-			warnings = append(warnings, detectSchemaFeatureLoss(prop, source, target, propPath)...)
-		}
-	}
-	if schema.Items != nil {
-		warnings = append(warnings, detectSchemaFeatureLoss(schema.Items, source, target, joinJSONPath(path, "items"))...)
-	}
-	if schema.Defs != nil {
-		for name, def := range schema.Defs {
-			warnings = append(warnings, detectSchemaFeatureLoss(def, source, target, joinJSONPath(path, "$defs", name))...)
-		}
-	}
-	for i, s := range schema.AnyOf {
-		warnings = append(warnings, detectSchemaFeatureLoss(s, source, target, joinJSONPath(path, "anyOf", indexPath(i)))...)
-	}
-	for i, s := range schema.OneOf {
-		warnings = append(warnings, detectSchemaFeatureLoss(s, source, target, joinJSONPath(path, "oneOf", indexPath(i)))...)
-	}
-	for i, s := range schema.AllOf {
-		warnings = append(warnings, detectSchemaFeatureLoss(s, source, target, joinJSONPath(path, "allOf", indexPath(i)))...)
-	}
-	if schema.Not != nil {
-		warnings = append(warnings, detectSchemaFeatureLoss(schema.Not, source, target, joinJSONPath(path, "not"))...)
-	}
+	walkSchema(schema, path, func(nodePath string, node *JSONSchema) {
+		used := nodeFeatures(node)
+		for _, feature := range AllFeatures() {
+			if !used[feature] {
+				continue
+			}
+			if note := rewriteNote(target, feature, node); note != "" {
+				warnings = append(warnings, FeatureLossWarning{
+					Feature:     feature,
+					Severity:    SeverityInfo,
+					Path:        nodePath,
+					FromAdapter: source.Name(),
+					ToAdapter:   target.Name(),
+					Rewritten:   true,
+					Message:     note,
+				})
+				continue
+			}
+			if !supportsFeatureAt(target, feature, node, opts) {
+				w := FeatureLossWarning{
+					Feature:     feature,
+					Severity:    featureSeverity(feature),
+					Path:        nodePath,
+					FromAdapter: source.Name(),
+					ToAdapter:   target.Name(),
+				}
+				switch {
+				case opts.describesFeature(feature):
+					w.Message = "noted in description"
+				case feature == FeatureConst:
+					w.Message = "widens accepted input"
+					if node.Type != "" {
+						w.Message += " to any " + node.Type
+					}
+				}
+				warnings = append(warnings, w)
+			}
+		}
+	})
 
 	return warnings
 }
 
+// rewriteNote returns the target's rewrite note for feature on node, if any.
+func rewriteNote(target Adapter, feature SchemaFeature, node *JSONSchema) string {
+	if rw, ok := target.(FeatureRewriter); ok {
+		return rw.RewriteNote(feature, node)
+	}
+	return ""
+}
+
+// nodeFeatures reports which features are used directly on a schema node,
+// not counting nested schemas.
+func nodeFeatures(schema *JSONSchema) map[SchemaFeature]bool {
+	return map[SchemaFeature]bool{
+		FeatureRef:                        schema.Ref != "",
+		FeatureDefs:                       len(schema.Defs) > 0,
+		FeatureAnyOf:                      len(schema.AnyOf) > 0,
+		FeatureOneOf:                      len(schema.OneOf) > 0,
+		FeatureAllOf:                      len(schema.AllOf) > 0,
+		FeatureNot:                        schema.Not != nil,
+		FeatureIf:                         schema.If != nil,
+		FeatureThen:                       schema.Then != nil,
+		FeatureElse:                       schema.Else != nil,
+		FeatureTitle:                      schema.Title != "",
+		FeatureExamples:                   len(schema.Examples) > 0,
+		FeatureMultipleOf:                 schema.MultipleOf != nil,
+		FeaturePattern:                    schema.Pattern != "",
+		FeatureFormat:                     schema.Format != "",
+		FeatureAdditionalProperties:       schema.AdditionalProperties != nil,
+		FeatureAdditionalPropertiesSchema: schema.AdditionalPropertiesSchema != nil,
+		FeatureMinimum:                    schema.Minimum != nil || schema.ExclusiveMinimum != nil,
+		FeatureMaximum:                    schema.Maximum != nil || schema.ExclusiveMaximum != nil,
+		FeatureMinLength:                  schema.MinLength != nil,
+		FeatureMaxLength:                  schema.MaxLength != nil,
+		FeatureMinItems:                   schema.MinItems != nil,
+		FeatureMaxItems:                   schema.MaxItems != nil,
+		FeatureContains:                   schema.Contains != nil,
+		FeatureMinContains:                schema.MinContains != nil,
+		FeatureMaxContains:                schema.MaxContains != nil,
+		FeaturePrefixItems:                len(schema.PrefixItems) > 0,
+		FeatureMinProperties:              schema.MinProperties != nil,
+		FeatureMaxProperties:              schema.MaxProperties != nil,
+		FeatureUniqueItems:                schema.UniqueItems != nil,
+		FeatureNullable:                   schema.Nullable != nil,
+		FeatureDeprecated:                 schema.Deprecated != nil,
+		FeatureReadOnly:                   schema.ReadOnly != nil,
+		FeatureWriteOnly:                  schema.WriteOnly != nil,
+		FeatureEnum:                       len(schema.Enum) > 0,
+		FeatureConst:                      schema.hasConst(),
+		FeatureDefault:                    schema.Default != nil,
+	}
+}
+
 func joinJSONPath(base string, segments ...string) string {
 	path := base
 	for _, seg := range segments {
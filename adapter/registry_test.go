@@ -2,6 +2,7 @@ package adapter
 
 import (
 	"errors"
+	"reflect"
 	"sort"
 	"sync"
 	"testing"
@@ -88,6 +89,47 @@ func TestRegistry_List_Empty(t *testing.T) {
 	}
 }
 
+func TestRegistry_Has(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Register(&mockAdapter{name: "openai"})
+	_ = r.Alias("gpt", "openai")
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "openai", want: true},
+		{name: "gpt", want: true},
+		{name: "anthropic", want: false},
+		{name: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := r.Has(tt.name); got != tt.want {
+			t.Errorf("Has(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRegistry_Count(t *testing.T) {
+	r := NewRegistry()
+	if got := r.Count(); got != 0 {
+		t.Errorf("Count() empty = %d, want 0", got)
+	}
+
+	_ = r.Register(&mockAdapter{name: "a"})
+	_ = r.Register(&mockAdapter{name: "b"})
+	_ = r.Alias("alias", "a")
+	if got := r.Count(); got != 2 {
+		t.Errorf("Count() = %d, want 2 (aliases excluded)", got)
+	}
+
+	_ = r.Unregister("a")
+	if got := r.Count(); got != 1 {
+		t.Errorf("Count() after Unregister = %d, want 1", got)
+	}
+}
+
 func TestRegistry_Unregister_Success(t *testing.T) {
 	r := NewRegistry()
 	_ = r.Register(&mockAdapter{name: "test"})
@@ -115,6 +157,143 @@ func TestRegistry_Unregister_NotFound(t *testing.T) {
 	}
 }
 
+func TestRegistry_Alias(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Register(&mockAdapter{name: "openai"})
+	_ = r.Register(&mockAdapter{name: "mcp"})
+
+	if err := r.Alias("gpt", "openai"); err != nil {
+		t.Fatalf("Alias(gpt) = %v, want nil", err)
+	}
+	if err := r.Alias("function-calling", "gpt"); err != nil {
+		t.Fatalf("Alias(function-calling) via alias = %v, want nil", err)
+	}
+
+	for _, name := range []string{"openai", "gpt", "function-calling"} {
+		a, err := r.Get(name)
+		if err != nil {
+			t.Fatalf("Get(%q) error = %v", name, err)
+		}
+		if a.Name() != "openai" {
+			t.Errorf("Get(%q).Name() = %q, want openai", name, a.Name())
+		}
+	}
+
+	want := map[string]string{"gpt": "openai", "function-calling": "openai"}
+	if got := r.Aliases(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Aliases() = %v, want %v", got, want)
+	}
+	if got := r.List(); len(got) != 2 {
+		t.Errorf("List() = %v, want only the 2 adapters", got)
+	}
+}
+
+func TestRegistry_Alias_Errors(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Register(&mockAdapter{name: "openai"})
+	_ = r.Register(&mockAdapter{name: "mcp"})
+	_ = r.Alias("gpt", "openai")
+
+	tests := []struct {
+		name   string
+		alias  string
+		target string
+	}{
+		{name: "missing target", alias: "claude", target: "anthropic"},
+		{name: "collides with adapter", alias: "mcp", target: "openai"},
+		{name: "duplicate alias", alias: "gpt", target: "mcp"},
+		{name: "empty alias", alias: "", target: "openai"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.Alias(tt.alias, tt.target); err == nil {
+				t.Errorf("Alias(%q, %q) = nil, want error", tt.alias, tt.target)
+			}
+		})
+	}
+
+	if err := r.Register(&mockAdapter{name: "gpt"}); err == nil {
+		t.Error("Register() with alias name = nil, want error")
+	}
+}
+
+func TestRegistry_Alias_Unregister(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Register(&mockAdapter{name: "openai"})
+	_ = r.Alias("gpt", "openai")
+
+	if err := r.Unregister("openai"); err != nil {
+		t.Fatalf("Unregister() = %v", err)
+	}
+	if _, err := r.Get("gpt"); err == nil {
+		t.Error("Get(alias) after Unregister() should return error")
+	}
+	if got := r.Aliases(); len(got) != 0 {
+		t.Errorf("Aliases() after Unregister() = %v, want empty", got)
+	}
+}
+
+func TestRegistry_Convert_Alias(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Register(&mockAdapter{
+		name: "source",
+		toCanonicalFunc: func(raw any) (*CanonicalTool, error) {
+			return &CanonicalTool{Name: "test-tool", InputSchema: &JSONSchema{Type: "object"}}, nil
+		},
+	})
+	_ = r.Register(&mockAdapter{
+		name: "target",
+		fromCanonicalFunc: func(tool *CanonicalTool) (any, error) {
+			return tool.Name, nil
+		},
+	})
+	_ = r.Alias("src", "source")
+	_ = r.Alias("dst", "target")
+
+	result, err := r.Convert("input", "src", "dst")
+	if err != nil {
+		t.Fatalf("Convert() via aliases error = %v", err)
+	}
+	if result.Tool != "test-tool" {
+		t.Errorf("Convert() Tool = %v, want test-tool", result.Tool)
+	}
+}
+
+func TestRegistry_Clone(t *testing.T) {
+	shared := &mockAdapter{name: "shared"}
+	r := NewRegistry()
+	_ = r.Register(shared)
+	_ = r.Alias("common", "shared")
+
+	clone := r.Clone()
+	if err := clone.Register(&mockAdapter{name: "tenant"}); err != nil {
+		t.Fatalf("clone.Register() = %v", err)
+	}
+	if err := clone.Unregister("shared"); err != nil {
+		t.Fatalf("clone.Unregister() = %v", err)
+	}
+
+	if _, err := r.Get("tenant"); err == nil {
+		t.Error("original Get(tenant) = nil error, want not found")
+	}
+	got, err := r.Get("common")
+	if err != nil {
+		t.Fatalf("original Get(common) error = %v", err)
+	}
+	if got != Adapter(shared) {
+		t.Error("original Get(common) returned a different adapter instance")
+	}
+	if _, err := clone.Get("common"); err == nil {
+		t.Error("clone Get(common) after Unregister = nil error, want not found")
+	}
+
+	clone2 := r.Clone()
+	if a, _ := clone2.Get("shared"); a != Adapter(shared) {
+		t.Error("Clone() should share adapter instances")
+	}
+}
+
 func TestRegistry_Convert_Success(t *testing.T) {
 	r := NewRegistry()
 
@@ -293,6 +472,253 @@ func TestRegistry_Convert_FeatureWarnings(t *testing.T) {
 	}
 }
 
+// stringPatternAdapter supports pattern only on string schemas.
+type stringPatternAdapter struct {
+	mockAdapter
+}
+
+func (a *stringPatternAdapter) FeatureSupport(feature SchemaFeature, node *JSONSchema) bool {
+	if feature == FeaturePattern {
+		return node.Type == "string"
+	}
+	return a.SupportsFeature(feature)
+}
+
+func TestRegistry_Convert_PartialFeatureSupport(t *testing.T) {
+	r := NewRegistry()
+
+	source := &mockAdapter{
+		name: "source",
+		toCanonicalFunc: func(raw any) (*CanonicalTool, error) {
+			return &CanonicalTool{
+				Name: "test",
+				InputSchema: &JSONSchema{
+					Type: "object",
+					Properties: map[string]*JSONSchema{
+						"code": {Type: "string", Pattern: "^[A-Z]+$"},
+						"id":   {Type: "integer", Pattern: "^[0-9]+$"},
+					},
+				},
+			}, nil
+		},
+		supportsFunc: func(f SchemaFeature) bool { return true },
+	}
+	target := &stringPatternAdapter{mockAdapter{
+		name: "target",
+		fromCanonicalFunc: func(tool *CanonicalTool) (any, error) {
+			return tool.Name, nil
+		},
+		supportsFunc: func(f SchemaFeature) bool { return f != FeaturePattern },
+	}}
+
+	_ = r.Register(source)
+	_ = r.Register(target)
+
+	result, err := r.Convert("input", "source", "target")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	var paths []string
+	for _, w := range result.Warnings {
+		if w.Feature == FeaturePattern {
+			paths = append(paths, w.Path)
+		}
+	}
+	if want := []string{"/properties/id"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("pattern warning paths = %v, want %v", paths, want)
+	}
+}
+
+func TestRegistry_Convert_Stats(t *testing.T) {
+	tool := &AnthropicTool{
+		Name: "lookup",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id": map[string]any{"type": "string", "format": "uuid"},
+				"filter": map[string]any{
+					"anyOf": []any{
+						map[string]any{"type": "string"},
+						map[string]any{
+							"type":       "object",
+							"properties": map[string]any{"field": map[string]any{"type": "string"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := DefaultRegistry().Convert(tool, "anthropic", "openai")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	want := ConversionStats{
+		FeaturesLost:  2, // format and anyOf
+		PropertiesIn:  3,
+		PropertiesOut: 2,
+		DepthIn:       4,
+		DepthOut:      2,
+	}
+	if result.Stats != want {
+		t.Errorf("Stats = %+v, want %+v", result.Stats, want)
+	}
+}
+
+func TestRegistry_Convert_Stats_WithoutReverseParse(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Register(&mockAdapter{
+		name: "source",
+		toCanonicalFunc: func(any) (*CanonicalTool, error) {
+			return &CanonicalTool{
+				Name: "lookup",
+				InputSchema: &JSONSchema{
+					Type: "object",
+					Properties: map[string]*JSONSchema{
+						"id": {Type: "string"},
+						"filter": {AnyOf: []*JSONSchema{
+							{Type: "object", Properties: map[string]*JSONSchema{"field": {Type: "string"}}},
+							{Type: "null"},
+						}},
+					},
+				},
+			}, nil
+		},
+		supportsFunc: func(SchemaFeature) bool { return true },
+	})
+	_ = r.Register(&mockAdapter{
+		name: "target",
+		toCanonicalFunc: func(any) (*CanonicalTool, error) {
+			t.Error("Convert() should not read the output back")
+			return nil, errors.New("cannot read output")
+		},
+		fromCanonicalFunc: func(tool *CanonicalTool) (any, error) {
+			return tool.Name, nil
+		},
+		supportsFunc: func(f SchemaFeature) bool { return f != FeatureAnyOf },
+	})
+
+	result, err := r.Convert("input", "source", "target")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	want := ConversionStats{
+		FeaturesLost:  1,
+		PropertiesIn:  3,
+		PropertiesOut: 2,
+		DepthIn:       4,
+		DepthOut:      2,
+	}
+	if result.Stats != want {
+		t.Errorf("Stats = %+v, want %+v", result.Stats, want)
+	}
+}
+
+func TestRegistry_Convert_ConstDropWidensInput(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Register(&mockAdapter{
+		name: "source",
+		toCanonicalFunc: func(raw any) (*CanonicalTool, error) {
+			return &CanonicalTool{
+				Name: "test",
+				InputSchema: &JSONSchema{
+					Type: "object",
+					Properties: map[string]*JSONSchema{
+						"mode": {Type: "string", Title: "Mode", Const: "fast", HasConst: true},
+					},
+				},
+			}, nil
+		},
+		supportsFunc: func(f SchemaFeature) bool { return true },
+	})
+	_ = r.Register(&mockAdapter{
+		name: "target",
+		fromCanonicalFunc: func(tool *CanonicalTool) (any, error) {
+			return tool.Name, nil
+		},
+		supportsFunc: func(f SchemaFeature) bool {
+			return f != FeatureConst && f != FeatureTitle
+		},
+	})
+
+	result, err := r.Convert("input", "source", "target")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	got := make(map[SchemaFeature]FeatureLossWarning)
+	for _, w := range result.Warnings {
+		got[w.Feature] = w
+	}
+	if len(got) != 2 {
+		t.Fatalf("Convert() warnings = %v, want const and title", result.Warnings)
+	}
+	if w := got[FeatureConst]; w.Severity != SeverityError || w.Message != "widens accepted input to any string" {
+		t.Errorf("const warning = %+v, want error severity noting widened input", w)
+	}
+	if w := got[FeatureTitle]; w.Severity != SeverityInfo || w.Message != "" {
+		t.Errorf("title warning = %+v, want info severity", w)
+	}
+}
+
+func TestRegistry_Convert_FeatureWarnings_InOutput(t *testing.T) {
+	r := NewRegistry()
+
+	// Source that uses $ref in the input schema and anyOf in the output schema
+	source := &mockAdapter{
+		name: "source",
+		toCanonicalFunc: func(raw any) (*CanonicalTool, error) {
+			return &CanonicalTool{
+				Name: "test",
+				InputSchema: &JSONSchema{
+					Type: "object",
+					Ref:  "#/$defs/Something",
+				},
+				OutputSchema: &JSONSchema{
+					AnyOf: []*JSONSchema{{Type: "string"}, {Type: "null"}},
+				},
+			}, nil
+		},
+		supportsFunc: func(f SchemaFeature) bool { return true },
+	}
+
+	// Target that supports neither
+	target := &mockAdapter{
+		name: "target",
+		fromCanonicalFunc: func(tool *CanonicalTool) (any, error) {
+			return tool.Name, nil
+		},
+		supportsFunc: func(f SchemaFeature) bool {
+			return f != FeatureRef && f != FeatureAnyOf
+		},
+	}
+
+	_ = r.Register(source)
+	_ = r.Register(target)
+
+	result, err := r.Convert("input", "source", "target")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	want := map[SchemaFeature]bool{FeatureRef: false, FeatureAnyOf: true}
+	if len(result.Warnings) != len(want) {
+		t.Fatalf("Convert() warnings = %v, want %d", result.Warnings, len(want))
+	}
+	for _, w := range result.Warnings {
+		inOutput, ok := want[w.Feature]
+		if !ok {
+			t.Errorf("unexpected warning %v", w)
+			continue
+		}
+		if w.InOutput != inOutput {
+			t.Errorf("%s warning InOutput = %v, want %v", w.Feature, w.InOutput, inOutput)
+		}
+	}
+}
+
 func TestRegistry_Concurrent(t *testing.T) {
 	r := NewRegistry()
 
@@ -371,3 +797,166 @@ func TestRegistry_Convert_SameFormat(t *testing.T) {
 		t.Errorf("Convert() same format result = %v, want %q", result.Tool, "test")
 	}
 }
+
+func TestRegistry_RoundTrip(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Register(&mockAdapter{
+		name: "lossy",
+		toCanonicalFunc: func(raw any) (*CanonicalTool, error) {
+			return &CanonicalTool{
+				Name: raw.(string),
+				InputSchema: &JSONSchema{
+					Type:  "object",
+					AnyOf: []*JSONSchema{{Type: "object"}},
+				},
+			}, nil
+		},
+		fromCanonicalFunc: func(tool *CanonicalTool) (any, error) {
+			return tool.Name, nil
+		},
+		supportsFunc: func(f SchemaFeature) bool { return f != FeatureAnyOf },
+	})
+
+	out, warnings, err := r.RoundTrip("search", "lossy")
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	if out != "search" {
+		t.Errorf("RoundTrip() = %v, want %q", out, "search")
+	}
+	if len(warnings) != 1 || warnings[0].Feature != FeatureAnyOf {
+		t.Errorf("RoundTrip() warnings = %v, want one anyOf loss", warnings)
+	}
+}
+
+func TestRegistry_RoundTrip_Errors(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Register(&mockAdapter{
+		name: "failing",
+		toCanonicalFunc: func(raw any) (*CanonicalTool, error) {
+			return nil, errors.New("bad input")
+		},
+	})
+
+	if _, _, err := r.RoundTrip("input", "missing"); err == nil {
+		t.Error("RoundTrip() with missing adapter = nil, want error")
+	}
+	_, _, err := r.RoundTrip("input", "failing")
+	var convErr *ConversionError
+	if !errors.As(err, &convErr) || convErr.Direction != "to_canonical" {
+		t.Errorf("RoundTrip() error = %v, want to_canonical ConversionError", err)
+	}
+}
+
+func TestRegistry_CanConvert(t *testing.T) {
+	r := NewRegistry()
+	source := &mockAdapter{
+		name: "source",
+		toCanonicalFunc: func(raw any) (*CanonicalTool, error) {
+			if raw == "bad" {
+				return nil, errors.New("unreadable")
+			}
+			schema := &JSONSchema{Type: "object"}
+			if raw == "ref" {
+				schema.Ref = "#/$defs/Something"
+			}
+			return &CanonicalTool{Name: "test", InputSchema: schema}, nil
+		},
+		supportsFunc: func(f SchemaFeature) bool { return true },
+	}
+	target := &mockAdapter{
+		name: "target",
+		fromCanonicalFunc: func(tool *CanonicalTool) (any, error) {
+			t.Error("CanConvert() built the target output")
+			return nil, nil
+		},
+		supportsFunc: func(f SchemaFeature) bool { return f != FeatureRef },
+	}
+	_ = r.Register(source)
+	_ = r.Register(target)
+
+	ok, warnings, err := r.CanConvert("plain", "source", "target")
+	if err != nil || !ok || len(warnings) != 0 {
+		t.Errorf("CanConvert(plain) = %v, %v, %v, want lossless", ok, warnings, err)
+	}
+
+	ok, warnings, err = r.CanConvert("ref", "source", "target")
+	if err != nil || ok {
+		t.Errorf("CanConvert(ref) = %v, %v, want lossy without error", ok, err)
+	}
+	if len(warnings) != 1 || warnings[0].Feature != FeatureRef {
+		t.Errorf("CanConvert(ref) warnings = %v, want one $ref warning", warnings)
+	}
+
+	if _, _, err := r.CanConvert("bad", "source", "target"); err == nil {
+		t.Error("CanConvert(bad) error = nil, want source error")
+	}
+	if _, _, err := r.CanConvert("plain", "source", "missing"); err == nil {
+		t.Error("CanConvert(missing target) error = nil, want error")
+	}
+}
+
+func TestConvert_WithoutRegistry(t *testing.T) {
+	source := &mockAdapter{
+		name: "source",
+		toCanonicalFunc: func(raw any) (*CanonicalTool, error) {
+			if raw == "bad" {
+				return nil, errors.New("unreadable")
+			}
+			return &CanonicalTool{
+				Name:        raw.(string),
+				InputSchema: &JSONSchema{Type: "object", Ref: "#/$defs/Something"},
+			}, nil
+		},
+		supportsFunc: func(f SchemaFeature) bool { return true },
+	}
+	target := &mockAdapter{
+		name: "target",
+		fromCanonicalFunc: func(tool *CanonicalTool) (any, error) {
+			return tool.Name, nil
+		},
+		supportsFunc: func(f SchemaFeature) bool { return f != FeatureRef },
+	}
+
+	result, err := Convert("lookup", source, target)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if result.Tool != "lookup" {
+		t.Errorf("Convert().Tool = %v, want %q", result.Tool, "lookup")
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Feature != FeatureRef {
+		t.Errorf("Convert().Warnings = %v, want one $ref warning", result.Warnings)
+	}
+	if result.Warnings[0].FromAdapter != "source" || result.Warnings[0].ToAdapter != "target" {
+		t.Errorf("Convert() warning adapters = %q -> %q, want source -> target",
+			result.Warnings[0].FromAdapter, result.Warnings[0].ToAdapter)
+	}
+
+	_, err = Convert("bad", source, target)
+	var convErr *ConversionError
+	if !errors.As(err, &convErr) || convErr.Adapter != "source" || convErr.Direction != "to_canonical" {
+		t.Errorf("Convert(bad) error = %v, want to_canonical ConversionError from source", err)
+	}
+
+	if _, err := Convert("lookup", nil, target); err == nil {
+		t.Error("Convert() with nil source = nil, want error")
+	}
+	if _, err := Convert("lookup", source, nil); err == nil {
+		t.Error("Convert() with nil target = nil, want error")
+	}
+}
+
+func TestConversionResult_FidelityScore_WithoutUsage(t *testing.T) {
+	res := &ConversionResult{
+		Warnings: []FeatureLossWarning{{Feature: FeatureTitle, Severity: SeverityInfo}},
+	}
+	if got := res.FidelityScore(); got != 0 {
+		t.Errorf("FidelityScore() = %d, want 0 when usage is unknown", got)
+	}
+
+	var nilResult *ConversionResult
+	if got := nilResult.FidelityScore(); got != 100 {
+		t.Errorf("FidelityScore() on nil = %d, want 100", got)
+	}
+}
@@ -0,0 +1,158 @@
+package adapter
+
+import (
+	"math"
+	"slices"
+	"strings"
+)
+
+// Example synthesizes a minimal value for the schema, such as a smoke-test
+// payload for a tool's input. Each node yields, in order of preference, its
+// default, its const, its first enum value, or a minimal value of its type:
+//
+//   - objects include only required properties, plus further properties in
+//     sorted order until minProperties is met;
+//   - arrays hold minItems copies of the items example;
+//   - strings are minLength "a" characters;
+//   - numbers are 0, moved into [minimum, maximum] when bounded, and
+//     integers are returned as int;
+//   - booleans are false.
+//
+// A node without a type uses its first anyOf or oneOf member, or is read as
+// an object when it has properties. Local $refs are resolved against the
+// receiver's $defs; a cyclic or unresolvable ref yields nil. Patterns and
+// formats are not honored. The result is deterministic. Returns nil if the
+// receiver is nil.
+func (s *JSONSchema) Example() any {
+	if s == nil {
+		return nil
+	}
+	g := exampleGenerator{root: s, visiting: make(map[string]bool)}
+	return g.example(s)
+}
+
+// exampleGenerator carries the root schema for $ref resolution and the
+// definitions being expanded, which bound recursion through cyclic refs.
+type exampleGenerator struct {
+	root     *JSONSchema
+	visiting map[string]bool
+}
+
+func (g *exampleGenerator) example(s *JSONSchema) any {
+	if s == nil {
+		return nil
+	}
+	switch {
+	case s.Default != nil:
+		return s.Default
+	case s.hasConst():
+		return s.Const
+	case len(s.Enum) > 0:
+		return s.Enum[0]
+	case s.Ref != "":
+		return g.refExample(s.Ref)
+	}
+
+	switch s.Type {
+	case "object":
+		return g.objectExample(s)
+	case "array":
+		n := 0
+		if s.MinItems != nil {
+			n = *s.MinItems
+		}
+		items := make([]any, n)
+		for i := range items {
+			items[i] = g.example(s.Items)
+		}
+		return items
+	case "string":
+		n := 0
+		if s.MinLength != nil {
+			n = *s.MinLength
+		}
+		return strings.Repeat("a", n)
+	case "integer":
+		return int(numberExample(s, true))
+	case "number":
+		return numberExample(s, false)
+	case "boolean":
+		return false
+	case "null":
+		return nil
+	}
+
+	switch {
+	case len(s.AnyOf) > 0:
+		return g.example(s.AnyOf[0])
+	case len(s.OneOf) > 0:
+		return g.example(s.OneOf[0])
+	case len(s.Properties) > 0:
+		return g.objectExample(s)
+	default:
+		return nil
+	}
+}
+
+// refExample expands a local $ref, returning nil when the target is unknown
+// or already being expanded.
+func (g *exampleGenerator) refExample(ref string) any {
+	name, ok := g.root.refTarget(ref)
+	if !ok || g.visiting[name] {
+		return nil
+	}
+	g.visiting[name] = true
+	defer delete(g.visiting, name)
+
+	if name == "" {
+		root := *g.root
+		root.Defs = nil
+		return g.example(&root)
+	}
+	return g.example(g.root.Defs[name])
+}
+
+// objectExample builds an object from the required properties, topping up
+// with optional ones in sorted order to reach minProperties.
+func (g *exampleGenerator) objectExample(s *JSONSchema) map[string]any {
+	obj := make(map[string]any)
+	for _, name := range s.Required {
+		obj[name] = g.example(s.Properties[name])
+	}
+	if s.MinProperties != nil {
+		for _, name := range sortedKeys(s.Properties) {
+			if len(obj) >= *s.MinProperties {
+				break
+			}
+			if !slices.Contains(s.Required, name) {
+				obj[name] = g.example(s.Properties[name])
+			}
+		}
+	}
+	return obj
+}
+
+// numberExample returns 0 moved into the schema's bounds, rounded to a
+// whole number when integer is set.
+func numberExample(s *JSONSchema, integer bool) float64 {
+	v := 0.0
+	if s.Minimum != nil && v < *s.Minimum {
+		v = *s.Minimum
+	}
+	if s.ExclusiveMinimum != nil && v <= *s.ExclusiveMinimum {
+		v = *s.ExclusiveMinimum + 1
+	}
+	if s.Maximum != nil && v > *s.Maximum {
+		v = *s.Maximum
+	}
+	if s.ExclusiveMaximum != nil && v >= *s.ExclusiveMaximum {
+		v = *s.ExclusiveMaximum - 1
+	}
+	if !integer {
+		return v
+	}
+	if v >= 0 {
+		return math.Ceil(v)
+	}
+	return math.Floor(v)
+}
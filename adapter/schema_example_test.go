@@ -0,0 +1,144 @@
+package adapter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSONSchema_Example(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *JSONSchema
+		want   any
+	}{
+		{name: "nil", schema: nil, want: nil},
+		{name: "empty", schema: &JSONSchema{}, want: nil},
+		{name: "string", schema: &JSONSchema{Type: "string"}, want: ""},
+		{name: "string minLength", schema: &JSONSchema{Type: "string", MinLength: intPtr(3)}, want: "aaa"},
+		{name: "integer", schema: &JSONSchema{Type: "integer"}, want: 0},
+		{name: "integer minimum", schema: &JSONSchema{Type: "integer", Minimum: floatPtr(1.5)}, want: 2},
+		{name: "integer exclusive maximum", schema: &JSONSchema{Type: "integer", ExclusiveMaximum: floatPtr(0)}, want: -1},
+		{name: "number maximum", schema: &JSONSchema{Type: "number", Maximum: floatPtr(-2.5)}, want: -2.5},
+		{name: "boolean", schema: &JSONSchema{Type: "boolean"}, want: false},
+		{name: "null", schema: &JSONSchema{Type: "null"}, want: nil},
+		{
+			name:   "default wins",
+			schema: &JSONSchema{Type: "string", Default: "d", Const: "c", Enum: []any{"e"}},
+			want:   "d",
+		},
+		{name: "const before enum", schema: &JSONSchema{Type: "string", Const: "c", Enum: []any{"e"}}, want: "c"},
+		{name: "enum", schema: &JSONSchema{Type: "string", Enum: []any{"red", "green"}}, want: "red"},
+		{
+			name:   "anyOf first member",
+			schema: &JSONSchema{AnyOf: []*JSONSchema{{Type: "integer"}, {Type: "string"}}},
+			want:   0,
+		},
+		{name: "array", schema: &JSONSchema{Type: "array", Items: &JSONSchema{Type: "string"}}, want: []any{}},
+		{
+			name:   "array minItems",
+			schema: &JSONSchema{Type: "array", Items: &JSONSchema{Type: "boolean"}, MinItems: intPtr(2)},
+			want:   []any{false, false},
+		},
+		{
+			name: "object required only",
+			schema: &JSONSchema{
+				Type: "object",
+				Properties: map[string]*JSONSchema{
+					"query": {Type: "string", MinLength: intPtr(1)},
+					"limit": {Type: "integer", Minimum: floatPtr(1)},
+					"mode":  {Type: "string", Enum: []any{"fast", "full"}},
+				},
+				Required: []string{"query", "mode"},
+			},
+			want: map[string]any{"query": "a", "mode": "fast"},
+		},
+		{
+			name: "object minProperties",
+			schema: &JSONSchema{
+				Type: "object",
+				Properties: map[string]*JSONSchema{
+					"c": {Type: "string"},
+					"b": {Type: "boolean"},
+					"a": {Type: "integer"},
+				},
+				Required:      []string{"c"},
+				MinProperties: intPtr(2),
+			},
+			want: map[string]any{"c": "", "a": 0},
+		},
+		{
+			name: "nested object in array",
+			schema: &JSONSchema{
+				Type: "array",
+				Items: &JSONSchema{
+					Properties: map[string]*JSONSchema{"id": {Type: "integer"}},
+					Required:   []string{"id"},
+				},
+				MinItems: intPtr(1),
+			},
+			want: []any{map[string]any{"id": 0}},
+		},
+		{
+			name: "ref",
+			schema: &JSONSchema{
+				Type:       "object",
+				Properties: map[string]*JSONSchema{"user": {Ref: "#/$defs/User"}},
+				Required:   []string{"user"},
+				Defs: map[string]*JSONSchema{
+					"User": {
+						Type:       "object",
+						Properties: map[string]*JSONSchema{"name": {Type: "string"}},
+						Required:   []string{"name"},
+					},
+				},
+			},
+			want: map[string]any{"user": map[string]any{"name": ""}},
+		},
+		{
+			name: "cyclic ref",
+			schema: &JSONSchema{
+				Ref: "#/$defs/Node",
+				Defs: map[string]*JSONSchema{
+					"Node": {
+						Type:       "object",
+						Properties: map[string]*JSONSchema{"next": {Ref: "#/$defs/Node"}},
+						Required:   []string{"next"},
+					},
+				},
+			},
+			want: map[string]any{"next": nil},
+		},
+		{name: "unknown ref", schema: &JSONSchema{Ref: "#/$defs/Missing"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.schema.Example()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Example() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONSchema_Example_Valid(t *testing.T) {
+	schema := &JSONSchema{
+		Type: "object",
+		Properties: map[string]*JSONSchema{
+			"name": {Type: "string", MinLength: intPtr(2), MaxLength: intPtr(8)},
+			"tags": {Type: "array", Items: &JSONSchema{Type: "string"}, MinItems: intPtr(1)},
+			"size": {Type: "integer", ExclusiveMinimum: floatPtr(0), Maximum: floatPtr(10)},
+			"kind": {Type: "string", Enum: []any{"a", "b"}},
+		},
+		Required: []string{"name", "tags", "size", "kind"},
+	}
+
+	if first, second := schema.Example(), schema.Example(); !reflect.DeepEqual(first, second) {
+		t.Fatalf("Example() is not deterministic: %#v vs %#v", first, second)
+	}
+
+	want := map[string]any{"name": "aa", "tags": []any{""}, "size": 1, "kind": "a"}
+	if got := schema.Example(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Example() = %#v, want %#v", got, want)
+	}
+}
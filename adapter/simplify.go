@@ -0,0 +1,145 @@
+package adapter
+
+import (
+	"reflect"
+	"slices"
+)
+
+// SimplifySchema returns a copy of s with degenerate combinators removed, as
+// often produced by code generators:
+//
+//   - an anyOf, oneOf, or allOf with a single member is merged into the
+//     enclosing schema, so {"anyOf": [{"type": "string"}]} becomes
+//     {"type": "string"} and {"description": "d", "allOf": [{"$ref": "#/$defs/A"}]}
+//     becomes {"description": "d", "$ref": "#/$defs/A"};
+//   - empty anyOf, oneOf, and allOf lists are removed;
+//   - repeated names in required lists are removed.
+//
+// A single member is merged only when it does not conflict with the
+// enclosing schema: shared keywords must have equal values, except that
+// properties and $defs may add new names and required lists are joined.
+// When either side restricts other properties with additionalProperties,
+// their properties and $defs must match exactly, since a merged name would
+// escape the restriction. Otherwise the combinator is kept. s is never
+// modified.
+func SimplifySchema(s *JSONSchema) *JSONSchema {
+	return Transform(s, simplifyNode)
+}
+
+// simplifyNode collapses node's degenerate combinators, repeating until the
+// result has none left to collapse.
+func simplifyNode(node *JSONSchema) *JSONSchema {
+	for {
+		out := *node
+		out.Required = dedupeRequired(out.Required)
+		out.AnyOf = nilIfEmpty(out.AnyOf)
+		out.OneOf = nilIfEmpty(out.OneOf)
+		out.AllOf = nilIfEmpty(out.AllOf)
+
+		merged, ok := mergeSingleMember(&out)
+		if !ok {
+			return &out
+		}
+		node = merged
+	}
+}
+
+// mergeSingleMember merges into s the sole member of the first
+// single-member combinator that does not conflict with the rest of s. It
+// reports false when there is none.
+func mergeSingleMember(s *JSONSchema) (*JSONSchema, bool) {
+	for _, keyword := range []string{"anyOf", "oneOf", "allOf"} {
+		parent := *s
+		var list []*JSONSchema
+		switch keyword {
+		case "anyOf":
+			list, parent.AnyOf = s.AnyOf, nil
+		case "oneOf":
+			list, parent.OneOf = s.OneOf, nil
+		case "allOf":
+			list, parent.AllOf = s.AllOf, nil
+		}
+		if len(list) != 1 || list[0] == nil {
+			continue
+		}
+
+		m := parent.ToMap()
+		if mergeSchemaMaps(m, list[0].ToMap()) {
+			return schemaFromMap(m), true
+		}
+	}
+	return nil, false
+}
+
+// propertyRestrictingKeywords limit the properties a schema accepts beyond
+// those it names, so names merged in from another schema would change
+// what they accept.
+var propertyRestrictingKeywords = []string{"additionalProperties", "unevaluatedProperties", "patternProperties"}
+
+// mergeSchemaMaps adds the keywords of src to dst, reporting false if a
+// keyword both define has different values. properties and $defs are
+// merged by name and required lists are joined, unless either side has a
+// property-restricting keyword, in which case they must be equal.
+func mergeSchemaMaps(dst, src map[string]any) bool {
+	if restrictsProperties(dst) || restrictsProperties(src) {
+		for _, k := range []string{"properties", "$defs"} {
+			if !reflect.DeepEqual(dst[k], src[k]) {
+				return false
+			}
+		}
+	}
+	for k, v := range src {
+		existing, ok := dst[k]
+		if !ok {
+			dst[k] = v
+			continue
+		}
+		switch k {
+		case "properties", "$defs":
+			named, _ := existing.(map[string]any)
+			merged := make(map[string]any, len(named))
+			for name, sub := range named {
+				merged[name] = sub
+			}
+			for name, sub := range v.(map[string]any) {
+				if prev, ok := merged[name]; ok && !reflect.DeepEqual(prev, sub) {
+					return false
+				}
+				merged[name] = sub
+			}
+			dst[k] = merged
+		case "required":
+			required := slices.Clone(existing.([]string))
+			for _, name := range v.([]string) {
+				if !slices.Contains(required, name) {
+					required = append(required, name)
+				}
+			}
+			dst[k] = required
+		default:
+			if !reflect.DeepEqual(existing, v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// restrictsProperties reports whether m uses any of
+// propertyRestrictingKeywords.
+func restrictsProperties(m map[string]any) bool {
+	for _, k := range propertyRestrictingKeywords {
+		if _, ok := m[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// nilIfEmpty returns nil for an empty combinator list.
+func nilIfEmpty(list []*JSONSchema) []*JSONSchema {
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
@@ -0,0 +1,146 @@
+package adapter
+
+import (
+	"testing"
+)
+
+func TestSimplifySchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *JSONSchema
+		want   *JSONSchema
+	}{
+		{
+			name:   "single anyOf",
+			schema: &JSONSchema{AnyOf: []*JSONSchema{{Type: "string"}}},
+			want:   &JSONSchema{Type: "string"},
+		},
+		{
+			name:   "single oneOf keeps parent annotations",
+			schema: &JSONSchema{Description: "An id", OneOf: []*JSONSchema{{Type: "integer"}}},
+			want:   &JSONSchema{Type: "integer", Description: "An id"},
+		},
+		{
+			name:   "single allOf ref",
+			schema: &JSONSchema{Description: "Owner", AllOf: []*JSONSchema{{Ref: "#/$defs/User"}}},
+			want:   &JSONSchema{Description: "Owner", Ref: "#/$defs/User"},
+		},
+		{
+			name: "single allOf object merged",
+			schema: &JSONSchema{
+				Type:       "object",
+				Properties: map[string]*JSONSchema{"id": {Type: "string"}},
+				Required:   []string{"id"},
+				AllOf: []*JSONSchema{{
+					Type:       "object",
+					Properties: map[string]*JSONSchema{"name": {Type: "string"}},
+					Required:   []string{"name", "id"},
+				}},
+			},
+			want: &JSONSchema{
+				Type: "object",
+				Properties: map[string]*JSONSchema{
+					"id":   {Type: "string"},
+					"name": {Type: "string"},
+				},
+				Required: []string{"id", "name"},
+			},
+		},
+		{
+			name:   "nested single members",
+			schema: &JSONSchema{AnyOf: []*JSONSchema{{AllOf: []*JSONSchema{{Type: "boolean"}}}}},
+			want:   &JSONSchema{Type: "boolean"},
+		},
+		{
+			name:   "empty combinators removed",
+			schema: &JSONSchema{Type: "string", AnyOf: []*JSONSchema{}, OneOf: []*JSONSchema{}, AllOf: []*JSONSchema{}},
+			want:   &JSONSchema{Type: "string"},
+		},
+		{
+			name: "nested property simplified",
+			schema: &JSONSchema{
+				Type:       "object",
+				Properties: map[string]*JSONSchema{"tag": {AnyOf: []*JSONSchema{{Type: "string"}}}},
+			},
+			want: &JSONSchema{
+				Type:       "object",
+				Properties: map[string]*JSONSchema{"tag": {Type: "string"}},
+			},
+		},
+		{
+			name:   "conflicting member kept",
+			schema: &JSONSchema{Type: "string", AnyOf: []*JSONSchema{{Type: "integer"}}},
+			want:   &JSONSchema{Type: "string", AnyOf: []*JSONSchema{{Type: "integer"}}},
+		},
+		{
+			name: "member restricting additional properties kept",
+			schema: &JSONSchema{
+				Properties: map[string]*JSONSchema{"a": {}},
+				AllOf: []*JSONSchema{{
+					Properties:           map[string]*JSONSchema{"b": {}},
+					AdditionalProperties: boolPtr(false),
+				}},
+			},
+			want: &JSONSchema{
+				Properties: map[string]*JSONSchema{"a": {}},
+				AllOf: []*JSONSchema{{
+					Properties:           map[string]*JSONSchema{"b": {}},
+					AdditionalProperties: boolPtr(false),
+				}},
+			},
+		},
+		{
+			name: "parent restricting additional properties kept",
+			schema: &JSONSchema{
+				AdditionalPropertiesSchema: &JSONSchema{Type: "string"},
+				AnyOf:                      []*JSONSchema{{Properties: map[string]*JSONSchema{"b": {Type: "integer"}}}},
+			},
+			want: &JSONSchema{
+				AdditionalPropertiesSchema: &JSONSchema{Type: "string"},
+				AnyOf:                      []*JSONSchema{{Properties: map[string]*JSONSchema{"b": {Type: "integer"}}}},
+			},
+		},
+		{
+			name: "restricted member with equal properties merged",
+			schema: &JSONSchema{
+				Type:       "object",
+				Properties: map[string]*JSONSchema{"a": {Type: "string"}},
+				AllOf: []*JSONSchema{{
+					Properties:           map[string]*JSONSchema{"a": {Type: "string"}},
+					AdditionalProperties: boolPtr(false),
+				}},
+			},
+			want: &JSONSchema{
+				Type:                 "object",
+				Properties:           map[string]*JSONSchema{"a": {Type: "string"}},
+				AdditionalProperties: boolPtr(false),
+			},
+		},
+		{
+			name:   "multiple members kept",
+			schema: &JSONSchema{AnyOf: []*JSONSchema{{Type: "string"}, {Type: "integer"}}},
+			want:   &JSONSchema{AnyOf: []*JSONSchema{{Type: "string"}, {Type: "integer"}}},
+		},
+		{
+			name:   "duplicate required removed",
+			schema: &JSONSchema{Type: "object", Required: []string{"id", "name", "id"}},
+			want:   &JSONSchema{Type: "object", Required: []string{"id", "name"}},
+		},
+		{
+			name: "nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := tt.schema.DeepCopy()
+			got := SimplifySchema(tt.schema)
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(tt.want)) {
+				t.Errorf("SimplifySchema() = %v, want %v", got.ToMap(), tt.want.ToMap())
+			}
+			if before != nil && !tt.schema.Equal(before) {
+				t.Error("SimplifySchema() modified its input")
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package adapter
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// VertexFunctionDeclaration represents a Vertex AI function declaration.
+// Parameters use Vertex's OpenAPI-style Schema objects, whose type names are
+// uppercase (e.g. "OBJECT", "STRING").
+type VertexFunctionDeclaration struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+
+	// OutputSchema carries the canonical output schema for round-trip
+	// conversion. It is kept in memory only and never serialized.
+	OutputSchema map[string]any `json:"-"`
+
+	// SourceMeta carries SourceMeta entries of other formats (see
+	// CanonicalTool.SourceMeta) for round-trip conversion. It is kept in
+	// memory only and never serialized.
+	SourceMeta map[string]any `json:"-"`
+}
+
+// VertexTool wraps function declarations in the Vertex AI tools format.
+type VertexTool struct {
+	FunctionDeclarations []VertexFunctionDeclaration `json:"functionDeclarations,omitempty"`
+}
+
+// VertexAdapter converts between Vertex AI function declarations and
+// CanonicalTool. Schemas are filtered as for Gemini, and type names are
+// mapped between JSON Schema lowercase and Vertex uppercase.
+type VertexAdapter struct{}
+
+// NewVertexAdapter creates a new Vertex AI adapter.
+func NewVertexAdapter() *VertexAdapter {
+	return &VertexAdapter{}
+}
+
+// vertexMetaKeys are the SourceMeta keys the Vertex AI adapter owns.
+var vertexMetaKeys = []string{"vertex"}
+
+// Name returns the adapter's identifier.
+func (a *VertexAdapter) Name() string {
+	return "vertex"
+}
+
+// vertexFeatures defines which JSON Schema features Vertex AI supports.
+// Vertex shares Gemini's OpenAPI schema subset; the map is a separate copy so
+// the two can diverge.
+var vertexFeatures = maps.Clone(geminiFeatures)
+
+// vertexTypes maps JSON Schema type names to Vertex AI type names.
+var vertexTypes = map[string]string{
+	"string":  "STRING",
+	"number":  "NUMBER",
+	"integer": "INTEGER",
+	"boolean": "BOOLEAN",
+	"array":   "ARRAY",
+	"object":  "OBJECT",
+	"null":    "NULL",
+}
+
+// ToCanonical converts a Vertex AI function declaration to canonical format.
+// Accepts *VertexFunctionDeclaration, VertexFunctionDeclaration, *VertexTool,
+// VertexTool, or a decoded JSON object (map[string]any): one with a
+// "functionDeclarations" key is read as a VertexTool, anything else as a
+// single declaration.
+func (a *VertexAdapter) ToCanonical(raw any) (*CanonicalTool, error) {
+	if raw == nil {
+		return nil, &ConversionError{
+			Adapter:   a.Name(),
+			Direction: "to_canonical",
+			Cause:     errors.New("input is nil"),
+		}
+	}
+
+	var fn *VertexFunctionDeclaration
+
+	switch v := raw.(type) {
+	case *VertexFunctionDeclaration:
+		fn = v
+	case VertexFunctionDeclaration:
+		fn = &v
+	case *VertexTool:
+		if len(v.FunctionDeclarations) != 1 {
+			return nil, &ConversionError{
+				Adapter:   a.Name(),
+				Direction: "to_canonical",
+				Cause:     errors.New("vertex tool must contain exactly one function declaration"),
+			}
+		}
+		fn = &v.FunctionDeclarations[0]
+	case VertexTool:
+		if len(v.FunctionDeclarations) != 1 {
+			return nil, &ConversionError{
+				Adapter:   a.Name(),
+				Direction: "to_canonical",
+				Cause:     errors.New("vertex tool must contain exactly one function declaration"),
+			}
+		}
+		fn = &v.FunctionDeclarations[0]
+	case map[string]any:
+		decoded, err := decodeVertexMap(v)
+		if err != nil {
+			return nil, &ConversionError{
+				Adapter:   a.Name(),
+				Direction: "to_canonical",
+				Cause:     err,
+			}
+		}
+		return a.ToCanonical(decoded)
+	default:
+		return nil, &ConversionError{
+			Adapter:   a.Name(),
+			Direction: "to_canonical",
+			Cause:     fmt.Errorf("unsupported type: %T", raw),
+		}
+	}
+
+	if fn.Name == "" {
+		return nil, &ConversionError{
+			Adapter:   a.Name(),
+			Direction: "to_canonical",
+			Cause:     errors.New("function name is required"),
+		}
+	}
+
+	inputSchema := schemaFromMap(fn.Parameters)
+	if inputSchema == nil {
+		inputSchema = &JSONSchema{Type: "object"}
+	}
+	walkSchema(inputSchema, "", func(_ string, node *JSONSchema) {
+		node.Type = jsonTypeFromVertex(node.Type)
+	})
+
+	ct := &CanonicalTool{
+		Name:         fn.Name,
+		Description:  fn.Description,
+		InputSchema:  inputSchema,
+		OutputSchema: schemaFromMap(fn.OutputSchema),
+		SourceFormat: "vertex",
+		SourceMeta:   make(map[string]any),
+	}
+	restoreCarriedMeta(ct, fn.SourceMeta, vertexMetaKeys)
+	return ct, nil
+}
+
+// decodeVertexMap decodes a generic JSON object into a *VertexTool or a
+// *VertexFunctionDeclaration, depending on its shape.
+func decodeVertexMap(m map[string]any) (any, error) {
+	if _, ok := m["functionDeclarations"]; ok {
+		var tool VertexTool
+		return &tool, decodeToolMap(m, &tool)
+	}
+	var fn VertexFunctionDeclaration
+	return &fn, decodeToolMap(m, &fn)
+}
+
+// FromCanonical converts a canonical tool to Vertex AI format.
+// Returns *VertexTool.
+func (a *VertexAdapter) FromCanonical(ct *CanonicalTool) (any, error) {
+	if ct == nil {
+		return nil, &ConversionError{
+			Adapter:   a.Name(),
+			Direction: "from_canonical",
+			Cause:     errors.New("canonical tool is nil"),
+		}
+	}
+
+	if ct.Name == "" {
+		return nil, &ConversionError{
+			Adapter:   a.Name(),
+			Direction: "from_canonical",
+			Cause:     errors.New("tool name is required"),
+		}
+	}
+
+	fn := VertexFunctionDeclaration{
+		Name:        ct.Name,
+		Description: ct.Description,
+	}
+
+	if ct.InputSchema != nil {
+		params := filterGeminiSchema(ct.InputSchema)
+		walkSchema(params, "", func(_ string, node *JSONSchema) {
+			node.Type = vertexType(node.Type)
+		})
+		fn.Parameters = params.ToMap()
+	} else {
+		fn.Parameters = map[string]any{"type": vertexTypes["object"]}
+	}
+
+	// Carry OutputSchema unfiltered; it is not sent to the API
+	if ct.OutputSchema != nil {
+		fn.OutputSchema = ct.OutputSchema.ToMap()
+	}
+	fn.SourceMeta = carriedMeta(ct, vertexMetaKeys)
+
+	return &VertexTool{
+		FunctionDeclarations: []VertexFunctionDeclaration{fn},
+	}, nil
+}
+
+// vertexType returns the Vertex AI spelling of a JSON Schema type name.
+// Unknown names are returned unchanged.
+func vertexType(jsonType string) string {
+	if t, ok := vertexTypes[jsonType]; ok {
+		return t
+	}
+	return jsonType
+}
+
+// jsonTypeFromVertex returns the JSON Schema spelling of a Vertex AI type
+// name, accepting either case. Unknown names are returned unchanged.
+func jsonTypeFromVertex(t string) string {
+	if lower := strings.ToLower(t); vertexTypes[lower] != "" {
+		return lower
+	}
+	return t
+}
+
+// SupportsFeature returns whether this adapter supports a schema feature.
+func (a *VertexAdapter) SupportsFeature(feature SchemaFeature) bool {
+	supported, ok := vertexFeatures[feature]
+	return ok && supported
+}
+
+// RewriteNote reports schema features Vertex AI rewrites instead of
+// dropping. Vertex schemas are filtered as for Gemini, so the rewrites match.
+func (a *VertexAdapter) RewriteNote(feature SchemaFeature, node *JSONSchema) string {
+	return (&GeminiAdapter{}).RewriteNote(feature, node)
+}
@@ -0,0 +1,181 @@
+package adapter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jonwraymond/toolfoundation/model"
+)
+
+var _ FeatureRewriter = (*VertexAdapter)(nil)
+
+func TestVertexAdapter_Name(t *testing.T) {
+	adapter := NewVertexAdapter()
+	if adapter.Name() != "vertex" {
+		t.Errorf("Name() = %q, want %q", adapter.Name(), "vertex")
+	}
+}
+
+func TestVertexAdapter_SupportsFeature(t *testing.T) {
+	vertex, gemini := NewVertexAdapter(), NewGeminiAdapter()
+	for _, feature := range AllFeatures() {
+		if got, want := vertex.SupportsFeature(feature), gemini.SupportsFeature(feature); got != want {
+			t.Errorf("SupportsFeature(%v) = %v, want %v as for Gemini", feature, got, want)
+		}
+	}
+}
+
+func TestVertexAdapter_ToCanonical(t *testing.T) {
+	decl := &VertexFunctionDeclaration{
+		Name:        "get_weather",
+		Description: "Get the weather",
+		Parameters: map[string]any{
+			"type": "OBJECT",
+			"properties": map[string]any{
+				"city": map[string]any{"type": "STRING", "nullable": true},
+				"days": map[string]any{"type": "INTEGER"},
+				"tags": map[string]any{"type": "ARRAY", "items": map[string]any{"type": "STRING"}},
+			},
+			"required": []any{"city"},
+		},
+	}
+
+	ct, err := NewVertexAdapter().ToCanonical(&VertexTool{FunctionDeclarations: []VertexFunctionDeclaration{*decl}})
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+
+	if ct.Name != "get_weather" || ct.SourceFormat != "vertex" {
+		t.Errorf("ToCanonical() = %+v, want get_weather from vertex", ct)
+	}
+	if ct.InputSchema.Type != "object" {
+		t.Errorf("InputSchema.Type = %q, want object", ct.InputSchema.Type)
+	}
+	city := ct.InputSchema.Properties["city"]
+	if city.Type != "string" || city.Nullable == nil || !*city.Nullable {
+		t.Errorf("city = %+v, want nullable string", city)
+	}
+	if got := ct.InputSchema.Properties["days"].Type; got != "integer" {
+		t.Errorf("days.Type = %q, want integer", got)
+	}
+	if got := ct.InputSchema.Properties["tags"].Items.Type; got != "string" {
+		t.Errorf("tags.items.Type = %q, want string", got)
+	}
+}
+
+func TestVertexAdapter_ToCanonical_Map(t *testing.T) {
+	raw := map[string]any{"functionDeclarations": []any{
+		map[string]any{
+			"name": "search",
+			"parameters": map[string]any{
+				"type":       "OBJECT",
+				"properties": map[string]any{"q": map[string]any{"type": "STRING"}},
+			},
+		},
+	}}
+
+	ct, err := NewVertexAdapter().ToCanonical(raw)
+	if err != nil {
+		t.Fatalf("ToCanonical() error = %v", err)
+	}
+	if ct.Name != "search" || ct.InputSchema.Type != "object" || ct.InputSchema.Properties["q"].Type != "string" {
+		t.Errorf("ToCanonical() = %+v, want lowercase JSON Schema types", ct.InputSchema)
+	}
+}
+
+func TestVertexAdapter_ToCanonical_Errors(t *testing.T) {
+	adapter := NewVertexAdapter()
+	for _, raw := range []any{nil, &VertexFunctionDeclaration{}, &VertexTool{}, "tool"} {
+		if _, err := adapter.ToCanonical(raw); err == nil {
+			t.Errorf("ToCanonical(%v) error = nil, want error", raw)
+		}
+	}
+}
+
+func TestVertexAdapter_TypeCaseRoundTrip(t *testing.T) {
+	registry := DefaultRegistry()
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"query": map[string]any{"type": "string"},
+			"limit": map[string]any{"type": "integer"},
+			"score": map[string]any{"type": "number"},
+			"exact": map[string]any{"type": "boolean"},
+			"filters": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "object", "properties": map[string]any{"field": map[string]any{"type": "string"}}},
+			},
+		},
+	}
+	tool := &model.Tool{Tool: mcp.Tool{Name: "search", InputSchema: schema}}
+
+	result, err := registry.Convert(tool, "mcp", "vertex")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	params := result.Tool.(*VertexTool).FunctionDeclarations[0].Parameters
+	if params["type"] != "OBJECT" {
+		t.Errorf("parameters.type = %v, want OBJECT", params["type"])
+	}
+	props := params["properties"].(map[string]any)
+	want := map[string]string{"query": "STRING", "limit": "INTEGER", "score": "NUMBER", "exact": "BOOLEAN", "filters": "ARRAY"}
+	for name, typ := range want {
+		if got := props[name].(map[string]any)["type"]; got != typ {
+			t.Errorf("%s.type = %v, want %s", name, got, typ)
+		}
+	}
+	item := props["filters"].(map[string]any)["items"].(map[string]any)
+	if item["type"] != "OBJECT" || item["properties"].(map[string]any)["field"].(map[string]any)["type"] != "STRING" {
+		t.Errorf("filters.items = %v, want nested uppercase types", item)
+	}
+
+	back, err := registry.Convert(result.Tool, "vertex", "mcp")
+	if err != nil {
+		t.Fatalf("Convert() back error = %v", err)
+	}
+	if got := back.Tool.(*model.Tool).InputSchema; !reflect.DeepEqual(got, schema) {
+		t.Errorf("round trip = %v, want %v", got, schema)
+	}
+}
+
+func TestVertexAdapter_ExclusiveBoundsRewrittenAsInclusive(t *testing.T) {
+	registry := DefaultRegistry()
+	tool := &model.Tool{
+		Tool: mcp.Tool{
+			Name: "rate",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"score": map[string]any{"type": "number", "exclusiveMinimum": 0.0},
+				},
+			},
+		},
+	}
+
+	result, err := registry.Convert(tool, "mcp", "vertex")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	score := result.Tool.(*VertexTool).FunctionDeclarations[0].Parameters["properties"].(map[string]any)["score"].(map[string]any)
+	if _, ok := score["exclusiveMinimum"]; ok {
+		t.Errorf("exclusiveMinimum should not be emitted for Vertex: %v", score)
+	}
+	if score["minimum"] != 0.0 {
+		t.Errorf("score.minimum = %v, want 0", score["minimum"])
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Feature != FeatureMinimum || !result.Warnings[0].Rewritten {
+		t.Errorf("Warnings = %v, want one minimum rewrite", result.Warnings)
+	}
+}
+
+func TestVertexAdapter_FromCanonical_Errors(t *testing.T) {
+	adapter := NewVertexAdapter()
+	if _, err := adapter.FromCanonical(nil); err == nil {
+		t.Error("FromCanonical(nil) error = nil, want error")
+	}
+	if _, err := adapter.FromCanonical(&CanonicalTool{}); err == nil {
+		t.Error("FromCanonical(unnamed) error = nil, want error")
+	}
+}
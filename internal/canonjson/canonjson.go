@@ -0,0 +1,87 @@
+// Package canonjson encodes values as canonical JSON: object keys are written
+// in sorted order at every depth and no insignificant whitespace is emitted,
+// so equal values always produce identical bytes.
+package canonjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// Marshal returns the canonical JSON encoding of v.
+// v is first encoded with encoding/json, so struct tags and Marshaler
+// implementations are honored; the result is then re-encoded with an
+// ordered encoder. Numbers keep their original textual form.
+func Marshal(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := encode(&buf, generic); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encode writes a decoded JSON value with object keys in sorted order.
+func encode(buf *bytes.Buffer, v any) error {
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeScalar(buf, k); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := encode(buf, v[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encode(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		return encodeScalar(buf, v)
+	}
+	return nil
+}
+
+// encodeScalar writes a string, json.Number, bool, or nil value without
+// HTML escaping.
+func encodeScalar(buf *bytes.Buffer, v any) error {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	buf.Truncate(buf.Len() - 1) // drop the newline Encode appends
+	return nil
+}
@@ -0,0 +1,63 @@
+package canonjson
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{"nil", nil, `null`},
+		{"scalar", "a<b", `"a<b"`},
+		{"sorted keys", map[string]any{"b": 1, "a": 2, "c": 3}, `{"a":2,"b":1,"c":3}`},
+		{"nested", map[string]any{
+			"z": []any{map[string]any{"y": true, "x": nil}},
+			"a": map[string]any{"d": "v", "c": 1.5},
+		}, `{"a":{"c":1.5,"d":"v"},"z":[{"x":null,"y":true}]}`},
+		{"struct tags", struct {
+			B string `json:"b"`
+			A int    `json:"a,omitempty"`
+		}{B: "x"}, `{"b":"x"}`},
+		{"raw message", json.RawMessage(`{ "b" : 1, "a" : [ 2, 3 ] }`), `{"a":[2,3],"b":1}`},
+		{"int and float", []any{100, 100.0, int64(1) << 60}, `[100,100,1152921504606846976]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.input)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshal_Deterministic(t *testing.T) {
+	v := map[string]any{}
+	for _, k := range []string{"q", "w", "e", "r", "t", "y", "u", "i", "o", "p"} {
+		v[k] = map[string]any{k + "2": k, k + "1": []any{k}}
+	}
+	first, err := Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for i := 0; i < 50; i++ {
+		got, _ := Marshal(v)
+		if string(got) != string(first) {
+			t.Fatalf("Marshal() not deterministic: %s vs %s", got, first)
+		}
+	}
+}
+
+func TestMarshal_Error(t *testing.T) {
+	if _, err := Marshal(map[string]any{"f": func() {}}); err == nil {
+		t.Error("Marshal() should fail for unencodable values")
+	}
+}
@@ -121,6 +121,92 @@ func (b *ToolBuilder) OpenWorld() *ToolBuilder {
 	return b
 }
 
+// Summary sets a short summary under Meta["summary"].
+func (b *ToolBuilder) Summary(summary string) *ToolBuilder {
+	b.ensureMeta()
+	b.tool.Meta["summary"] = summary
+	return b
+}
+
+// Category sets the tool's category under Meta["category"].
+func (b *ToolBuilder) Category(category string) *ToolBuilder {
+	b.ensureMeta()
+	b.tool.Meta["category"] = category
+	return b
+}
+
+// InputModes sets the accepted input MIME types under Meta["inputModes"].
+func (b *ToolBuilder) InputModes(modes ...string) *ToolBuilder {
+	b.ensureMeta()
+	b.tool.Meta["inputModes"] = append([]string{}, modes...)
+	return b
+}
+
+// OutputModes sets the produced output MIME types under Meta["outputModes"].
+func (b *ToolBuilder) OutputModes(modes ...string) *ToolBuilder {
+	b.ensureMeta()
+	b.tool.Meta["outputModes"] = append([]string{}, modes...)
+	return b
+}
+
+// Examples sets example prompts or usage scenarios under Meta["examples"].
+func (b *ToolBuilder) Examples(examples ...string) *ToolBuilder {
+	b.ensureMeta()
+	b.tool.Meta["examples"] = append([]string{}, examples...)
+	return b
+}
+
+// Deterministic records under Meta["deterministic"] whether the tool returns
+// the same output for the same input, letting callers decide whether to cache.
+func (b *ToolBuilder) Deterministic(deterministic bool) *ToolBuilder {
+	b.ensureMeta()
+	b.tool.Meta["deterministic"] = deterministic
+	return b
+}
+
+// Streaming records under Meta["streaming"] whether the tool streams its output.
+func (b *ToolBuilder) Streaming(streaming bool) *ToolBuilder {
+	b.ensureMeta()
+	b.tool.Meta["streaming"] = streaming
+	return b
+}
+
+// RequireSecurity adds a security requirement naming scheme and the scopes it
+// must grant. Requirements accumulate under Meta["securityRequirements"] in
+// the shape the MCP adapter reads into CanonicalTool.SecurityRequirements.
+// Calling Meta afterwards replaces them.
+func (b *ToolBuilder) RequireSecurity(scheme string, scopes ...string) *ToolBuilder {
+	b.ensureMeta()
+	requirements, _ := b.tool.Meta["securityRequirements"].([]any)
+	b.tool.Meta["securityRequirements"] = append(requirements, map[string]any{
+		scheme: append([]string{}, scopes...),
+	})
+	return b
+}
+
+// DefineSecurityScheme defines the named security scheme (for example an
+// OpenAPI-style {"type": "http", "scheme": "bearer"} object) under
+// Meta["securitySchemes"], where the MCP adapter reads it into
+// CanonicalTool.SecuritySchemes. Defining a name again replaces it.
+// Calling Meta afterwards replaces all schemes.
+func (b *ToolBuilder) DefineSecurityScheme(name string, scheme map[string]any) *ToolBuilder {
+	b.ensureMeta()
+	schemes, ok := b.tool.Meta["securitySchemes"].(map[string]any)
+	if !ok {
+		schemes = make(map[string]any)
+		b.tool.Meta["securitySchemes"] = schemes
+	}
+	schemes[name] = scheme
+	return b
+}
+
+// ensureMeta initializes Meta if nil.
+func (b *ToolBuilder) ensureMeta() {
+	if b.tool.Meta == nil {
+		b.tool.Meta = mcp.Meta{}
+	}
+}
+
 // ensureAnnotations initializes Annotations if nil.
 func (b *ToolBuilder) ensureAnnotations() {
 	if b.tool.Annotations == nil {
@@ -128,6 +214,17 @@ func (b *ToolBuilder) ensureAnnotations() {
 	}
 }
 
+// Clone returns an independent copy of the builder, so a partially
+// configured tool can be forked into variants. Schemas, tags, and Meta
+// values are deep-copied; changes to either builder do not affect the other.
+func (b *ToolBuilder) Clone() *ToolBuilder {
+	fork := b.tool.Clone()
+	for k, v := range fork.Meta {
+		fork.Meta[k] = deepCopyAny(v)
+	}
+	return &ToolBuilder{tool: *fork}
+}
+
 // Build validates and returns the constructed Tool.
 // Returns an error if validation fails.
 func (b *ToolBuilder) Build() (*Tool, error) {
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -257,6 +258,67 @@ func TestToolBuilder_Immutability(t *testing.T) {
 	}
 }
 
+func TestToolBuilder_Clone(t *testing.T) {
+	base := NewTool("search").
+		Description("base").
+		Tags("search").
+		InputSchema(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{"type": "string"},
+			},
+		}).
+		DefineSecurityScheme("bearerAuth", map[string]any{"type": "http"}).
+		RequireSecurity("bearerAuth")
+
+	fork := base.Clone().Description("fork")
+
+	original := base.MustBuild()
+	forked := fork.MustBuild()
+
+	diff := original.Diff(forked)
+	if len(diff.Fields) != 1 || diff.Fields[0].Field != "description" {
+		t.Errorf("Diff().Fields = %+v, want only description", diff.Fields)
+	}
+	if len(diff.AddedProperties) != 0 || len(diff.RemovedProperties) != 0 || len(diff.ChangedConstraints) != 0 {
+		t.Errorf("Diff() = %+v, want schemas unchanged", diff)
+	}
+	if original.Description != "base" || forked.Description != "fork" {
+		t.Errorf("Descriptions = %q, %q, want base, fork", original.Description, forked.Description)
+	}
+}
+
+func TestToolBuilder_Clone_Independent(t *testing.T) {
+	base := NewTool("search").
+		Tags("search").
+		InputSchema(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{"type": "string"},
+			},
+		}).
+		DefineSecurityScheme("bearerAuth", map[string]any{"type": "http"})
+
+	fork := base.Clone().
+		Tags("other").
+		DefineSecurityScheme("apiKey", map[string]any{"type": "apiKey"})
+	forked := fork.MustBuild()
+	props := forked.InputSchema.(map[string]any)["properties"].(map[string]any)
+	props["query"].(map[string]any)["type"] = "integer"
+
+	original := base.MustBuild()
+	if len(original.Tags) != 1 || original.Tags[0] != "search" {
+		t.Errorf("original Tags = %v, want [search]", original.Tags)
+	}
+	if schemes := original.Meta["securitySchemes"].(map[string]any); len(schemes) != 1 {
+		t.Errorf("original securitySchemes = %v, want only bearerAuth", schemes)
+	}
+	query := original.InputSchema.(map[string]any)["properties"].(map[string]any)["query"].(map[string]any)
+	if query["type"] != "string" {
+		t.Errorf("original query type = %v, want string", query["type"])
+	}
+}
+
 func TestToolBuilder_Chaining(t *testing.T) {
 	builder := NewTool("chained")
 
@@ -274,3 +336,70 @@ func TestToolBuilder_Chaining(t *testing.T) {
 		t.Error("All builder methods should return the same builder instance for chaining")
 	}
 }
+
+func TestToolBuilder_Security(t *testing.T) {
+	bearer := map[string]any{"type": "http", "scheme": "bearer"}
+	tool, err := NewTool("secure").
+		InputSchema(map[string]any{"type": "object"}).
+		Meta(mcp.Meta{"traceId": "abc123"}).
+		DefineSecurityScheme("bearerAuth", bearer).
+		DefineSecurityScheme("apiKey", map[string]any{"type": "apiKey", "in": "header", "name": "X-Key"}).
+		RequireSecurity("bearerAuth", "read", "write").
+		RequireSecurity("apiKey").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if tool.Meta["traceId"] != "abc123" {
+		t.Errorf("Meta[traceId] = %v, want existing meta preserved", tool.Meta["traceId"])
+	}
+
+	schemes, ok := tool.Meta["securitySchemes"].(map[string]any)
+	if !ok {
+		t.Fatalf("Meta[securitySchemes] = %T, want map[string]any", tool.Meta["securitySchemes"])
+	}
+	if len(schemes) != 2 {
+		t.Errorf("len(securitySchemes) = %d, want 2", len(schemes))
+	}
+	if !reflect.DeepEqual(schemes["bearerAuth"], bearer) {
+		t.Errorf("securitySchemes[bearerAuth] = %v, want %v", schemes["bearerAuth"], bearer)
+	}
+
+	want := []any{
+		map[string]any{"bearerAuth": []string{"read", "write"}},
+		map[string]any{"apiKey": []string{}},
+	}
+	if got := tool.Meta["securityRequirements"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Meta[securityRequirements] = %#v, want %#v", got, want)
+	}
+}
+
+func TestToolBuilder_DiscoveryMeta(t *testing.T) {
+	tool, err := NewTool("search").
+		InputSchema(map[string]any{"type": "object"}).
+		Summary("Search documents").
+		Category("retrieval").
+		InputModes("application/json").
+		OutputModes("application/json", "text/plain").
+		Examples("find the latest report").
+		Deterministic(true).
+		Streaming(false).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := mcp.Meta{
+		"summary":       "Search documents",
+		"category":      "retrieval",
+		"inputModes":    []string{"application/json"},
+		"outputModes":   []string{"application/json", "text/plain"},
+		"examples":      []string{"find the latest report"},
+		"deterministic": true,
+		"streaming":     false,
+	}
+	if !reflect.DeepEqual(tool.Meta, want) {
+		t.Errorf("Meta = %#v, want %#v", tool.Meta, want)
+	}
+}
@@ -0,0 +1,198 @@
+package model
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeKind describes how a field, property, or constraint changed.
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "changed"
+)
+
+// FieldChange records a change to a top-level Tool field.
+type FieldChange struct {
+	// Field is the JSON name of the field, e.g. "description".
+	Field string
+	Kind  ChangeKind
+	Old   any
+	New   any
+}
+
+// ConstraintChange records a changed schema keyword at a JSON Pointer path,
+// e.g. "maxLength" at "/inputSchema/properties/query".
+type ConstraintChange struct {
+	Path    string
+	Keyword string
+	Kind    ChangeKind
+	Old     any
+	New     any
+}
+
+// ToolDiff describes the differences between two tools.
+// Property paths are JSON Pointers rooted at "/inputSchema" or "/outputSchema",
+// e.g. "/inputSchema/properties/query".
+type ToolDiff struct {
+	Fields             []FieldChange
+	AddedProperties    []string
+	RemovedProperties  []string
+	ChangedConstraints []ConstraintChange
+}
+
+// IsEmpty reports whether the diff contains no changes.
+func (d ToolDiff) IsEmpty() bool {
+	return len(d.Fields) == 0 &&
+		len(d.AddedProperties) == 0 &&
+		len(d.RemovedProperties) == 0 &&
+		len(d.ChangedConstraints) == 0
+}
+
+// Diff describes what changed from t to other: top-level fields, schema
+// properties added or removed, and schema keywords whose values changed.
+// Schemas are compared in their canonical JSON form. A nil tool is treated
+// as an empty one.
+func (t *Tool) Diff(other *Tool) ToolDiff {
+	if t == nil {
+		t = &Tool{}
+	}
+	if other == nil {
+		other = &Tool{}
+	}
+
+	var d ToolDiff
+	d.diffField("name", t.Name, other.Name, t.Name == other.Name)
+	d.diffField("title", t.Title, other.Title, t.Title == other.Title)
+	d.diffField("description", t.Description, other.Description, t.Description == other.Description)
+	d.diffField("namespace", t.Namespace, other.Namespace, t.Namespace == other.Namespace)
+	d.diffField("version", t.Version, other.Version, t.Version == other.Version)
+	d.diffField("tags", t.Tags, other.Tags, tagsEqual(t.Tags, other.Tags))
+	d.diffField("annotations", t.Annotations, other.Annotations, annotationsEqual(t.Annotations, other.Annotations))
+	d.diffField("_meta", t.Meta, other.Meta,
+		(len(t.Meta) == 0 && len(other.Meta) == 0) || canonicalEqual(t.Meta, other.Meta))
+	d.diffField("icons", t.Icons, other.Icons,
+		(len(t.Icons) == 0 && len(other.Icons) == 0) || canonicalEqual(t.Icons, other.Icons))
+	d.diffField("backends", t.Backends, other.Backends,
+		(len(t.Backends) == 0 && len(other.Backends) == 0) || canonicalEqual(t.Backends, other.Backends))
+
+	d.diffSchema("/inputSchema", schemaMap(t.InputSchema), schemaMap(other.InputSchema))
+	d.diffSchema("/outputSchema", schemaMap(t.OutputSchema), schemaMap(other.OutputSchema))
+	return d
+}
+
+func (d *ToolDiff) diffField(field string, before, after any, equal bool) {
+	if equal {
+		return
+	}
+	d.Fields = append(d.Fields, FieldChange{
+		Field: field,
+		Kind:  changeKind(before, after),
+		Old:   before,
+		New:   after,
+	})
+}
+
+// diffSchema compares two schema objects at path, recursing into
+// properties and items.
+func (d *ToolDiff) diffSchema(path string, before, after map[string]any) {
+	beforeProps, _ := before["properties"].(map[string]any)
+	afterProps, _ := after["properties"].(map[string]any)
+	for _, name := range unionKeys(beforeProps, afterProps) {
+		propPath := path + "/properties/" + escapePointer(name)
+		beforeProp, inBefore := beforeProps[name]
+		afterProp, inAfter := afterProps[name]
+		switch {
+		case !inBefore:
+			d.AddedProperties = append(d.AddedProperties, propPath)
+		case !inAfter:
+			d.RemovedProperties = append(d.RemovedProperties, propPath)
+		default:
+			d.diffSchema(propPath, schemaMap(beforeProp), schemaMap(afterProp))
+		}
+	}
+
+	beforeItems, beforeIsSchema := before["items"].(map[string]any)
+	afterItems, afterIsSchema := after["items"].(map[string]any)
+	if beforeIsSchema && afterIsSchema {
+		d.diffSchema(path+"/items", beforeItems, afterItems)
+	}
+
+	for _, keyword := range unionKeys(before, after) {
+		if keyword == "properties" || (keyword == "items" && beforeIsSchema && afterIsSchema) {
+			continue
+		}
+		beforeVal, inBefore := before[keyword]
+		afterVal, inAfter := after[keyword]
+		if reflect.DeepEqual(beforeVal, afterVal) {
+			continue
+		}
+		kind := ChangeModified
+		switch {
+		case !inBefore:
+			kind = ChangeAdded
+		case !inAfter:
+			kind = ChangeRemoved
+		}
+		d.ChangedConstraints = append(d.ChangedConstraints, ConstraintChange{
+			Path:    path,
+			Keyword: keyword,
+			Kind:    kind,
+			Old:     beforeVal,
+			New:     afterVal,
+		})
+	}
+}
+
+// schemaMap returns the canonical JSON object form of a schema, or nil.
+func schemaMap(schema any) map[string]any {
+	m, _ := schemaObject(schema)
+	return m
+}
+
+// changeKind classifies a field change by which side holds a zero value.
+func changeKind(before, after any) ChangeKind {
+	switch {
+	case isZeroValue(before):
+		return ChangeAdded
+	case isZeroValue(after):
+		return ChangeRemoved
+	default:
+		return ChangeModified
+	}
+}
+
+func isZeroValue(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+func unionKeys(a, b map[string]any) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapePointer escapes a JSON Pointer reference token (RFC 6901).
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
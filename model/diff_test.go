@@ -0,0 +1,169 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func diffTestTool(version string, props map[string]any) *Tool {
+	return &Tool{
+		Tool: mcp.Tool{
+			Name:        "search",
+			Description: "Search documents",
+			InputSchema: map[string]any{
+				"type":       "object",
+				"properties": props,
+			},
+		},
+		Namespace: "docs",
+		Version:   version,
+	}
+}
+
+func TestTool_Diff_AddedProperties(t *testing.T) {
+	v1 := diffTestTool("1.0.0", map[string]any{
+		"query": map[string]any{"type": "string"},
+	})
+	v2 := diffTestTool("2.0.0", map[string]any{
+		"query": map[string]any{"type": "string"},
+		"limit": map[string]any{"type": "integer"},
+		"a/b":   map[string]any{"type": "string"},
+	})
+
+	d := v1.Diff(v2)
+	want := []string{"/inputSchema/properties/a~1b", "/inputSchema/properties/limit"}
+	if !reflect.DeepEqual(d.AddedProperties, want) {
+		t.Errorf("AddedProperties = %v, want %v", d.AddedProperties, want)
+	}
+	if len(d.RemovedProperties) != 0 {
+		t.Errorf("RemovedProperties = %v, want none", d.RemovedProperties)
+	}
+	wantFields := []FieldChange{{Field: "version", Kind: ChangeModified, Old: "1.0.0", New: "2.0.0"}}
+	if !reflect.DeepEqual(d.Fields, wantFields) {
+		t.Errorf("Fields = %+v, want %+v", d.Fields, wantFields)
+	}
+}
+
+func TestTool_Diff_RemovedProperties(t *testing.T) {
+	v1 := diffTestTool("1.0.0", map[string]any{
+		"query": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"text": map[string]any{"type": "string"},
+				"lang": map[string]any{"type": "string"},
+			},
+		},
+	})
+	v2 := diffTestTool("1.0.0", map[string]any{
+		"query": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"text": map[string]any{"type": "string"},
+			},
+		},
+	})
+
+	d := v1.Diff(v2)
+	want := []string{"/inputSchema/properties/query/properties/lang"}
+	if !reflect.DeepEqual(d.RemovedProperties, want) {
+		t.Errorf("RemovedProperties = %v, want %v", d.RemovedProperties, want)
+	}
+	if len(d.AddedProperties) != 0 || len(d.Fields) != 0 || len(d.ChangedConstraints) != 0 {
+		t.Errorf("unexpected changes: %+v", d)
+	}
+}
+
+func TestTool_Diff_TightenedMaxLength(t *testing.T) {
+	v1 := diffTestTool("1.0.0", map[string]any{
+		"query": map[string]any{"type": "string", "maxLength": 200},
+	})
+	v2 := diffTestTool("1.0.0", map[string]any{
+		"query": map[string]any{"type": "string", "maxLength": 100, "pattern": "^[a-z]+$"},
+	})
+
+	d := v1.Diff(v2)
+	want := []ConstraintChange{
+		{Path: "/inputSchema/properties/query", Keyword: "maxLength", Kind: ChangeModified, Old: 200.0, New: 100.0},
+		{Path: "/inputSchema/properties/query", Keyword: "pattern", Kind: ChangeAdded, New: "^[a-z]+$"},
+	}
+	if !reflect.DeepEqual(d.ChangedConstraints, want) {
+		t.Errorf("ChangedConstraints = %+v, want %+v", d.ChangedConstraints, want)
+	}
+}
+
+func TestTool_Diff_ConstraintKinds(t *testing.T) {
+	v1 := diffTestTool("1.0.0", map[string]any{
+		"tags": map[string]any{
+			"type":  "array",
+			"items": map[string]any{"type": "string", "minLength": 1},
+		},
+	})
+	v1.InputSchema.(map[string]any)["additionalProperties"] = true
+	v2 := diffTestTool("1.0.0", map[string]any{
+		"tags": map[string]any{
+			"type":  "array",
+			"items": map[string]any{"type": "string"},
+		},
+	})
+	v2.InputSchema.(map[string]any)["additionalProperties"] = false
+
+	d := v1.Diff(v2)
+	want := []ConstraintChange{
+		{Path: "/inputSchema/properties/tags/items", Keyword: "minLength", Kind: ChangeRemoved, Old: 1.0},
+		{Path: "/inputSchema", Keyword: "additionalProperties", Kind: ChangeModified, Old: true, New: false},
+	}
+	if !reflect.DeepEqual(d.ChangedConstraints, want) {
+		t.Errorf("ChangedConstraints = %+v, want %+v", d.ChangedConstraints, want)
+	}
+}
+
+func TestTool_Diff_Fields(t *testing.T) {
+	v1 := diffTestTool("1.0.0", nil)
+	v1.Tags = []string{"search", "docs"}
+	v2 := diffTestTool("1.0.0", nil)
+	v2.Tags = []string{"docs", "search"}
+	v2.Title = "Search"
+	v2.Description = ""
+	v2.Annotations = &mcp.ToolAnnotations{ReadOnlyHint: true}
+
+	d := v1.Diff(v2)
+	got := map[string]ChangeKind{}
+	for _, f := range d.Fields {
+		got[f.Field] = f.Kind
+	}
+	want := map[string]ChangeKind{
+		"title":       ChangeAdded,
+		"description": ChangeRemoved,
+		"annotations": ChangeAdded,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields = %v, want %v", got, want)
+	}
+}
+
+func TestTool_Diff_Identical(t *testing.T) {
+	tool := diffTestTool("1.0.0", map[string]any{"query": map[string]any{"type": "string"}})
+	if d := tool.Diff(tool.Clone()); !d.IsEmpty() {
+		t.Errorf("Diff(clone) = %+v, want empty", d)
+	}
+}
+
+func TestTool_Diff_Nil(t *testing.T) {
+	tool := diffTestTool("1.0.0", map[string]any{"query": map[string]any{"type": "string"}})
+
+	d := (*Tool)(nil).Diff(tool)
+	if !reflect.DeepEqual(d.AddedProperties, []string{"/inputSchema/properties/query"}) {
+		t.Errorf("AddedProperties = %v", d.AddedProperties)
+	}
+	for _, f := range d.Fields {
+		if f.Kind != ChangeAdded {
+			t.Errorf("field %s kind = %s, want added", f.Field, f.Kind)
+		}
+	}
+
+	if d := (*Tool)(nil).Diff(nil); !d.IsEmpty() {
+		t.Errorf("nil.Diff(nil) = %+v, want empty", d)
+	}
+}
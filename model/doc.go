@@ -7,7 +7,7 @@
 //
 //   - Tool and ToolIcon types matching the MCP Tool specification
 //   - Namespace and Version extensions for stable tool identification
-//   - Backend binding types (MCP, Provider, Local) for execution metadata
+//   - Backend binding types (MCP, Provider, Local) attached via Tool.Backends
 //   - Optional tool Tags for search/discovery layers
 //   - JSON Schema validation helpers for inputs and outputs
 //   - JSON serialization compatible with the MCP Tool spec
@@ -135,7 +135,7 @@ func ExampleParseToolID() {
 	fmt.Printf("Namespace: %q, Name: %q\n", ns, name)
 	// Output:
 	// Namespace: "github", Name: "list_repos"
-	// Namespace: "github", Name: "list_repos:1.0.0"
+	// Namespace: "github", Name: "list_repos"
 	// Namespace: "", Name: "simple_tool"
 }
 
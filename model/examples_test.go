@@ -70,7 +70,7 @@ func Example_parseToolID() {
 	fmt.Printf("Namespace: %q, Name: %q\n", namespace, name)
 	// Output:
 	// Namespace: "filesystem", Name: "read"
-	// Namespace: "filesystem", Name: "read:1.2.3"
+	// Namespace: "filesystem", Name: "read"
 	// Namespace: "", Name: "echo"
 }
 
@@ -0,0 +1,216 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// jsonSchemaTypes are the type names defined by JSON Schema.
+var jsonSchemaTypes = map[string]bool{
+	"null":    true,
+	"boolean": true,
+	"object":  true,
+	"array":   true,
+	"number":  true,
+	"integer": true,
+	"string":  true,
+}
+
+// schemaBounds pairs keywords whose lower bound must not exceed the upper.
+var schemaBounds = [][2]string{
+	{"minimum", "maximum"},
+	{"exclusiveMinimum", "exclusiveMaximum"},
+	{"minLength", "maxLength"},
+	{"minItems", "maxItems"},
+	{"minProperties", "maxProperties"},
+	{"minContains", "maxContains"},
+}
+
+// schemaCounts are keywords that must be non-negative integers.
+var schemaCounts = []string{
+	"minLength", "maxLength",
+	"minItems", "maxItems",
+	"minProperties", "maxProperties",
+	"minContains", "maxContains",
+}
+
+// ValidateSchema checks that the tool's InputSchema is structurally sound and
+// returns every problem found, each wrapping ErrInvalidSchema and naming the
+// JSON Pointer path of the offending schema (e.g. "/properties/query").
+//
+// It reports unknown type names, required entries that are not strings or
+// not declared in properties, inverted bounds such as minimum > maximum,
+// negative or fractional length and count keywords, and a multipleOf that is
+// not positive. Nested schemas under properties, items, prefixItems,
+// additionalItems, contains, $defs, definitions, anyOf, oneOf, allOf, not,
+// if, then, else, and additionalProperties are checked too.
+//
+// Unlike Validate, which stays cheap, ValidateSchema walks the whole schema
+// and is intended for publish-time checks. It returns nil for a sound schema.
+func (t *Tool) ValidateSchema() []error {
+	if t.InputSchema == nil {
+		return []error{fmt.Errorf("%w: inputSchema is required", ErrInvalidSchema)}
+	}
+	schema, err := schemaObject(t.InputSchema)
+	if err != nil {
+		return []error{fmt.Errorf("%w: inputSchema %v", ErrInvalidSchema, err)}
+	}
+	return schemaErrors(schema, "")
+}
+
+// schemaObject converts a schema given as map[string]any, json.RawMessage,
+// []byte, or any JSON-marshalable value into a generic JSON object.
+func schemaObject(schema any) (map[string]any, error) {
+	var generic any
+	switch s := schema.(type) {
+	case json.RawMessage:
+		if err := json.Unmarshal(s, &generic); err != nil {
+			return nil, err
+		}
+	case []byte:
+		if err := json.Unmarshal(s, &generic); err != nil {
+			return nil, err
+		}
+	default:
+		generic = jsonValue(schema)
+	}
+	m, ok := generic.(map[string]any)
+	if !ok {
+		return nil, errors.New("must be a JSON object")
+	}
+	return m, nil
+}
+
+// schemaErrors returns the structural problems in schema and its subschemas.
+func schemaErrors(schema map[string]any, path string) []error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w at %s: %s", ErrInvalidSchema, displayPath(path), fmt.Sprintf(format, args...)))
+	}
+
+	switch typ := schema["type"].(type) {
+	case nil:
+	case string:
+		if !jsonSchemaTypes[typ] {
+			fail("unknown type %q", typ)
+		}
+	case []any:
+		for _, v := range typ {
+			if name, ok := v.(string); !ok || !jsonSchemaTypes[name] {
+				fail("unknown type %v", v)
+			}
+		}
+	default:
+		fail("type must be a string or array of strings")
+	}
+
+	props, hasProps := schema["properties"].(map[string]any)
+	if raw, ok := schema["properties"]; ok && !hasProps {
+		fail("properties must be an object, got %T", raw)
+	}
+	switch required := schema["required"].(type) {
+	case nil:
+	case []any:
+		for _, v := range required {
+			name, ok := v.(string)
+			if !ok {
+				fail("required entries must be strings, got %v", v)
+				continue
+			}
+			if _, declared := props[name]; !declared {
+				fail("required property %q is not declared in properties", name)
+			}
+		}
+	default:
+		fail("required must be an array")
+	}
+
+	for _, keyword := range schemaCounts {
+		if n, ok := schema[keyword].(float64); ok && (n < 0 || n != math.Trunc(n)) {
+			fail("%s must be a non-negative integer, got %v", keyword, n)
+		}
+	}
+	if raw, ok := schema["multipleOf"]; ok {
+		if n, isNum := raw.(float64); !isNum || !(n > 0) {
+			fail("multipleOf must be a number greater than 0, got %v", raw)
+		}
+	}
+	for _, pair := range schemaBounds {
+		lo, loOK := schema[pair[0]].(float64)
+		hi, hiOK := schema[pair[1]].(float64)
+		if loOK && hiOK && lo > hi {
+			fail("%s %v exceeds %s %v", pair[0], lo, pair[1], hi)
+		}
+	}
+
+	for _, name := range sortedSchemaKeys(props) {
+		errs = append(errs, subschemaErrors(props[name], path+"/properties/"+escapePointer(name))...)
+	}
+	for _, keyword := range []string{"$defs", "definitions"} {
+		defs, _ := schema[keyword].(map[string]any)
+		for _, name := range sortedSchemaKeys(defs) {
+			errs = append(errs, subschemaErrors(defs[name], path+"/"+keyword+"/"+escapePointer(name))...)
+		}
+	}
+	switch items := schema["items"].(type) {
+	case nil:
+	case []any:
+		for i, item := range items {
+			errs = append(errs, subschemaErrors(item, fmt.Sprintf("%s/items/%d", path, i))...)
+		}
+	default:
+		errs = append(errs, subschemaErrors(items, path+"/items")...)
+	}
+	for _, keyword := range []string{"prefixItems", "anyOf", "oneOf", "allOf"} {
+		raw, ok := schema[keyword]
+		if !ok {
+			continue
+		}
+		list, isList := raw.([]any)
+		if !isList {
+			fail("%s must be an array", keyword)
+			continue
+		}
+		for i, sub := range list {
+			errs = append(errs, subschemaErrors(sub, fmt.Sprintf("%s/%s/%d", path, keyword, i))...)
+		}
+	}
+	for _, keyword := range []string{"additionalItems", "contains", "not", "if", "then", "else", "additionalProperties"} {
+		if sub, ok := schema[keyword]; ok {
+			errs = append(errs, subschemaErrors(sub, path+"/"+keyword)...)
+		}
+	}
+
+	return errs
+}
+
+// subschemaErrors checks a nested schema, which may be an object or a boolean.
+func subschemaErrors(v any, path string) []error {
+	switch sub := v.(type) {
+	case map[string]any:
+		return schemaErrors(sub, path)
+	case bool:
+		return nil
+	default:
+		return []error{fmt.Errorf("%w at %s: schema must be an object or boolean, got %T", ErrInvalidSchema, displayPath(path), v)}
+	}
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func sortedSchemaKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,174 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestTool_ValidateSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema any
+		want   []string
+	}{
+		{
+			name: "valid",
+			schema: map[string]any{
+				"type":     "object",
+				"required": []any{"query"},
+				"properties": map[string]any{
+					"query": map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
+					"tags":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+					"count": map[string]any{"type": []any{"integer", "null"}, "minimum": 0, "maximum": 10},
+				},
+				"additionalProperties": false,
+			},
+		},
+		{
+			name: "unknown type",
+			schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{"type": "text"},
+				},
+			},
+			want: []string{`at /properties/query: unknown type "text"`},
+		},
+		{
+			name: "undeclared required",
+			schema: map[string]any{
+				"type":       "object",
+				"required":   []any{"query", "limit"},
+				"properties": map[string]any{"query": map[string]any{"type": "string"}},
+			},
+			want: []string{`at /: required property "limit" is not declared`},
+		},
+		{
+			name: "inverted bounds",
+			schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"n": map[string]any{"type": "number", "minimum": 10, "maximum": 1},
+					"s": map[string]any{"type": "string", "minLength": 5, "maxLength": 2},
+				},
+			},
+			want: []string{
+				"at /properties/n: minimum 10 exceeds maximum 1",
+				"at /properties/s: minLength 5 exceeds maxLength 2",
+			},
+		},
+		{
+			name: "negative count",
+			schema: map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string", "maxLength": -1},
+			},
+			want: []string{"at /items: maxLength must be a non-negative integer"},
+		},
+		{
+			name: "non-positive multipleOf",
+			schema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"step":  map[string]any{"type": "number", "multipleOf": 0},
+					"price": map[string]any{"type": "number", "multipleOf": 0.1},
+				},
+			},
+			want: []string{"at /properties/step: multipleOf must be a number greater than 0"},
+		},
+		{
+			name: "nested combinators",
+			schema: map[string]any{
+				"type": "object",
+				"$defs": map[string]any{
+					"id": map[string]any{"type": "uuid"},
+				},
+				"anyOf": []any{
+					map[string]any{"type": "string"},
+					"not a schema",
+				},
+			},
+			want: []string{
+				`at /$defs/id: unknown type "uuid"`,
+				"at /anyOf/1: schema must be an object or boolean",
+			},
+		},
+		{
+			name: "array subschemas",
+			schema: map[string]any{
+				"type":            "array",
+				"prefixItems":     []any{map[string]any{"type": "text"}, 1.0},
+				"additionalItems": map[string]any{"type": "str"},
+				"contains":        map[string]any{"minLength": -1.0},
+			},
+			want: []string{
+				`at /prefixItems/0: unknown type "text"`,
+				"at /prefixItems/1: schema must be an object or boolean",
+				`at /additionalItems: unknown type "str"`,
+				"at /contains: minLength must be a non-negative integer",
+			},
+		},
+		{
+			name: "prefixItems not an array",
+			schema: map[string]any{
+				"type":        "array",
+				"prefixItems": map[string]any{"type": "string"},
+			},
+			want: []string{"at /: prefixItems must be an array"},
+		},
+		{
+			name: "conditional subschemas",
+			schema: map[string]any{
+				"type": "object",
+				"not":  map[string]any{"type": "nothing"},
+				"if":   map[string]any{"required": "x"},
+				"then": map[string]any{"multipleOf": 0.0},
+				"else": map[string]any{"minItems": 3.0, "maxItems": 1.0},
+			},
+			want: []string{
+				`at /not: unknown type "nothing"`,
+				"at /if: required must be an array",
+				"at /then: multipleOf must be a number greater than 0",
+				"at /else: minItems 3 exceeds maxItems 1",
+			},
+		},
+		{
+			name:   "raw JSON",
+			schema: []byte(`{"type":"object","required":["x"]}`),
+			want:   []string{`at /: required property "x" is not declared`},
+		},
+		{
+			name:   "not an object",
+			schema: []any{"type", "object"},
+			want:   []string{"inputSchema must be a JSON object"},
+		},
+		{
+			name: "nil",
+			want: []string{"inputSchema is required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := &Tool{Tool: mcp.Tool{Name: "t", InputSchema: tt.schema}}
+			if tt.schema == nil {
+				tool.InputSchema = nil
+			}
+			errs := tool.ValidateSchema()
+			if len(errs) != len(tt.want) {
+				t.Fatalf("ValidateSchema() = %v, want %d errors", errs, len(tt.want))
+			}
+			for i, err := range errs {
+				if !errors.Is(err, ErrInvalidSchema) {
+					t.Errorf("error %d = %v, want ErrInvalidSchema", i, err)
+				}
+				if !strings.Contains(err.Error(), tt.want[i]) {
+					t.Errorf("error %d = %q, want it to contain %q", i, err, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -1,13 +1,19 @@
 package model
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"github.com/jonwraymond/toolfoundation/internal/canonjson"
 	"github.com/jonwraymond/toolfoundation/version"
 )
 
@@ -44,26 +50,24 @@ type Tool struct {
 	Version string `json:"version,omitempty"`
 	// Tags is an optional set of search keywords for discovery layers (e.g. toolindex).
 	Tags []string `json:"tags,omitempty"`
+	// Backends declares how the tool is executed. It is not part of the MCP spec
+	// and is stripped by ToMCPJSON.
+	Backends []ToolBackend `json:"backends,omitempty"`
 }
 
 // ToolIcon is an alias for mcp.Icon from the official SDK.
 type ToolIcon = mcp.Icon
 
+// maxTagCount is the most tags NormalizeTags keeps.
+const maxTagCount = 20
+
 // NormalizeTags normalizes a list of tags for indexing/search.
 // Rules:
-// - lowercase
-// - trim whitespace
-// - replace internal whitespace with '-'
-// - allow only [a-z0-9-_.]
+// - each tag is normalized with NormalizeTag
 // - dedupe while preserving order
 // - drop empty/invalid tags
-// - max tag length: 64 chars
 // - max tag count: 20
 func NormalizeTags(tags []string) []string {
-	const (
-		maxTagLen   = 64
-		maxTagCount = 20
-	)
 	seen := make(map[string]struct{}, len(tags))
 	out := make([]string, 0, len(tags))
 
@@ -71,33 +75,8 @@ func NormalizeTags(tags []string) []string {
 		if len(out) >= maxTagCount {
 			break
 		}
-		t := strings.TrimSpace(strings.ToLower(raw))
-		if t == "" {
-			continue
-		}
-
-		// Replace any whitespace run with '-'
-		t = strings.Join(strings.Fields(t), "-")
-		if t == "" {
-			continue
-		}
-
-		// Filter to allowed characters.
-		b := make([]byte, 0, len(t))
-		for i := 0; i < len(t); i++ {
-			c := t[i]
-			if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '.' {
-				b = append(b, c)
-			}
-		}
-		if len(b) == 0 {
-			continue
-		}
-		if len(b) > maxTagLen {
-			b = b[:maxTagLen]
-		}
-		normalized := string(b)
-		if normalized == "" {
+		normalized, ok := NormalizeTag(raw)
+		if !ok {
 			continue
 		}
 		if _, ok := seen[normalized]; ok {
@@ -109,6 +88,52 @@ func NormalizeTags(tags []string) []string {
 	return out
 }
 
+// NormalizeTag normalizes a single tag and reports whether anything is left.
+// Rules:
+// - lowercase
+// - trim whitespace
+// - replace internal whitespace with '-'
+// - allow only [a-z0-9-_.]
+// - max tag length: 64 chars
+func NormalizeTag(tag string) (string, bool) {
+	const maxTagLen = 64
+
+	// Replace any whitespace run with '-'
+	t := strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+
+	// Filter to allowed characters.
+	b := make([]byte, 0, len(t))
+	for i := 0; i < len(t); i++ {
+		c := t[i]
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '.' {
+			b = append(b, c)
+		}
+	}
+	if len(b) == 0 {
+		return "", false
+	}
+	if len(b) > maxTagLen {
+		b = b[:maxTagLen]
+	}
+	return string(b), true
+}
+
+// TagsContain reports whether tags contains query once both are normalized
+// with NormalizeTag, so "Web Search" matches a stored "web-search".
+// A query that normalizes to nothing matches no tag.
+func TagsContain(tags []string, query string) bool {
+	want, ok := NormalizeTag(query)
+	if !ok {
+		return false
+	}
+	for _, tag := range tags {
+		if got, ok := NormalizeTag(tag); ok && got == want {
+			return true
+		}
+	}
+	return false
+}
+
 // BackendKind defines the type of backend backing a tool.
 type BackendKind string
 
@@ -161,42 +186,26 @@ func (t *Tool) ToolID() string {
 // ParseToolID parses a tool ID string into namespace and name components.
 // The format is "namespace:name:version", "namespace:name", or just "name".
 // Returns an error if the ID is empty or contains more than two colons.
-// When a version segment is present, the returned name includes ":version".
+// A version segment is validated but dropped; use ParseToolIDWithVersion to
+// keep it.
 func ParseToolID(id string) (namespace, name string, err error) {
-	if id == "" {
-		return "", "", ErrInvalidToolID
-	}
-
-	// Count colons - we only allow at most one
-	colonCount := strings.Count(id, ":")
-	if colonCount > 2 {
-		return "", "", ErrInvalidToolID
-	}
-
-	if colonCount == 0 {
-		// No namespace, just the name
-		return "", id, nil
-	}
-
-	parts := strings.Split(id, ":")
-	namespace = parts[0]
-	name = parts[1]
-	if colonCount == 2 {
-		name = name + ":" + parts[2]
-	}
-
-	// Both namespace and name must be non-empty when colon is present
-	if namespace == "" || name == "" || (colonCount == 2 && parts[2] == "") {
-		return "", "", ErrInvalidToolID
-	}
-
-	return namespace, name, nil
+	namespace, name, _, err = ParseToolIDWithVersion(id)
+	return namespace, name, err
 }
 
 // ParseToolIDWithVersion parses a tool ID string into namespace, name, and version.
 // The format is "namespace:name:version", "namespace:name", or just "name".
 // Returns an error if the ID is empty or contains more than two colons.
 func ParseToolIDWithVersion(id string) (namespace, name, version string, err error) {
+	return ParseQualifiedID(id)
+}
+
+// ParseQualifiedID parses a "namespace:name:version", "namespace:name", or
+// "name" identifier. It is the single definition of qualified-ID syntax,
+// shared by tool IDs and by adapters that encode tools the same way, such
+// as A2A skill IDs. Returns ErrInvalidToolID if the ID is empty, contains
+// more than two colons, or has an empty segment.
+func ParseQualifiedID(id string) (namespace, name, version string, err error) {
 	if id == "" {
 		return "", "", "", ErrInvalidToolID
 	}
@@ -236,19 +245,13 @@ func (t *Tool) Validate() error {
 	if len(t.Name) > maxToolNameLen {
 		return fmt.Errorf("%w: name exceeds %d characters", ErrInvalidTool, maxToolNameLen)
 	}
-	var invalidChars []string
-	seen := make(map[rune]bool)
-	for _, r := range t.Name {
-		if !validToolNameRune(r) {
-			if !seen[r] {
-				invalidChars = append(invalidChars, string(r))
-				seen[r] = true
-			}
-		}
-	}
-	if len(invalidChars) > 0 {
+	if invalidChars := invalidNameChars(t.Name); len(invalidChars) > 0 {
 		return fmt.Errorf("%w: name contains invalid characters: %s", ErrInvalidTool, strings.Join(invalidChars, ", "))
 	}
+	// Namespaces share the name character set, which excludes the ':' ToolID separator.
+	if invalidChars := invalidNameChars(t.Namespace); len(invalidChars) > 0 {
+		return fmt.Errorf("%w: namespace contains invalid characters: %s", ErrInvalidTool, strings.Join(invalidChars, ", "))
+	}
 	if t.InputSchema == nil {
 		return fmt.Errorf("%w: inputSchema is required", ErrInvalidTool)
 	}
@@ -282,6 +285,68 @@ func (b ToolBackend) Validate() error {
 	return nil
 }
 
+// UnmarshalJSON decodes a backend and validates it, so that a backend whose
+// details do not match its Kind, such as {"kind":"mcp","local":{...}}, fails
+// at parse time. Details for a kind other than Kind are rejected too. A JSON
+// null leaves the backend unchanged, as for other Go values.
+func (b *ToolBackend) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	type plain ToolBackend
+	var decoded plain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	backend := ToolBackend(decoded)
+	if err := backend.Validate(); err != nil {
+		return err
+	}
+	details := map[BackendKind]bool{
+		BackendKindMCP:      backend.MCP != nil,
+		BackendKindProvider: backend.Provider != nil,
+		BackendKindLocal:    backend.Local != nil,
+	}
+	for _, kind := range []BackendKind{BackendKindMCP, BackendKindProvider, BackendKindLocal} {
+		if kind != backend.Kind && details[kind] {
+			return fmt.Errorf("%w: %s backend must not set %s details", ErrInvalidBackend, backend.Kind, kind)
+		}
+	}
+	*b = backend
+	return nil
+}
+
+// invalidNameChars returns the distinct characters in s that are not allowed
+// in tool names, in order of first appearance.
+func invalidNameChars(s string) []string {
+	var invalidChars []string
+	seen := make(map[rune]bool)
+	for _, r := range s {
+		if !validToolNameRune(r) && !seen[r] {
+			invalidChars = append(invalidChars, string(r))
+			seen[r] = true
+		}
+	}
+	return invalidChars
+}
+
+// SanitizeToolName returns name with every character not allowed in tool
+// names replaced by '_', shortened to the maximum name length, so that it
+// passes Tool.Validate's name rules. For example, "files/read all" becomes
+// "files_read_all". Names that are already valid are returned unchanged.
+func SanitizeToolName(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if validToolNameRune(r) {
+			return r
+		}
+		return '_'
+	}, name)
+	if len(sanitized) > maxToolNameLen {
+		sanitized = sanitized[:maxToolNameLen]
+	}
+	return sanitized
+}
+
 func validToolNameRune(r rune) bool {
 	return (r >= 'a' && r <= 'z') ||
 		(r >= 'A' && r <= 'Z') ||
@@ -290,8 +355,8 @@ func validToolNameRune(r rune) bool {
 }
 
 // ToMCPJSON serializes the Tool to JSON that is compatible with the MCP Tool spec.
-// This strips toolmodel-specific fields (Namespace, Version) and returns only
-// the standard MCP Tool fields.
+// This strips toolmodel-specific fields (Namespace, Version, Tags, Backends) and
+// returns only the standard MCP Tool fields.
 func (t *Tool) ToMCPJSON() ([]byte, error) {
 	return json.Marshal(t.Tool)
 }
@@ -301,6 +366,22 @@ func (t *Tool) ToJSON() ([]byte, error) {
 	return json.Marshal(t)
 }
 
+// ToMCPJSONArray serializes tools into the MCP tools/list envelope
+// {"tools":[...]}. Like ToMCPJSON, each tool is stripped to the standard MCP
+// Tool fields.
+func ToMCPJSONArray(tools []*Tool) ([]byte, error) {
+	mcpTools := make([]mcp.Tool, len(tools))
+	for i, t := range tools {
+		if t == nil {
+			return nil, fmt.Errorf("%w: tool %d is nil", ErrInvalidTool, i)
+		}
+		mcpTools[i] = t.Tool
+	}
+	return json.Marshal(struct {
+		Tools []mcp.Tool `json:"tools"`
+	}{Tools: mcpTools})
+}
+
 // FromMCPJSON deserializes an MCP Tool JSON into a Tool struct.
 // The Namespace and Version fields will be empty after this call.
 func FromMCPJSON(data []byte) (*Tool, error) {
@@ -311,6 +392,39 @@ func FromMCPJSON(data []byte) (*Tool, error) {
 	return &Tool{Tool: mcpTool}, nil
 }
 
+// FromMCPJSONArray deserializes a list of MCP Tool JSON objects, given either
+// as a bare JSON array or as an object with a "tools" key (the shape of an
+// MCP tools/list result). The Namespace and Version fields will be empty.
+// It returns an error naming the index of the first tool without a name.
+func FromMCPJSONArray(data []byte) ([]*Tool, error) {
+	trimmed := bytes.TrimSpace(data)
+
+	var mcpTools []mcp.Tool
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var list struct {
+			Tools *[]mcp.Tool `json:"tools"`
+		}
+		if err := json.Unmarshal(trimmed, &list); err != nil {
+			return nil, err
+		}
+		if list.Tools == nil {
+			return nil, fmt.Errorf("%w: missing tools array", ErrInvalidTool)
+		}
+		mcpTools = *list.Tools
+	} else if err := json.Unmarshal(trimmed, &mcpTools); err != nil {
+		return nil, err
+	}
+
+	tools := make([]*Tool, len(mcpTools))
+	for i, mcpTool := range mcpTools {
+		if mcpTool.Name == "" {
+			return nil, fmt.Errorf("%w: tool %d: name is required", ErrInvalidTool, i)
+		}
+		tools[i] = &Tool{Tool: mcpTool}
+	}
+	return tools, nil
+}
+
 // FromJSON deserializes a full Tool JSON (including toolmodel extensions) into a Tool struct.
 func FromJSON(data []byte) (*Tool, error) {
 	var tool Tool
@@ -393,7 +507,7 @@ func (t *Tool) Clone() *Tool {
 		}
 	}
 
-	// Deep copy InputSchema (via JSON round-trip for any type)
+	// Deep copy InputSchema
 	if t.InputSchema != nil {
 		clone.InputSchema = deepCopyAny(t.InputSchema)
 	}
@@ -415,25 +529,391 @@ func (t *Tool) Clone() *Tool {
 		copy(clone.Tags, t.Tags)
 	}
 
+	// Deep copy Backends
+	if t.Backends != nil {
+		clone.Backends = make([]ToolBackend, len(t.Backends))
+		for i, b := range t.Backends {
+			clone.Backends[i] = b.clone()
+		}
+	}
+
 	return clone
 }
 
-// deepCopyAny creates a deep copy of an any value via JSON round-trip.
-func deepCopyAny(v any) any {
+// WithNamespace returns a clone of the tool with Namespace set to ns.
+// The original tool is not modified. Returns nil if the receiver is nil.
+func (t *Tool) WithNamespace(ns string) *Tool {
+	clone := t.Clone()
+	if clone != nil {
+		clone.Namespace = ns
+	}
+	return clone
+}
+
+// WithVersion returns a clone of the tool with Version set to v.
+// The original tool is not modified. Returns nil if the receiver is nil.
+func (t *Tool) WithVersion(v string) *Tool {
+	clone := t.Clone()
+	if clone != nil {
+		clone.Version = v
+	}
+	return clone
+}
+
+// clone returns a copy of the backend that shares no pointers with b.
+func (b ToolBackend) clone() ToolBackend {
+	if b.MCP != nil {
+		v := *b.MCP
+		b.MCP = &v
+	}
+	if b.Provider != nil {
+		v := *b.Provider
+		b.Provider = &v
+	}
+	if b.Local != nil {
+		v := *b.Local
+		b.Local = &v
+	}
+	return b
+}
+
+// AddBackend validates b and appends it to the tool's backends.
+func (t *Tool) AddBackend(b ToolBackend) error {
+	if err := b.Validate(); err != nil {
+		return err
+	}
+	t.Backends = append(t.Backends, b)
+	return nil
+}
+
+// PrimaryBackend returns the first backend that passes Validate.
+// It returns false if the tool has no valid backend.
+func (t *Tool) PrimaryBackend() (ToolBackend, bool) {
+	for _, b := range t.Backends {
+		if b.Validate() == nil {
+			return b, true
+		}
+	}
+	return ToolBackend{}, false
+}
+
+// Fingerprint returns a deterministic SHA-256 hex digest of the tool's
+// definition, suitable for detecting when a tool changed.
+//
+// Only name, title, description, inputSchema, outputSchema, and annotations
+// participate. They are encoded as canonical JSON with all object keys sorted,
+// so map insertion order does not affect the result. Namespace, Version, Tags,
+// Icons, Backends, and Meta (which may carry volatile values such as
+// "traceId") are excluded. Fingerprint returns "" for a nil tool or if a
+// schema cannot be encoded as JSON.
+func (t *Tool) Fingerprint() string {
+	if t == nil {
+		return ""
+	}
+
+	canonical := map[string]any{"name": t.Name}
+	if t.Title != "" {
+		canonical["title"] = t.Title
+	}
+	if t.Description != "" {
+		canonical["description"] = t.Description
+	}
+	if t.InputSchema != nil {
+		canonical["inputSchema"] = t.InputSchema
+	}
+	if t.OutputSchema != nil {
+		canonical["outputSchema"] = t.OutputSchema
+	}
+	if t.Annotations != nil {
+		canonical["annotations"] = t.Annotations
+	}
+
+	data, err := canonjson.Marshal(canonical)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Merge returns a new Tool with overlay applied on top of t.
+// Non-empty scalar fields in overlay win, Tags are unioned and re-normalized
+// with NormalizeTags, Meta is shallow-merged with overlay keys winning, and a
+// non-nil overlay InputSchema, OutputSchema, Annotations, Icons, or Backends
+// replaces the base value. Neither t nor overlay is modified.
+func (t *Tool) Merge(overlay *Tool) *Tool {
+	if t == nil {
+		t = &Tool{}
+	}
+	merged := t.Clone()
+	if overlay == nil {
+		return merged
+	}
+	patch := overlay.Clone()
+
+	if patch.Name != "" {
+		merged.Name = patch.Name
+	}
+	if patch.Title != "" {
+		merged.Title = patch.Title
+	}
+	if patch.Description != "" {
+		merged.Description = patch.Description
+	}
+	if patch.Namespace != "" {
+		merged.Namespace = patch.Namespace
+	}
+	if patch.Version != "" {
+		merged.Version = patch.Version
+	}
+
+	if len(patch.Tags) > 0 {
+		merged.Tags = NormalizeTags(append(merged.Tags, patch.Tags...))
+	}
+
+	if len(patch.Meta) > 0 {
+		if merged.Meta == nil {
+			merged.Meta = make(mcp.Meta, len(patch.Meta))
+		}
+		for k, v := range patch.Meta {
+			merged.Meta[k] = v
+		}
+	}
+
+	if patch.Annotations != nil {
+		merged.Annotations = patch.Annotations
+	}
+	if patch.Icons != nil {
+		merged.Icons = patch.Icons
+	}
+	if patch.Backends != nil {
+		merged.Backends = patch.Backends
+	}
+	if patch.InputSchema != nil {
+		merged.InputSchema = patch.InputSchema
+	}
+	if patch.OutputSchema != nil {
+		merged.OutputSchema = patch.OutputSchema
+	}
+
+	return merged
+}
+
+// Equal reports whether t and other describe the same tool.
+// Tags are compared ignoring order, Annotations by value, and Meta, Icons,
+// Backends, InputSchema, and OutputSchema by their canonical JSON form, so a schema
+// given as map[string]any equals the same schema given as json.RawMessage.
+// Two nil tools are equal; a nil and a non-nil tool are not.
+func (t *Tool) Equal(other *Tool) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+
+	if t.Name != other.Name ||
+		t.Title != other.Title ||
+		t.Description != other.Description ||
+		t.Namespace != other.Namespace ||
+		t.Version != other.Version {
+		return false
+	}
+
+	if !tagsEqual(t.Tags, other.Tags) ||
+		!annotationsEqual(t.Annotations, other.Annotations) {
+		return false
+	}
+
+	if len(t.Meta) != 0 || len(other.Meta) != 0 {
+		if !canonicalEqual(t.Meta, other.Meta) {
+			return false
+		}
+	}
+	if len(t.Icons) != 0 || len(other.Icons) != 0 {
+		if !canonicalEqual(t.Icons, other.Icons) {
+			return false
+		}
+	}
+	if len(t.Backends) != 0 || len(other.Backends) != 0 {
+		if !canonicalEqual(t.Backends, other.Backends) {
+			return false
+		}
+	}
+
+	return canonicalEqual(t.InputSchema, other.InputSchema) &&
+		canonicalEqual(t.OutputSchema, other.OutputSchema)
+}
+
+// tagsEqual compares two tag lists ignoring order.
+func tagsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// annotationsEqual compares two annotation sets by value.
+func annotationsEqual(a, b *mcp.ToolAnnotations) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Title == b.Title &&
+		a.ReadOnlyHint == b.ReadOnlyHint &&
+		a.IdempotentHint == b.IdempotentHint &&
+		boolPtrEqual(a.DestructiveHint, b.DestructiveHint) &&
+		boolPtrEqual(a.OpenWorldHint, b.OpenWorldHint)
+}
+
+func boolPtrEqual(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// canonicalEqual compares two values by their JSON form.
+func canonicalEqual(a, b any) bool {
+	return reflect.DeepEqual(jsonValue(a), jsonValue(b))
+}
+
+// jsonValue returns v in its generic JSON form (map[string]any, []any,
+// float64, string, bool, or nil) via a JSON round-trip. Values that cannot be
+// encoded are returned unchanged.
+func jsonValue(v any) any {
 	if v == nil {
 		return nil
 	}
 	data, err := json.Marshal(v)
 	if err != nil {
-		return v // fallback to shallow copy on error
+		return v
 	}
 	var result any
 	if err := json.Unmarshal(data, &result); err != nil {
-		return v // fallback to shallow copy on error
+		return v
 	}
 	return result
 }
 
+// deepCopyAny creates a deep copy of an any value, preserving its types.
+// Maps, slices, arrays, pointers, and exported struct fields are copied
+// recursively. Only values that cannot be copied (channels, funcs, unsafe
+// pointers, and unexported struct fields) are shared with the original.
+func deepCopyAny(v any) any {
+	return copyAny(v, make(map[copySeenKey]reflect.Value))
+}
+
+// copySeenKey identifies an already-copied pointer or map. The type is part
+// of the key because distinct values can share an address, such as a struct
+// and its first field, or two zero-size allocations.
+type copySeenKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// copyAny is deepCopyAny with fast paths for decoded JSON values. seen is
+// shared with deepCopyValue so that cyclic maps terminate on either path.
+func copyAny(v any, seen map[copySeenKey]reflect.Value) any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		if v == nil {
+			return map[string]any(nil)
+		}
+		key := copySeenKey{reflect.ValueOf(v).Pointer(), reflect.TypeOf(v)}
+		if m, ok := seen[key]; ok {
+			return m.Interface()
+		}
+		out := make(map[string]any, len(v))
+		seen[key] = reflect.ValueOf(out)
+		for k, elem := range v {
+			out[k] = copyAny(elem, seen)
+		}
+		return out
+	case []any:
+		if v == nil {
+			return []any(nil)
+		}
+		out := make([]any, len(v))
+		for i, elem := range v {
+			out[i] = copyAny(elem, seen)
+		}
+		return out
+	case string, bool, float64, int, int64, json.Number:
+		return v
+	}
+	return deepCopyValue(reflect.ValueOf(v), seen).Interface()
+}
+
+// deepCopyValue copies v recursively. seen maps already-copied pointers to
+// their copies so that cyclic values terminate.
+func deepCopyValue(v reflect.Value, seen map[copySeenKey]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(deepCopyValue(v.Elem(), seen))
+		return out
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := copySeenKey{v.Pointer(), v.Type()}
+		if p, ok := seen[key]; ok {
+			return p
+		}
+		out := reflect.New(v.Type().Elem())
+		seen[key] = out
+		out.Elem().Set(deepCopyValue(v.Elem(), seen))
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		key := copySeenKey{v.Pointer(), v.Type()}
+		if m, ok := seen[key]; ok {
+			return m
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		seen[key] = out
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), deepCopyValue(iter.Value(), seen))
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(deepCopyValue(v.Index(i), seen))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(deepCopyValue(v.Index(i), seen))
+		}
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v) // copies unexported fields by value
+		for i := 0; i < v.NumField(); i++ {
+			if field := out.Field(i); field.CanSet() {
+				field.Set(deepCopyValue(v.Field(i), seen))
+			}
+		}
+		return out
+	default:
+		// Scalars are copied by value; chan, func, and unsafe pointers are shared.
+		return v
+	}
+}
+
 // ParsedVersion returns the Tool's version as a structured version.Version.
 // Returns an error if the version string is empty or cannot be parsed.
 func (t *Tool) ParsedVersion() (version.Version, error) {
@@ -442,3 +922,12 @@ func (t *Tool) ParsedVersion() (version.Version, error) {
 	}
 	return version.Parse(t.Version)
 }
+
+// CoercedVersion is like ParsedVersion but accepts partial versions such as
+// "1" or "1.2", padding the missing components with zeros.
+func (t *Tool) CoercedVersion() (version.Version, error) {
+	if t.Version == "" {
+		return version.Version{}, errors.New("tool has no version")
+	}
+	return version.Coerce(t.Version)
+}
@@ -3,6 +3,8 @@ package model
 import (
 	"encoding/json"
 	"errors"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
@@ -80,7 +82,7 @@ func TestParseToolID(t *testing.T) {
 			name:          "with namespace and version",
 			id:            "filesystem:read:1.0.0",
 			wantNamespace: "filesystem",
-			wantName:      "read:1.0.0",
+			wantName:      "read",
 			wantErr:       false,
 		},
 		{
@@ -99,7 +101,7 @@ func TestParseToolID(t *testing.T) {
 			name:          "multiple colons",
 			id:            "a:b:c",
 			wantNamespace: "a",
-			wantName:      "b:c",
+			wantName:      "b",
 			wantErr:       false,
 		},
 		{
@@ -172,12 +174,8 @@ func TestParseToolID_RoundTrip(t *testing.T) {
 			t.Errorf("ParseToolID(ToolID()) failed for namespace=%q, name=%q: %v", tt.namespace, tt.name, err)
 			continue
 		}
-		wantName := tt.name
-		if tt.namespace != "" && tt.version != "" {
-			wantName = tt.name + ":" + tt.version
-		}
-		if gotNamespace != tt.namespace || gotName != wantName {
-			t.Errorf("Round-trip failed: got (%q, %q), want (%q, %q)", gotNamespace, gotName, tt.namespace, wantName)
+		if gotNamespace != tt.namespace || gotName != tt.name {
+			t.Errorf("Round-trip failed: got (%q, %q), want (%q, %q)", gotNamespace, gotName, tt.namespace, tt.name)
 		}
 	}
 }
@@ -248,6 +246,46 @@ func TestParseToolIDWithVersion(t *testing.T) {
 	}
 }
 
+func TestParseQualifiedID(t *testing.T) {
+	tests := []struct {
+		id            string
+		wantNamespace string
+		wantName      string
+		wantVersion   string
+		wantErr       bool
+	}{
+		{id: "read", wantName: "read"},
+		{id: "fs:read", wantNamespace: "fs", wantName: "read"},
+		{id: "fs:read:1.0.0", wantNamespace: "fs", wantName: "read", wantVersion: "1.0.0"},
+		{id: "", wantErr: true},
+		{id: ":read", wantErr: true},
+		{id: "fs:", wantErr: true},
+		{id: "fs::1.0.0", wantErr: true},
+		{id: "fs:read:", wantErr: true},
+		{id: "::", wantErr: true},
+		{id: "a:b:c:d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			namespace, name, version, err := ParseQualifiedID(tt.id)
+			if tt.wantErr {
+				if err != ErrInvalidToolID {
+					t.Errorf("ParseQualifiedID(%q) error = %v, want ErrInvalidToolID", tt.id, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseQualifiedID(%q) error = %v", tt.id, err)
+			}
+			if namespace != tt.wantNamespace || name != tt.wantName || version != tt.wantVersion {
+				t.Errorf("ParseQualifiedID(%q) = (%q, %q, %q), want (%q, %q, %q)",
+					tt.id, namespace, name, version, tt.wantNamespace, tt.wantName, tt.wantVersion)
+			}
+		})
+	}
+}
+
 func TestBackendKind_Constants(t *testing.T) {
 	// Verify the constants have expected string values
 	if BackendKindMCP != "mcp" {
@@ -412,6 +450,73 @@ func TestToolBackend_Validate(t *testing.T) {
 	}
 }
 
+func TestToolBackend_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    ToolBackend
+		wantErr bool
+	}{
+		{
+			name: "valid mcp",
+			json: `{"kind":"mcp","mcp":{"serverName":"github"}}`,
+			want: NewMCPBackend("github"),
+		},
+		{
+			name: "valid provider",
+			json: `{"kind":"provider","provider":{"providerId":"p","toolId":"t"}}`,
+			want: NewProviderBackend("p", "t"),
+		},
+		{name: "mismatched discriminator", json: `{"kind":"mcp","local":{"name":"x"}}`, wantErr: true},
+		{name: "extra details", json: `{"kind":"local","local":{"name":"x"},"mcp":{"serverName":"s"}}`, wantErr: true},
+		{name: "unknown kind", json: `{"kind":"grpc"}`, wantErr: true},
+		{name: "missing kind", json: `{"local":{"name":"x"}}`, wantErr: true},
+		{name: "malformed", json: `{"kind":`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ToolBackend
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Unmarshal() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolBackend_UnmarshalJSON_InTool(t *testing.T) {
+	data := []byte(`{"name":"search","inputSchema":{"type":"object"},"backends":[{"kind":"mcp","local":{"name":"x"}}]}`)
+	_, err := FromJSON(data)
+	if !errors.Is(err, ErrInvalidBackend) {
+		t.Errorf("FromJSON() error = %v, want ErrInvalidBackend", err)
+	}
+}
+
+func TestToolBackend_UnmarshalJSON_Null(t *testing.T) {
+	var backends []ToolBackend
+	if err := json.Unmarshal([]byte(`[{"kind":"local","local":{"name":"x"}},null]`), &backends); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(backends) != 2 || backends[0].Kind != BackendKindLocal || backends[1] != (ToolBackend{}) {
+		t.Errorf("backends = %+v, want a local backend and a zero backend", backends)
+	}
+
+	var ptr *ToolBackend
+	if err := json.Unmarshal([]byte(`null`), &ptr); err != nil || ptr != nil {
+		t.Errorf("Unmarshal(null) = %v, %v, want nil pointer and no error", ptr, err)
+	}
+}
+
 func TestTool_EmbedsMCPTool(t *testing.T) {
 	// Verify Tool correctly embeds mcp.Tool and can access its fields
 	tool := Tool{
@@ -854,6 +959,39 @@ func TestToolValidate_NameRules(t *testing.T) {
 	}
 }
 
+func TestSanitizeToolName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "valid", in: "read_file-v2", want: "read_file-v2"},
+		{name: "dots kept", in: "files.read", want: "files.read"},
+		{name: "slashes", in: "files/read", want: "files_read"},
+		{name: "spaces", in: "read all files", want: "read_all_files"},
+		{name: "colon", in: "ns:tool", want: "ns_tool"},
+		{name: "multibyte", in: "café", want: "caf_"},
+		{name: "too long", in: strings.Repeat("a", 130), want: strings.Repeat("a", 128)},
+		{name: "empty", in: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SanitizeToolName(tt.in)
+			if got != tt.want {
+				t.Errorf("SanitizeToolName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if got == "" {
+				return
+			}
+			tool := Tool{Tool: mcp.Tool{Name: got, InputSchema: map[string]any{"type": "object"}}}
+			if err := tool.Validate(); err != nil {
+				t.Errorf("Validate() of sanitized name error = %v", err)
+			}
+		})
+	}
+}
+
 func TestNormalizeTags(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1085,6 +1223,38 @@ func TestTool_Clone_Nil(t *testing.T) {
 	}
 }
 
+func TestTool_WithNamespaceAndVersion(t *testing.T) {
+	original := &Tool{
+		Tool: mcp.Tool{
+			Name:        "search",
+			InputSchema: map[string]any{"type": "object"},
+		},
+		Namespace: "github",
+		Version:   "1.0.0",
+		Tags:      []string{"search"},
+	}
+
+	stamped := original.WithNamespace("gitlab").WithVersion("2.0.0")
+
+	if stamped.Namespace != "gitlab" || stamped.Version != "2.0.0" {
+		t.Errorf("stamped = %s, want gitlab:search:2.0.0", stamped.ToolID())
+	}
+	if original.Namespace != "github" || original.Version != "1.0.0" {
+		t.Errorf("original changed to %s", original.ToolID())
+	}
+
+	stamped.Tags[0] = "changed"
+	stamped.InputSchema.(map[string]any)["type"] = "string"
+	if original.Tags[0] != "search" || original.InputSchema.(map[string]any)["type"] != "object" {
+		t.Error("WithNamespace/WithVersion result shares state with the original")
+	}
+
+	var nilTool *Tool
+	if nilTool.WithNamespace("ns") != nil || nilTool.WithVersion("1.0.0") != nil {
+		t.Error("WithNamespace/WithVersion on nil should return nil")
+	}
+}
+
 func TestTool_Clone_MinimalFields(t *testing.T) {
 	original := &Tool{
 		Tool: mcp.Tool{
@@ -1191,6 +1361,28 @@ func TestTool_ParsedVersion(t *testing.T) {
 	}
 }
 
+func TestTool_CoercedVersion(t *testing.T) {
+	tool := &Tool{
+		Tool:    mcp.Tool{Name: "test", InputSchema: map[string]any{"type": "object"}},
+		Version: "1.2",
+	}
+	if _, err := tool.ParsedVersion(); err == nil {
+		t.Error("ParsedVersion() should reject a partial version")
+	}
+	got, err := tool.CoercedVersion()
+	if err != nil {
+		t.Fatalf("CoercedVersion() error = %v", err)
+	}
+	if want := version.MustParse("1.2.0"); got != want {
+		t.Errorf("CoercedVersion() = %v, want %v", got, want)
+	}
+
+	tool.Version = ""
+	if _, err := tool.CoercedVersion(); err == nil {
+		t.Error("CoercedVersion() with empty version should error")
+	}
+}
+
 func TestTool_ParsedVersion_Comparison(t *testing.T) {
 	tool1 := &Tool{
 		Tool:    mcp.Tool{Name: "t1", InputSchema: map[string]any{"type": "object"}},
@@ -1268,6 +1460,68 @@ func TestTool_Validate_InvalidCharsMultiple(t *testing.T) {
 	}
 }
 
+func TestParseToolID_RoundTripWithVersion(t *testing.T) {
+	tool := &Tool{
+		Tool:      mcp.Tool{Name: "read", InputSchema: map[string]any{"type": "object"}},
+		Namespace: "filesystem",
+		Version:   "1.0.0",
+	}
+
+	namespace, name, err := ParseToolID(tool.ToolID())
+	if err != nil {
+		t.Fatalf("ParseToolID(%q) error = %v", tool.ToolID(), err)
+	}
+	if namespace != tool.Namespace || name != tool.Name {
+		t.Errorf("ParseToolID(%q) = %q, %q, want %q, %q", tool.ToolID(), namespace, name, tool.Namespace, tool.Name)
+	}
+	if strings.Contains(name, ":") {
+		t.Errorf("ParseToolID(%q) name %q contains a colon", tool.ToolID(), name)
+	}
+
+	parsed := &Tool{Tool: mcp.Tool{Name: name, InputSchema: map[string]any{"type": "object"}}, Namespace: namespace}
+	if err := parsed.Validate(); err != nil {
+		t.Errorf("Validate() on parsed ID error = %v", err)
+	}
+}
+
+func TestTool_Validate_Namespace(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		wantErr   string
+	}{
+		{"colon", "acme:docs", ":"},
+		{"spaces", "acme docs", " "},
+		{"dotted", "com.acme.docs", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := Tool{
+				Tool: mcp.Tool{
+					Name:        "search",
+					InputSchema: map[string]any{"type": "object"},
+				},
+				Namespace: tt.namespace,
+			}
+			err := tool.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidTool) {
+				t.Fatalf("Validate() error = %v, want ErrInvalidTool", err)
+			}
+			if !strings.Contains(err.Error(), "namespace") || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %q, want namespace error naming %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestTool_Clone_NilInputSchema(t *testing.T) {
 	// Test Clone with nil schemas - covers deepCopyAny nil path
 	tool := &Tool{
@@ -1299,7 +1553,7 @@ func TestTool_Clone_WithUnmarshallableSchemaValue(t *testing.T) {
 		},
 	}
 
-	// Clone should not panic - it falls back to shallow copy on marshal error
+	// Clone should not panic; the func value is shared with the original
 	clone := tool.Clone()
 	if clone == nil {
 		t.Fatal("Clone() should not return nil")
@@ -1334,6 +1588,89 @@ func TestTool_Clone_WithFuncInSchema(t *testing.T) {
 	}
 }
 
+func TestTool_Clone_CyclicMap(t *testing.T) {
+	schema := map[string]any{"type": "object"}
+	schema["properties"] = map[string]any{"self": schema}
+	schema["anyOf"] = []any{schema}
+	tool := &Tool{Tool: mcp.Tool{Name: "test", InputSchema: schema}}
+
+	clone := tool.Clone()
+	cloned := clone.InputSchema.(map[string]any)
+	self := cloned["properties"].(map[string]any)["self"].(map[string]any)
+	if reflect.ValueOf(self).Pointer() != reflect.ValueOf(cloned).Pointer() {
+		t.Error("clone cycle does not point back to the cloned schema")
+	}
+	if reflect.ValueOf(cloned).Pointer() == reflect.ValueOf(schema).Pointer() {
+		t.Error("clone shares the original schema map")
+	}
+	if item := cloned["anyOf"].([]any)[0].(map[string]any); reflect.ValueOf(item).Pointer() != reflect.ValueOf(cloned).Pointer() {
+		t.Error("clone cycle through a slice does not point back to the cloned schema")
+	}
+}
+
+type cloneInner struct {
+	Value string
+}
+
+type cloneOuter struct {
+	First cloneInner
+	Ptr   *cloneInner
+}
+
+func TestTool_Clone_PointerToFirstField(t *testing.T) {
+	// o and &o.First share an address; the copy of one must not be reused
+	// for the other.
+	o := &cloneOuter{First: cloneInner{Value: "v"}}
+	o.Ptr = &o.First
+	tool := &Tool{Tool: mcp.Tool{Name: "test", InputSchema: map[string]any{"type": "object", "x-value": o}}}
+
+	clone := tool.Clone()
+	value := clone.InputSchema.(map[string]any)["x-value"]
+	got, ok := value.(*cloneOuter)
+	if !ok || got == o {
+		t.Fatalf("x-value = %v, want a copied *cloneOuter", value)
+	}
+	if got.Ptr == nil || got.Ptr.Value != "v" || got.Ptr == o.Ptr {
+		t.Errorf("Ptr = %v, want a copy of the first field", got.Ptr)
+	}
+}
+
+func TestTool_Clone_NestedMapWithUnmarshallableValue(t *testing.T) {
+	// A func anywhere in the schema used to force a shallow copy of the whole
+	// schema, aliasing nested maps between the clone and the original.
+	tool := &Tool{
+		Tool: mcp.Tool{
+			Name: "test",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{"type": "string", "maxLength": 100},
+				},
+				"required": []string{"query"},
+				"x-hook":   func() {},
+			},
+		},
+	}
+
+	clone := tool.Clone()
+	schema := clone.InputSchema.(map[string]any)
+	query := schema["properties"].(map[string]any)["query"].(map[string]any)
+	query["type"] = "integer"
+	schema["required"].([]string)[0] = "other"
+
+	original := tool.InputSchema.(map[string]any)
+	origQuery := original["properties"].(map[string]any)["query"].(map[string]any)
+	if origQuery["type"] != "string" {
+		t.Errorf("original nested type = %v, want string", origQuery["type"])
+	}
+	if got := original["required"].([]string)[0]; got != "query" {
+		t.Errorf("original required[0] = %q, want query", got)
+	}
+	if _, ok := query["maxLength"].(int); !ok {
+		t.Errorf("clone maxLength type = %T, want int preserved", query["maxLength"])
+	}
+}
+
 func TestNormalizeTags_AllSpecialChars(t *testing.T) {
 	// Tag with only special characters that get filtered out
 	tags := []string{"@#$%^&*()"}
@@ -1351,3 +1688,524 @@ func TestNormalizeTags_WhitespaceOnly(t *testing.T) {
 		t.Errorf("NormalizeTags() = %v, want empty slice for whitespace-only tags", result)
 	}
 }
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{input: "Search", want: "search", wantOK: true},
+		{input: "  Web   Search ", want: "web-search", wantOK: true},
+		{input: "c++/go!", want: "cgo", wantOK: true},
+		{input: "v1.2_beta", want: "v1.2_beta", wantOK: true},
+		{input: strings.Repeat("a", 70), want: strings.Repeat("a", 64), wantOK: true},
+		{input: "!!!", want: "", wantOK: false},
+		{input: "   ", want: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := NormalizeTag(tt.input)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizeTag(%q) = (%q, %v), want (%q, %v)", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTagsContain(t *testing.T) {
+	tags := NormalizeTags([]string{"Web Search", "GitHub", "c#"})
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "web-search", want: true},
+		{query: "WEB SEARCH", want: true},
+		{query: "GitHub", want: true},
+		{query: "C#", want: true},
+		{query: "c", want: true},
+		{query: "git", want: false},
+		{query: "#!", want: false},
+		{query: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := TagsContain(tags, tt.query); got != tt.want {
+				t.Errorf("TagsContain(%v, %q) = %v, want %v", tags, tt.query, got, tt.want)
+			}
+		})
+	}
+
+	if !TagsContain([]string{"Web Search"}, "web-search") {
+		t.Error("TagsContain() should normalize stored tags too")
+	}
+}
+
+func equalTestTool() *Tool {
+	destructive := false
+	return &Tool{
+		Tool: mcp.Tool{
+			Name:        "search",
+			Title:       "Search",
+			Description: "Search documents",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{"type": "string", "maxLength": 100},
+				},
+			},
+			Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true, DestructiveHint: &destructive},
+			Meta:        mcp.Meta{"owner": "search-team", "priority": 1},
+		},
+		Namespace: "docs",
+		Version:   "1.0.0",
+		Tags:      []string{"search", "docs"},
+	}
+}
+
+func TestTool_Equal(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Tool)
+		want   bool
+	}{
+		{"identical", func(*Tool) {}, true},
+		{"tags reordered", func(tool *Tool) { tool.Tags = []string{"docs", "search"} }, true},
+		{"annotations copied", func(tool *Tool) {
+			destructive := false
+			tool.Annotations = &mcp.ToolAnnotations{ReadOnlyHint: true, DestructiveHint: &destructive}
+		}, true},
+		{"schema as raw JSON", func(tool *Tool) {
+			tool.InputSchema = json.RawMessage(`{"properties":{"query":{"maxLength":100,"type":"string"}},"type":"object"}`)
+		}, true},
+		{"meta number type", func(tool *Tool) { tool.Meta["priority"] = 1.0 }, true},
+		{"name", func(tool *Tool) { tool.Name = "find" }, false},
+		{"title", func(tool *Tool) { tool.Title = "Find" }, false},
+		{"description", func(tool *Tool) { tool.Description = "Find documents" }, false},
+		{"namespace", func(tool *Tool) { tool.Namespace = "web" }, false},
+		{"version", func(tool *Tool) { tool.Version = "1.0.1" }, false},
+		{"tags", func(tool *Tool) { tool.Tags = []string{"search"} }, false},
+		{"tags duplicated", func(tool *Tool) { tool.Tags = []string{"search", "search"} }, false},
+		{"annotations nil", func(tool *Tool) { tool.Annotations = nil }, false},
+		{"destructive hint", func(tool *Tool) {
+			destructive := true
+			tool.Annotations.DestructiveHint = &destructive
+		}, false},
+		{"destructive hint unset", func(tool *Tool) { tool.Annotations.DestructiveHint = nil }, false},
+		{"meta", func(tool *Tool) { tool.Meta["owner"] = "other" }, false},
+		{"input schema", func(tool *Tool) {
+			tool.InputSchema.(map[string]any)["required"] = []any{"query"}
+		}, false},
+		{"output schema", func(tool *Tool) { tool.OutputSchema = map[string]any{"type": "object"} }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := equalTestTool(), equalTestTool()
+			tt.modify(b)
+			if got := a.Equal(b); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+			if got := b.Equal(a); got != tt.want {
+				t.Errorf("Equal() reversed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTool_Equal_Nil(t *testing.T) {
+	var a, b *Tool
+	if !a.Equal(b) {
+		t.Error("nil.Equal(nil) = false, want true")
+	}
+	tool := equalTestTool()
+	if tool.Equal(nil) || a.Equal(tool) {
+		t.Error("Equal() between nil and non-nil tool = true, want false")
+	}
+}
+
+func TestTool_Equal_Clone(t *testing.T) {
+	tool := equalTestTool()
+	if !tool.Equal(tool.Clone()) {
+		t.Error("tool.Equal(tool.Clone()) = false, want true")
+	}
+}
+
+func TestTool_Equal_EmptyMetaAndTags(t *testing.T) {
+	a := &Tool{Tool: mcp.Tool{Name: "t"}}
+	b := &Tool{Tool: mcp.Tool{Name: "t", Meta: mcp.Meta{}}, Tags: []string{}}
+	if !a.Equal(b) {
+		t.Error("nil and empty Meta/Tags should be equal")
+	}
+}
+
+func TestTool_Merge_TagUnion(t *testing.T) {
+	base := &Tool{Tool: mcp.Tool{Name: "search"}, Tags: []string{"search", "Docs"}}
+	overlay := &Tool{Tags: []string{"docs", "Full Text"}}
+
+	merged := base.Merge(overlay)
+	want := []string{"search", "docs", "full-text"}
+	if !slices.Equal(merged.Tags, want) {
+		t.Errorf("Merge() tags = %v, want %v", merged.Tags, want)
+	}
+	if !slices.Equal(base.Tags, []string{"search", "Docs"}) {
+		t.Errorf("base tags mutated: %v", base.Tags)
+	}
+	if !slices.Equal(overlay.Tags, []string{"docs", "Full Text"}) {
+		t.Errorf("overlay tags mutated: %v", overlay.Tags)
+	}
+}
+
+func TestTool_Merge_MetaOverride(t *testing.T) {
+	base := &Tool{Tool: mcp.Tool{Name: "search", Meta: mcp.Meta{"owner": "base", "tier": "gold"}}}
+	overlay := &Tool{Tool: mcp.Tool{Meta: mcp.Meta{"owner": "overlay", "region": "eu"}}}
+
+	merged := base.Merge(overlay)
+	want := mcp.Meta{"owner": "overlay", "tier": "gold", "region": "eu"}
+	if len(merged.Meta) != len(want) {
+		t.Fatalf("Merge() meta = %v, want %v", merged.Meta, want)
+	}
+	for k, v := range want {
+		if merged.Meta[k] != v {
+			t.Errorf("Merge() meta[%q] = %v, want %v", k, merged.Meta[k], v)
+		}
+	}
+	if base.Meta["owner"] != "base" || len(base.Meta) != 2 {
+		t.Errorf("base meta mutated: %v", base.Meta)
+	}
+	if len(overlay.Meta) != 2 {
+		t.Errorf("overlay meta mutated: %v", overlay.Meta)
+	}
+}
+
+func TestTool_Merge_Fields(t *testing.T) {
+	base := &Tool{
+		Tool: mcp.Tool{
+			Name:        "search",
+			Title:       "Search",
+			Description: "Search documents",
+			InputSchema: map[string]any{"type": "object"},
+			Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+		},
+		Namespace: "docs",
+		Version:   "1.0.0",
+	}
+	overlay := &Tool{
+		Tool: mcp.Tool{
+			Description: "Search all documents",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []any{"query"},
+			},
+		},
+		Version: "1.1.0",
+	}
+
+	merged := base.Merge(overlay)
+	if merged.Name != "search" || merged.Title != "Search" || merged.Namespace != "docs" {
+		t.Errorf("Merge() overwrote fields with empty overlay values: %+v", merged)
+	}
+	if merged.Description != "Search all documents" || merged.Version != "1.1.0" {
+		t.Errorf("Merge() did not apply overlay scalars: %+v", merged)
+	}
+	if _, ok := merged.InputSchema.(map[string]any)["required"]; !ok {
+		t.Error("Merge() should replace InputSchema with overlay schema")
+	}
+	if merged.Annotations == nil || !merged.Annotations.ReadOnlyHint {
+		t.Error("Merge() should keep base annotations when overlay has none")
+	}
+	if merged.Annotations == base.Annotations {
+		t.Error("Merge() should not share annotations with base")
+	}
+
+	merged.InputSchema.(map[string]any)["type"] = "array"
+	if overlay.InputSchema.(map[string]any)["type"] != "object" {
+		t.Error("Merge() result shares InputSchema with overlay")
+	}
+	if base.Description != "Search documents" || base.Version != "1.0.0" {
+		t.Errorf("base mutated: %+v", base)
+	}
+}
+
+func TestTool_Merge_Nil(t *testing.T) {
+	base := &Tool{Tool: mcp.Tool{Name: "search"}, Tags: []string{"docs"}}
+	if merged := base.Merge(nil); !merged.Equal(base) || merged == base {
+		t.Errorf("Merge(nil) = %+v, want independent copy of base", merged)
+	}
+	if merged := (*Tool)(nil).Merge(base); !merged.Equal(base) {
+		t.Errorf("nil.Merge(overlay) = %+v, want overlay", merged)
+	}
+}
+
+func TestTool_Fingerprint_PropertyOrder(t *testing.T) {
+	// Decode from JSON so the two schemas are built in different insertion orders.
+	a, err := FromJSON([]byte(`{"name":"search","inputSchema":{"type":"object","properties":{"query":{"type":"string"},"limit":{"type":"integer","maximum":50}}}}`))
+	if err != nil {
+		t.Fatalf("FromJSON() error = %v", err)
+	}
+	b := &Tool{Tool: mcp.Tool{Name: "search"}}
+	props := map[string]any{}
+	props["limit"] = map[string]any{"maximum": 50, "type": "integer"}
+	props["query"] = map[string]any{"type": "string"}
+	b.InputSchema = map[string]any{"properties": props, "type": "object"}
+
+	fa, fb := a.Fingerprint(), b.Fingerprint()
+	if fa != fb {
+		t.Errorf("Fingerprint() differs for reordered properties: %s vs %s", fa, fb)
+	}
+	if len(fa) != 64 {
+		t.Errorf("Fingerprint() = %q, want 64 hex chars", fa)
+	}
+}
+
+func TestTool_Fingerprint_Fields(t *testing.T) {
+	base := func() *Tool {
+		return &Tool{
+			Tool: mcp.Tool{
+				Name:        "search",
+				Description: "Search documents",
+				InputSchema: map[string]any{"type": "object"},
+				Meta:        mcp.Meta{"traceId": "abc"},
+			},
+			Namespace: "docs",
+			Version:   "1.0.0",
+			Tags:      []string{"search"},
+		}
+	}
+	want := base().Fingerprint()
+
+	tests := []struct {
+		name    string
+		modify  func(*Tool)
+		changed bool
+	}{
+		{"trace id", func(tool *Tool) { tool.Meta["traceId"] = "def" }, false},
+		{"meta", func(tool *Tool) { tool.Meta = nil }, false},
+		{"version", func(tool *Tool) { tool.Version = "2.0.0" }, false},
+		{"namespace", func(tool *Tool) { tool.Namespace = "" }, false},
+		{"tags", func(tool *Tool) { tool.Tags = nil }, false},
+		{"name", func(tool *Tool) { tool.Name = "find" }, true},
+		{"title", func(tool *Tool) { tool.Title = "Search" }, true},
+		{"description", func(tool *Tool) { tool.Description = "Find documents" }, true},
+		{"input schema", func(tool *Tool) { tool.InputSchema = map[string]any{"type": "object", "required": []any{"q"}} }, true},
+		{"output schema", func(tool *Tool) { tool.OutputSchema = map[string]any{"type": "object"} }, true},
+		{"annotations", func(tool *Tool) { tool.Annotations = &mcp.ToolAnnotations{ReadOnlyHint: true} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := base()
+			tt.modify(tool)
+			if got := tool.Fingerprint(); (got != want) != tt.changed {
+				t.Errorf("Fingerprint() changed = %v, want %v", got != want, tt.changed)
+			}
+		})
+	}
+}
+
+func TestTool_Fingerprint_Nil(t *testing.T) {
+	if got := (*Tool)(nil).Fingerprint(); got != "" {
+		t.Errorf("nil.Fingerprint() = %q, want empty", got)
+	}
+}
+
+func TestTool_AddBackend(t *testing.T) {
+	tool := &Tool{Tool: mcp.Tool{Name: "search"}}
+
+	if err := tool.AddBackend(ToolBackend{Kind: BackendKindMCP}); !errors.Is(err, ErrInvalidBackend) {
+		t.Errorf("AddBackend(invalid) error = %v, want ErrInvalidBackend", err)
+	}
+	if len(tool.Backends) != 0 {
+		t.Fatalf("AddBackend(invalid) appended backend: %v", tool.Backends)
+	}
+
+	if err := tool.AddBackend(NewProviderBackend("acme", "search")); err != nil {
+		t.Fatalf("AddBackend() error = %v", err)
+	}
+	if err := tool.AddBackend(NewLocalBackend("search_handler")); err != nil {
+		t.Fatalf("AddBackend() error = %v", err)
+	}
+	if len(tool.Backends) != 2 {
+		t.Errorf("Backends = %v, want 2 entries", tool.Backends)
+	}
+}
+
+func TestTool_PrimaryBackend(t *testing.T) {
+	tool := &Tool{Tool: mcp.Tool{Name: "search"}}
+	if _, ok := tool.PrimaryBackend(); ok {
+		t.Error("PrimaryBackend() = true for tool without backends")
+	}
+
+	// Backends set directly may be invalid; PrimaryBackend skips them.
+	tool.Backends = []ToolBackend{
+		{Kind: BackendKindLocal},
+		NewMCPBackend("docs-server"),
+		NewLocalBackend("search_handler"),
+	}
+	b, ok := tool.PrimaryBackend()
+	if !ok || b.Kind != BackendKindMCP || b.MCP.ServerName != "docs-server" {
+		t.Errorf("PrimaryBackend() = %+v, %v, want docs-server MCP backend", b, ok)
+	}
+
+	tool.Backends = []ToolBackend{{Kind: "unknown"}}
+	if _, ok := tool.PrimaryBackend(); ok {
+		t.Error("PrimaryBackend() = true with only invalid backends")
+	}
+}
+
+func TestTool_Clone_Backends(t *testing.T) {
+	tool := &Tool{Tool: mcp.Tool{Name: "search"}}
+	_ = tool.AddBackend(NewMCPBackend("docs-server"))
+	_ = tool.AddBackend(NewProviderBackend("acme", "search"))
+
+	clone := tool.Clone()
+	clone.Backends[0].MCP.ServerName = "other"
+	clone.Backends[1].Provider.ToolID = "other"
+	clone.Backends = append(clone.Backends, NewLocalBackend("x"))
+
+	if tool.Backends[0].MCP.ServerName != "docs-server" || tool.Backends[1].Provider.ToolID != "search" {
+		t.Errorf("Clone() shares backend pointers: %+v", tool.Backends)
+	}
+	if len(tool.Backends) != 2 {
+		t.Errorf("Clone() shares backends slice: %v", tool.Backends)
+	}
+}
+
+func TestTool_Backends_JSON(t *testing.T) {
+	tool := &Tool{Tool: mcp.Tool{Name: "search", InputSchema: map[string]any{"type": "object"}}}
+	_ = tool.AddBackend(NewMCPBackend("docs-server"))
+
+	mcpJSON, err := tool.ToMCPJSON()
+	if err != nil {
+		t.Fatalf("ToMCPJSON() error = %v", err)
+	}
+	if strings.Contains(string(mcpJSON), "backends") {
+		t.Errorf("ToMCPJSON() = %s, should not include backends", mcpJSON)
+	}
+
+	data, err := tool.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+	decoded, err := FromJSON(data)
+	if err != nil {
+		t.Fatalf("FromJSON() error = %v", err)
+	}
+	if len(decoded.Backends) != 1 || decoded.Backends[0].MCP.ServerName != "docs-server" {
+		t.Errorf("round trip backends = %+v", decoded.Backends)
+	}
+	if !decoded.Equal(tool) {
+		t.Error("round-tripped tool should equal original")
+	}
+	decoded.Backends = nil
+	if decoded.Equal(tool) {
+		t.Error("Equal() should compare backends")
+	}
+}
+
+func TestFromMCPJSONArray(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"bare array", `[{"name":"a","inputSchema":{"type":"object"}},{"name":"b","inputSchema":{"type":"object"}}]`, []string{"a", "b"}},
+		{"tools/list result", `{"tools":[{"name":"search","inputSchema":{"type":"object"}}],"nextCursor":"abc"}`, []string{"search"}},
+		{"leading whitespace", "\n  {\"tools\": []}", []string{}},
+		{"empty array", `[]`, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tools, err := FromMCPJSONArray([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("FromMCPJSONArray() error = %v", err)
+			}
+			names := make([]string, len(tools))
+			for i, tool := range tools {
+				names[i] = tool.Name
+				if tool.Namespace != "" || tool.Version != "" {
+					t.Errorf("tool %q has namespace/version set", tool.Name)
+				}
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("FromMCPJSONArray() names = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromMCPJSONArray_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+		wantMsg string
+	}{
+		{"missing name", `[{"name":"a"},{"description":"no name"}]`, ErrInvalidTool, "tool 1"},
+		{"missing tools key", `{"items":[]}`, ErrInvalidTool, "missing tools"},
+		{"malformed", `[{"name":`, nil, ""},
+		{"wrong type", `"tools"`, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromMCPJSONArray([]byte(tt.input))
+			if err == nil {
+				t.Fatal("FromMCPJSONArray() error = nil, want error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromMCPJSONArray() error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("FromMCPJSONArray() error = %q, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestToMCPJSONArray_RoundTrip(t *testing.T) {
+	tools := []*Tool{
+		{Tool: mcp.Tool{Name: "search", InputSchema: map[string]any{"type": "object"}}, Namespace: "docs", Version: "1.0.0", Tags: []string{"read"}},
+		{Tool: mcp.Tool{Name: "fetch", Description: "Fetch a URL", InputSchema: map[string]any{"type": "object"}}},
+	}
+
+	data, err := ToMCPJSONArray(tools)
+	if err != nil {
+		t.Fatalf("ToMCPJSONArray() error = %v", err)
+	}
+	for _, field := range []string{"namespace", "version", "tags"} {
+		if strings.Contains(string(data), `"`+field+`"`) {
+			t.Errorf("ToMCPJSONArray() = %s, should strip %s", data, field)
+		}
+	}
+
+	parsed, err := FromMCPJSONArray(data)
+	if err != nil {
+		t.Fatalf("FromMCPJSONArray() error = %v", err)
+	}
+	if len(parsed) != len(tools) {
+		t.Fatalf("round trip count = %d, want %d", len(parsed), len(tools))
+	}
+	for i := range tools {
+		if parsed[i].Name != tools[i].Name || parsed[i].Description != tools[i].Description {
+			t.Errorf("tool %d = %q, want %q", i, parsed[i].Name, tools[i].Name)
+		}
+	}
+}
+
+func TestToMCPJSONArray_Empty(t *testing.T) {
+	data, err := ToMCPJSONArray(nil)
+	if err != nil {
+		t.Fatalf("ToMCPJSONArray(nil) error = %v", err)
+	}
+	if string(data) != `{"tools":[]}` {
+		t.Errorf("ToMCPJSONArray(nil) = %s, want {\"tools\":[]}", data)
+	}
+	if _, err := ToMCPJSONArray([]*Tool{nil}); !errors.Is(err, ErrInvalidTool) {
+		t.Errorf("ToMCPJSONArray([nil]) error = %v, want ErrInvalidTool", err)
+	}
+}
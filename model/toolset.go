@@ -0,0 +1,266 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// ErrDuplicateTool is returned when a tool with the same ToolID is already in a ToolSet.
+var ErrDuplicateTool = errors.New("duplicate tool")
+
+// ToolSet is a collection of tools indexed by ToolID, namespace, and tag.
+// Every method that returns tools returns copies, so modifying a result
+// does not change the set or its indexes.
+// ToolSet is safe for concurrent use by multiple goroutines.
+type ToolSet struct {
+	mu          sync.RWMutex
+	tools       map[string]*Tool
+	byNamespace map[string]map[string]*Tool
+	byTag       map[string]map[string]*Tool
+}
+
+// NewToolSet creates an empty ToolSet.
+func NewToolSet() *ToolSet {
+	return &ToolSet{
+		tools:       make(map[string]*Tool),
+		byNamespace: make(map[string]map[string]*Tool),
+		byTag:       make(map[string]map[string]*Tool),
+	}
+}
+
+// Add validates the tool and inserts a copy with tags normalized via
+// NormalizeTags. It returns ErrDuplicateTool if a tool with the same ToolID
+// is already present, or the Validate error if the tool is invalid.
+// The caller's tool is not modified.
+func (s *ToolSet) Add(t *Tool) error {
+	if t == nil {
+		return fmt.Errorf("%w: tool is nil", ErrInvalidTool)
+	}
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	tool := t.Clone()
+	tool.Tags = NormalizeTags(tool.Tags)
+	id := tool.ToolID()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.tools[id]; exists {
+		return fmt.Errorf("%w: %s", ErrDuplicateTool, id)
+	}
+	s.tools[id] = tool
+	addToIndex(s.byNamespace, tool.Namespace, id, tool)
+	for _, tag := range tool.Tags {
+		addToIndex(s.byTag, tag, id, tool)
+	}
+	return nil
+}
+
+// Get returns a copy of the tool with the given ToolID.
+func (s *ToolSet) Get(id string) (*Tool, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tool, ok := s.tools[id]
+	return tool.Clone(), ok
+}
+
+// Remove deletes the tool with the given ToolID, if present.
+func (s *ToolSet) Remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tool, ok := s.tools[id]
+	if !ok {
+		return
+	}
+	delete(s.tools, id)
+	removeFromIndex(s.byNamespace, tool.Namespace, id)
+	for _, tag := range tool.Tags {
+		removeFromIndex(s.byTag, tag, id)
+	}
+}
+
+// Len returns the number of tools in the set.
+func (s *ToolSet) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.tools)
+}
+
+// ByNamespace returns the tools in the given namespace, sorted by ToolID.
+// Use "" for tools without a namespace.
+func (s *ToolSet) ByNamespace(ns string) []*Tool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return sortedTools(s.byNamespace[ns])
+}
+
+// ByTag returns the tools carrying the given tag, sorted by ToolID.
+// The tag is normalized with NormalizeTag before lookup.
+func (s *ToolSet) ByTag(tag string) []*Tool {
+	normalized, ok := NormalizeTag(tag)
+	if !ok {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return sortedTools(s.byTag[normalized])
+}
+
+// Versions returns every tool with the given namespace and name, ordered by
+// ParsedVersion from highest to lowest. Tools whose version is empty or
+// cannot be parsed sort last, ordered by ToolID.
+func (s *ToolSet) Versions(namespace, name string) []*Tool {
+	s.mu.RLock()
+	matches := make(map[string]*Tool)
+	for id, tool := range s.byNamespace[namespace] {
+		if tool.Name == name {
+			matches[id] = tool
+		}
+	}
+	s.mu.RUnlock()
+
+	tools := sortedTools(matches)
+	slices.SortStableFunc(tools, compareToolVersionsDesc)
+	return tools
+}
+
+// Latest returns the highest-version tool with the given namespace and name,
+// as ordered by Versions, or false if the set has none.
+func (s *ToolSet) Latest(namespace, name string) (*Tool, bool) {
+	tools := s.Versions(namespace, name)
+	if len(tools) == 0 {
+		return nil, false
+	}
+	return tools[0], true
+}
+
+// compareToolVersionsDesc orders tools by descending parsed version, placing
+// tools without a parseable version last.
+func compareToolVersionsDesc(a, b *Tool) int {
+	va, errA := a.ParsedVersion()
+	vb, errB := b.ParsedVersion()
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	}
+	return vb.Compare(va)
+}
+
+// TagQuery selects tools by tag. All lists are normalized with NormalizeTags
+// before matching; an empty list imposes no condition. A tag that normalizes
+// to nothing, such as "@@", is carried by no tool: it fails AllOf, never
+// satisfies AnyOf, and excludes nothing in NoneOf.
+type TagQuery struct {
+	// AllOf requires every listed tag.
+	AllOf []string
+	// AnyOf requires at least one listed tag.
+	AnyOf []string
+	// NoneOf excludes tools carrying any listed tag.
+	NoneOf []string
+}
+
+// Query returns the tools matching q, sorted by ToolID.
+// An empty query matches every tool.
+func (s *ToolSet) Query(q TagQuery) []*Tool {
+	allOf, allOfEmptied := queryTags(q.AllOf)
+	anyOf, anyOfEmptied := queryTags(q.AnyOf)
+	noneOf := NormalizeTags(q.NoneOf)
+	if allOfEmptied || (anyOfEmptied && len(anyOf) == 0) {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matches := make(map[string]*Tool)
+	for id, tool := range s.tools {
+		if s.matchesLocked(id, allOf, anyOf, noneOf) {
+			matches[id] = tool
+		}
+	}
+	return sortedTools(matches)
+}
+
+// queryTags normalizes a TagQuery list with NormalizeTags and reports
+// whether any tag normalized to nothing.
+func queryTags(tags []string) ([]string, bool) {
+	emptied := false
+	for _, tag := range tags {
+		if _, ok := NormalizeTag(tag); !ok {
+			emptied = true
+			break
+		}
+	}
+	return NormalizeTags(tags), emptied
+}
+
+// matchesLocked reports whether the tool with the given ID satisfies the
+// normalized tag conditions. The lock must be held.
+func (s *ToolSet) matchesLocked(id string, allOf, anyOf, noneOf []string) bool {
+	for _, tag := range allOf {
+		if _, ok := s.byTag[tag][id]; !ok {
+			return false
+		}
+	}
+	for _, tag := range noneOf {
+		if _, ok := s.byTag[tag][id]; ok {
+			return false
+		}
+	}
+	if len(anyOf) == 0 {
+		return true
+	}
+	for _, tag := range anyOf {
+		if _, ok := s.byTag[tag][id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func addToIndex(index map[string]map[string]*Tool, key, id string, tool *Tool) {
+	bucket, ok := index[key]
+	if !ok {
+		bucket = make(map[string]*Tool)
+		index[key] = bucket
+	}
+	bucket[id] = tool
+}
+
+func removeFromIndex(index map[string]map[string]*Tool, key, id string) {
+	bucket := index[key]
+	delete(bucket, id)
+	if len(bucket) == 0 {
+		delete(index, key)
+	}
+}
+
+// sortedTools returns copies of the tools in bucket ordered by ToolID.
+func sortedTools(bucket map[string]*Tool) []*Tool {
+	if len(bucket) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(bucket))
+	for id := range bucket {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	tools := make([]*Tool, len(ids))
+	for i, id := range ids {
+		tools[i] = bucket[id].Clone()
+	}
+	return tools
+}
@@ -0,0 +1,269 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func toolSetTestTool(namespace, name string, tags ...string) *Tool {
+	return &Tool{
+		Tool: mcp.Tool{
+			Name:        name,
+			InputSchema: map[string]any{"type": "object"},
+		},
+		Namespace: namespace,
+		Tags:      tags,
+	}
+}
+
+func toolIDs(tools []*Tool) []string {
+	ids := make([]string, len(tools))
+	for i, tool := range tools {
+		ids[i] = tool.ToolID()
+	}
+	return ids
+}
+
+func TestToolSet_AddGet(t *testing.T) {
+	s := NewToolSet()
+	tool := toolSetTestTool("docs", "search", "Full Text", "search")
+
+	if err := s.Add(tool); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	got, ok := s.Get("docs:search")
+	if !ok {
+		t.Fatal("Get() did not find added tool")
+	}
+	if want := []string{"full-text", "search"}; !slices.Equal(got.Tags, want) {
+		t.Errorf("stored tags = %v, want %v", got.Tags, want)
+	}
+	if !slices.Equal(tool.Tags, []string{"Full Text", "search"}) {
+		t.Errorf("Add() mutated caller tags: %v", tool.Tags)
+	}
+	if s.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", s.Len())
+	}
+	if _, ok := s.Get("docs:missing"); ok {
+		t.Error("Get() found a tool that was never added")
+	}
+}
+
+func TestToolSet_Add_Duplicate(t *testing.T) {
+	s := NewToolSet()
+	if err := s.Add(toolSetTestTool("docs", "search")); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	err := s.Add(toolSetTestTool("docs", "search", "other"))
+	if !errors.Is(err, ErrDuplicateTool) {
+		t.Errorf("Add() duplicate error = %v, want ErrDuplicateTool", err)
+	}
+	if err := s.Add(toolSetTestTool("web", "search")); err != nil {
+		t.Errorf("Add() same name in another namespace error = %v", err)
+	}
+}
+
+func TestToolSet_Add_Invalid(t *testing.T) {
+	s := NewToolSet()
+	for _, tool := range []*Tool{nil, {Tool: mcp.Tool{Name: "no-schema"}}, toolSetTestTool("", "bad name")} {
+		if err := s.Add(tool); !errors.Is(err, ErrInvalidTool) {
+			t.Errorf("Add(%v) error = %v, want ErrInvalidTool", tool, err)
+		}
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len() = %d, want 0 after invalid adds", s.Len())
+	}
+}
+
+func TestToolSet_ByNamespaceAndTag(t *testing.T) {
+	s := NewToolSet()
+	for _, tool := range []*Tool{
+		toolSetTestTool("docs", "search", "read"),
+		toolSetTestTool("docs", "delete", "write"),
+		toolSetTestTool("web", "fetch", "read"),
+		toolSetTestTool("", "echo"),
+	} {
+		if err := s.Add(tool); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	if got, want := toolIDs(s.ByNamespace("docs")), []string{"docs:delete", "docs:search"}; !slices.Equal(got, want) {
+		t.Errorf("ByNamespace(docs) = %v, want %v", got, want)
+	}
+	if got, want := toolIDs(s.ByNamespace("")), []string{"echo"}; !slices.Equal(got, want) {
+		t.Errorf("ByNamespace(\"\") = %v, want %v", got, want)
+	}
+	if got, want := toolIDs(s.ByTag("READ")), []string{"docs:search", "web:fetch"}; !slices.Equal(got, want) {
+		t.Errorf("ByTag(READ) = %v, want %v", got, want)
+	}
+	if got := s.ByTag("missing"); len(got) != 0 {
+		t.Errorf("ByTag(missing) = %v, want none", toolIDs(got))
+	}
+	if got := s.ByTag("  "); got != nil {
+		t.Errorf("ByTag(blank) = %v, want nil", toolIDs(got))
+	}
+}
+
+func TestToolSet_Remove(t *testing.T) {
+	s := NewToolSet()
+	_ = s.Add(toolSetTestTool("docs", "search", "read"))
+	_ = s.Add(toolSetTestTool("docs", "list", "read"))
+
+	s.Remove("docs:search")
+	s.Remove("docs:missing")
+
+	if _, ok := s.Get("docs:search"); ok {
+		t.Error("Get() found removed tool")
+	}
+	if got, want := toolIDs(s.ByTag("read")), []string{"docs:list"}; !slices.Equal(got, want) {
+		t.Errorf("ByTag(read) = %v, want %v", got, want)
+	}
+	if got, want := toolIDs(s.ByNamespace("docs")), []string{"docs:list"}; !slices.Equal(got, want) {
+		t.Errorf("ByNamespace(docs) = %v, want %v", got, want)
+	}
+
+	s.Remove("docs:list")
+	if len(s.byTag) != 0 || len(s.byNamespace) != 0 {
+		t.Errorf("indexes not cleaned up: byTag=%v byNamespace=%v", s.byTag, s.byNamespace)
+	}
+	if err := s.Add(toolSetTestTool("docs", "search")); err != nil {
+		t.Errorf("Add() after Remove error = %v", err)
+	}
+}
+
+func TestToolSet_ConcurrentAccess(t *testing.T) {
+	s := NewToolSet()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			name := "tool-" + string(rune('a'+id))
+			for j := 0; j < 100; j++ {
+				_ = s.Add(toolSetTestTool("ns", name, "shared"))
+				_, _ = s.Get("ns:" + name)
+				_ = s.ByTag("shared")
+				_ = s.ByNamespace("ns")
+				s.Remove("ns:" + name)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestToolSet_Query(t *testing.T) {
+	s := NewToolSet()
+	for _, tool := range []*Tool{
+		toolSetTestTool("docs", "search", "read", "search"),
+		toolSetTestTool("docs", "delete", "write", "dangerous"),
+		toolSetTestTool("web", "fetch", "read", "network"),
+		toolSetTestTool("web", "post", "write", "network"),
+		toolSetTestTool("", "echo"),
+	} {
+		if err := s.Add(tool); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query TagQuery
+		want  []string
+	}{
+		{"empty", TagQuery{}, []string{"docs:delete", "docs:search", "echo", "web:fetch", "web:post"}},
+		{"all of", TagQuery{AllOf: []string{"read", "network"}}, []string{"web:fetch"}},
+		{"any of", TagQuery{AnyOf: []string{"search", "dangerous"}}, []string{"docs:delete", "docs:search"}},
+		{"none of", TagQuery{NoneOf: []string{"network"}}, []string{"docs:delete", "docs:search", "echo"}},
+		{"combined", TagQuery{AllOf: []string{"network"}, AnyOf: []string{"read", "write"}, NoneOf: []string{"write"}}, []string{"web:fetch"}},
+		{"normalized", TagQuery{AllOf: []string{"  READ "}}, []string{"docs:search", "web:fetch"}},
+		{"no match", TagQuery{AllOf: []string{"read", "write"}}, nil},
+		{"unknown any of", TagQuery{AnyOf: []string{"missing"}}, nil},
+		{"empty-normalizing all of", TagQuery{AllOf: []string{"@@"}}, nil},
+		{"empty-normalizing all of with valid tag", TagQuery{AllOf: []string{"read", "@@"}}, nil},
+		{"empty-normalizing any of", TagQuery{AnyOf: []string{"@@"}}, nil},
+		{"empty-normalizing any of with valid tag", TagQuery{AnyOf: []string{"@@", "dangerous"}}, []string{"docs:delete"}},
+		{"empty-normalizing none of", TagQuery{NoneOf: []string{"@@"}}, []string{"docs:delete", "docs:search", "echo", "web:fetch", "web:post"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toolIDs(s.Query(tt.query)); !slices.Equal(got, tt.want) {
+				t.Errorf("Query(%+v) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolSet_ReturnsCopies(t *testing.T) {
+	s := NewToolSet()
+	if err := s.Add(toolSetTestTool("docs", "search", "read")); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	results := map[string]func() *Tool{
+		"Get": func() *Tool {
+			tool, _ := s.Get("docs:search")
+			return tool
+		},
+		"ByNamespace": func() *Tool { return s.ByNamespace("docs")[0] },
+		"ByTag":       func() *Tool { return s.ByTag("read")[0] },
+		"Versions":    func() *Tool { return s.Versions("docs", "search")[0] },
+		"Latest": func() *Tool {
+			tool, _ := s.Latest("docs", "search")
+			return tool
+		},
+		"Query": func() *Tool { return s.Query(TagQuery{})[0] },
+	}
+	for name, get := range results {
+		t.Run(name, func(t *testing.T) {
+			tool := get()
+			tool.Description = "changed"
+			tool.Tags[0] = "write"
+
+			again, _ := s.Get("docs:search")
+			if again.Description != "" || again.Tags[0] != "read" {
+				t.Errorf("modifying the %s result changed the set: %+v", name, again)
+			}
+			if got := toolIDs(s.ByTag("read")); !slices.Equal(got, []string{"docs:search"}) {
+				t.Errorf("ByTag(read) = %v, want [docs:search]", got)
+			}
+		})
+	}
+}
+
+func TestToolSet_Versions(t *testing.T) {
+	s := NewToolSet()
+	for _, v := range []string{"1.0.0", "", "1.2.0", "latest"} {
+		tool := toolSetTestTool("docs", "search")
+		tool.Version = v
+		if err := s.Add(tool); err != nil {
+			t.Fatalf("Add(%q) error = %v", v, err)
+		}
+	}
+	other := toolSetTestTool("docs", "fetch")
+	other.Version = "9.0.0"
+	if err := s.Add(other); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	want := []string{"docs:search:1.2.0", "docs:search:1.0.0", "docs:search", "docs:search:latest"}
+	if got := toolIDs(s.Versions("docs", "search")); !slices.Equal(got, want) {
+		t.Errorf("Versions() = %v, want %v", got, want)
+	}
+	if got := s.Versions("other", "search"); len(got) != 0 {
+		t.Errorf("Versions(other namespace) = %v, want none", toolIDs(got))
+	}
+
+	latest, ok := s.Latest("docs", "search")
+	if !ok || latest.Version != "1.2.0" {
+		t.Errorf("Latest() = %v, %v, want version 1.2.0", latest, ok)
+	}
+	if _, ok := s.Latest("docs", "missing"); ok {
+		t.Error("Latest() found a tool that was never added")
+	}
+}
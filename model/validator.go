@@ -122,6 +122,17 @@ func (v *DefaultValidator) ValidateOutput(tool *Tool, result any) error {
 	return v.Validate(tool.OutputSchema, result)
 }
 
+// ValidateOutput checks an example output value against the tool's
+// OutputSchema using DefaultValidator, catching drift between the declared
+// output contract and documented examples. It returns nil when the value is
+// valid or the tool declares no OutputSchema.
+func (t *Tool) ValidateOutput(value any) []error {
+	if err := NewDefaultValidator().ValidateOutput(t, value); err != nil {
+		return []error{err}
+	}
+	return nil
+}
+
 // toJSONSchema converts various schema representations to jsonschema.Schema.
 func (v *DefaultValidator) toJSONSchema(schema any) (*jsonschema.Schema, error) {
 	switch s := schema.(type) {
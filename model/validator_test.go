@@ -472,6 +472,34 @@ func TestDefaultValidator_ValidateOutput(t *testing.T) {
 	})
 }
 
+func TestTool_ValidateOutput(t *testing.T) {
+	tool := &Tool{
+		Tool: mcp.Tool{
+			Name:        "weather",
+			InputSchema: map[string]any{"type": "object"},
+			OutputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"tempC": map[string]any{"type": "number"},
+				},
+				"required": []any{"tempC"},
+			},
+		},
+	}
+
+	if errs := tool.ValidateOutput(map[string]any{"tempC": 21.5}); errs != nil {
+		t.Errorf("ValidateOutput(valid) = %v, want nil", errs)
+	}
+	if errs := tool.ValidateOutput(map[string]any{"tempF": 70}); len(errs) == 0 {
+		t.Error("ValidateOutput(invalid) = nil, want errors")
+	}
+
+	tool.OutputSchema = nil
+	if errs := tool.ValidateOutput("anything"); errs != nil {
+		t.Errorf("ValidateOutput() without OutputSchema = %v, want nil", errs)
+	}
+}
+
 func TestDefaultValidator_ExternalRefBlocked(t *testing.T) {
 	v := NewDefaultValidator()
 
@@ -1,109 +1,180 @@
 package version
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 )
 
 // Compatibility represents version compatibility between components.
+//
+// When Constraint is set it decides compatibility and MinVersion and
+// MaxVersion are ignored.
 type Compatibility struct {
-	Component  string
-	MinVersion Version
-	MaxVersion *Version // nil means no upper bound
-	Deprecated bool
-	Message    string
+	Component  string         `json:"component"`
+	MinVersion Version        `json:"minVersion"`
+	MaxVersion *Version       `json:"maxVersion,omitempty"` // nil means no upper bound
+	Constraint ConstraintExpr `json:"constraint,omitempty"` // e.g. "^1.2.0 || ^2.0.0"
+	Deprecated bool           `json:"deprecated,omitempty"`
+	Message    string         `json:"message,omitempty"`
 }
 
 // Matrix holds compatibility information for multiple components.
 // Matrix is safe for concurrent use by multiple goroutines.
 type Matrix struct {
 	mu      sync.RWMutex
-	entries map[string][]Compatibility
+	entries map[string]Compatibility
 }
 
 // NewMatrix creates a new compatibility matrix.
 func NewMatrix() *Matrix {
 	return &Matrix{
-		entries: make(map[string][]Compatibility),
+		entries: make(map[string]Compatibility),
 	}
 }
 
-// Add adds a compatibility entry for a component.
+// Add adds a compatibility entry for a component, replacing any existing
+// entry for the same component.
 // Add is safe for concurrent use.
 func (m *Matrix) Add(comp Compatibility) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.entries[comp.Component] = append(m.entries[comp.Component], comp)
+	m.entries[comp.Component] = comp
 }
 
-// Check checks if a version is compatible for a component.
-// Check is safe for concurrent use.
-func (m *Matrix) Check(component string, v Version) (bool, string) {
+// Remove deletes the entry for a component.
+// It returns false if the component was not registered.
+// Remove is safe for concurrent use.
+func (m *Matrix) Remove(component string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.entries[component]; !ok {
+		return false
+	}
+	delete(m.entries, component)
+	return true
+}
+
+// Get returns the entry for a component.
+// Get is safe for concurrent use.
+func (m *Matrix) Get(component string) (Compatibility, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	entries, ok := m.entries[component]
-	if !ok {
-		return true, "" // unknown component, assume compatible
+	comp, ok := m.entries[component]
+	return comp, ok
+}
+
+// Components returns the names of all registered components, sorted.
+// Components is safe for concurrent use.
+func (m *Matrix) Components() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.componentsLocked()
+}
+
+// componentsLocked returns the sorted component names; the lock must be held.
+func (m *Matrix) componentsLocked() []string {
+	components := make([]string, 0, len(m.entries))
+	for component := range m.entries {
+		components = append(components, component)
 	}
+	sort.Strings(components)
+	return components
+}
 
-	for _, entry := range entries {
-		if v.Compare(entry.MinVersion) < 0 {
-			return false, fmt.Sprintf("version %s is below minimum %s", v, entry.MinVersion)
-		}
-		if entry.MaxVersion != nil && v.Compare(*entry.MaxVersion) > 0 {
-			return false, fmt.Sprintf("version %s exceeds maximum %s", v, entry.MaxVersion)
-		}
-		if entry.Deprecated {
-			return true, entry.Message // compatible but deprecated
-		}
+// MarshalJSON encodes the matrix as a JSON array of compatibility entries,
+// ordered by component name.
+// MarshalJSON is safe for concurrent use.
+func (m *Matrix) MarshalJSON() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entries := []Compatibility{}
+	for _, component := range m.componentsLocked() {
+		entries = append(entries, m.entries[component])
 	}
+	return json.Marshal(entries)
+}
 
-	return true, ""
+// UnmarshalJSON decodes a JSON array of compatibility entries, replacing
+// the matrix contents and rebuilding the per-component index. As with Add,
+// a later entry for a component replaces an earlier one.
+// UnmarshalJSON is safe for concurrent use.
+func (m *Matrix) UnmarshalJSON(data []byte) error {
+	var list []Compatibility
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+
+	entries := make(map[string]Compatibility, len(list))
+	for _, comp := range list {
+		entries[comp.Component] = comp
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = entries
+	return nil
+}
+
+// Check checks if a version is compatible for a component.
+// Check is safe for concurrent use.
+func (m *Matrix) Check(component string, v Version) (bool, string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.checkLocked(component, v)
 }
 
 // Negotiate finds the best compatible version from a list.
+// When none is compatible, the error lists why each version was rejected.
 // Negotiate is safe for concurrent use.
 func (m *Matrix) Negotiate(component string, available []Version) (Version, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	var best *Version
-
+	var compatible []Version
+	var rejected []string
 	for _, v := range available {
-		compatible, _ := m.checkLocked(component, v)
-		if compatible {
-			if best == nil || v.GreaterThan(*best) {
-				vCopy := v
-				best = &vCopy
-			}
+		if ok, reason := m.checkLocked(component, v); ok {
+			compatible = append(compatible, v)
+		} else {
+			rejected = append(rejected, reason)
 		}
 	}
 
-	if best == nil {
-		return Version{}, fmt.Errorf("no compatible version found for %s", component)
+	best, ok := Max(compatible)
+	if !ok {
+		if len(rejected) == 0 {
+			return Version{}, fmt.Errorf("no compatible version found for %s: no versions available", component)
+		}
+		return Version{}, fmt.Errorf("no compatible version found for %s: %s", component, strings.Join(rejected, "; "))
 	}
 
-	return *best, nil
+	return best, nil
 }
 
 // checkLocked is the internal check implementation that assumes the lock is already held.
 func (m *Matrix) checkLocked(component string, v Version) (bool, string) {
-	entries, ok := m.entries[component]
+	entry, ok := m.entries[component]
 	if !ok {
 		return true, "" // unknown component, assume compatible
 	}
 
-	for _, entry := range entries {
-		if v.Compare(entry.MinVersion) < 0 {
-			return false, fmt.Sprintf("version %s is below minimum %s", v, entry.MinVersion)
-		}
-		if entry.MaxVersion != nil && v.Compare(*entry.MaxVersion) > 0 {
-			return false, fmt.Sprintf("version %s exceeds maximum %s", v, entry.MaxVersion)
-		}
-		if entry.Deprecated {
-			return true, entry.Message // compatible but deprecated
+	if len(entry.Constraint) > 0 {
+		if ok, reason := entry.Constraint.Explain(v); !ok {
+			return false, reason
 		}
+	} else if v.Compare(entry.MinVersion) < 0 {
+		return false, fmt.Sprintf("version %s is below minimum %s", v, entry.MinVersion)
+	} else if entry.MaxVersion != nil && v.Compare(*entry.MaxVersion) > 0 {
+		return false, fmt.Sprintf("version %s exceeds maximum %s", v, entry.MaxVersion)
+	}
+	if entry.Deprecated {
+		return true, entry.Message // compatible but deprecated
 	}
 
 	return true, ""
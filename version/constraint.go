@@ -1,13 +1,21 @@
 package version
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
 	"strings"
 )
 
-// Constraint represents a version constraint (e.g., ">=1.0.0", "^2.0.0").
+// Constraint represents a version constraint (e.g., ">=1.0.0", "^2.0.0", "1.2.x").
 type Constraint struct {
-	Op      string // "", "=", ">", ">=", "<", "<=", "^", "~"
+	Op      string // "", "=", ">", ">=", "<", "<=", "^", "~", "*"
 	Version Version
+
+	// upper is the exclusive upper bound of a wildcard constraint; the zero
+	// Version means unbounded. raw is the wildcard as written.
+	upper Version
+	raw   string
 }
 
 // ParseConstraint parses a version constraint string.
@@ -44,7 +52,12 @@ func ParseConstraint(s string) (Constraint, error) {
 		versionStr = s
 	}
 
-	v, err := Parse(strings.TrimSpace(versionStr))
+	versionStr = strings.TrimSpace(versionStr)
+	if op == "=" && isWildcard(versionStr) {
+		return parseWildcard(versionStr)
+	}
+
+	v, err := Parse(versionStr)
 	if err != nil {
 		return Constraint{}, err
 	}
@@ -52,8 +65,41 @@ func ParseConstraint(s string) (Constraint, error) {
 	return Constraint{Op: op, Version: v}, nil
 }
 
+// CheckOptions tunes how constraints match versions.
+type CheckOptions struct {
+	// AllowPrereleases lets pre-release versions satisfy constraints
+	// purely by precedence, e.g. ">=1.0.0" matches "2.0.0-alpha.1".
+	AllowPrereleases bool
+}
+
 // Check returns true if the given version satisfies the constraint.
+//
+// A pre-release version only satisfies the constraint if the constraint
+// names a pre-release of the same major.minor.patch, so ">=1.0.0" does not
+// match "2.0.0-alpha.1" but ">=2.0.0-alpha" does. Use CheckWithOptions with
+// AllowPrereleases to match pre-releases by precedence alone.
 func (c Constraint) Check(v Version) bool {
+	return c.CheckWithOptions(v, CheckOptions{})
+}
+
+// CheckWithOptions is like Check but applies the given options.
+func (c Constraint) CheckWithOptions(v Version, opts CheckOptions) bool {
+	return c.matches(v) && (opts.AllowPrereleases || c.admitsPrerelease(v))
+}
+
+// admitsPrerelease reports whether v may be matched at all: release versions
+// always may, pre-releases only when c names a pre-release of the same
+// major.minor.patch.
+func (c Constraint) admitsPrerelease(v Version) bool {
+	if v.Prerelease == "" {
+		return true
+	}
+	return c.Version.Prerelease != "" &&
+		c.Version.Major == v.Major && c.Version.Minor == v.Minor && c.Version.Patch == v.Patch
+}
+
+// matches compares v against the constraint by precedence alone.
+func (c Constraint) matches(v Version) bool {
 	switch c.Op {
 	case "", "=":
 		return v.Equal(c.Version)
@@ -72,15 +118,343 @@ func (c Constraint) Check(v Version) bool {
 		// Tilde: same major.minor, >= version
 		return v.Major == c.Version.Major && v.Minor == c.Version.Minor &&
 			(v.GreaterThan(c.Version) || v.Equal(c.Version))
+	case "*":
+		// Wildcard: any version in the expanded range
+		return c.expand().CheckWithOptions(v, CheckOptions{AllowPrereleases: true})
 	default:
 		return false
 	}
 }
 
+// Explain reports whether v satisfies the constraint, as Check does, along
+// with a human-readable reason, e.g.
+// "v2.1.0 does not satisfy ^v1.0.0: major version differs".
+func (c Constraint) Explain(v Version) (bool, string) {
+	if reason := c.mismatch(v); reason != "" {
+		return false, fmt.Sprintf("%s does not satisfy %s: %s", v, c, reason)
+	}
+	if !c.admitsPrerelease(v) {
+		return false, fmt.Sprintf("%s does not satisfy %s: %s", v, c, prereleaseReason(v))
+	}
+	return true, fmt.Sprintf("%s satisfies %s", v, c)
+}
+
+// mismatch returns why v fails to match the constraint by precedence, or ""
+// if it matches.
+func (c Constraint) mismatch(v Version) string {
+	if c.matches(v) {
+		return ""
+	}
+	switch c.Op {
+	case "", "=":
+		return "version differs"
+	case ">", ">=":
+		return "version is too low"
+	case "<", "<=":
+		return "version is too high"
+	case "^":
+		if v.Major != c.Version.Major {
+			return "major version differs"
+		}
+		return "version is too low"
+	case "~":
+		if v.Major != c.Version.Major {
+			return "major version differs"
+		}
+		if v.Minor != c.Version.Minor {
+			return "minor version differs"
+		}
+		return "version is too low"
+	case "*":
+		if v.LessThan(c.Version) {
+			return "version is below the wildcard range"
+		}
+		return "version is above the wildcard range"
+	default:
+		return fmt.Sprintf("unknown operator %q", c.Op)
+	}
+}
+
+// prereleaseReason explains why a pre-release version was not admitted.
+func prereleaseReason(v Version) string {
+	release := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	return fmt.Sprintf("pre-releases only match constraints naming a pre-release of %s", release)
+}
+
+// LatestMatch returns the highest available version that satisfies the
+// constraint, or false if none does.
+func (c Constraint) LatestMatch(available []Version) (Version, bool) {
+	return latestMatch(c.Check, available)
+}
+
 // String returns the constraint as a string.
 func (c Constraint) String() string {
+	if c.Op == "*" {
+		if c.raw != "" {
+			return c.raw
+		}
+		return "*"
+	}
 	if c.Op == "" || c.Op == "=" {
 		return c.Version.String()
 	}
 	return c.Op + c.Version.String()
 }
+
+// isWildcard reports whether a major, minor, or patch component of s is a
+// wildcard. Pre-release and build identifiers, as in "1.0.0-x", are not
+// considered.
+func isWildcard(s string) bool {
+	core := strings.TrimPrefix(s, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	for _, p := range strings.Split(core, ".") {
+		if p == "x" || p == "X" || p == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseWildcard parses a wildcard version such as "*", "1.x", "1.*", or
+// "1.2.x". Components after the first wildcard must also be wildcards.
+func parseWildcard(s string) (Constraint, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(parts) > 3 {
+		return Constraint{}, fmt.Errorf("invalid wildcard version: %s", s)
+	}
+
+	var nums []int
+	for i, p := range parts {
+		if p == "x" || p == "X" || p == "*" {
+			for _, rest := range parts[i+1:] {
+				if rest != "x" && rest != "X" && rest != "*" {
+					return Constraint{}, fmt.Errorf("invalid wildcard version: %s", s)
+				}
+			}
+			break
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || i == 2 {
+			return Constraint{}, fmt.Errorf("invalid wildcard version: %s", s)
+		}
+		nums = append(nums, n)
+	}
+
+	c := Constraint{Op: "*", raw: s}
+	switch len(nums) {
+	case 1:
+		c.Version = Version{Major: nums[0]}
+		c.upper = Version{Major: nums[0] + 1}
+	case 2:
+		c.Version = Version{Major: nums[0], Minor: nums[1]}
+		c.upper = Version{Major: nums[0], Minor: nums[1] + 1}
+	}
+	return c, nil
+}
+
+// expand returns the range a wildcard constraint stands for: ">=lower, <upper",
+// or an empty set (matching everything) for a bare "*".
+func (c Constraint) expand() ConstraintSet {
+	if c.upper == (Version{}) {
+		return ConstraintSet{}
+	}
+	return ConstraintSet{
+		{Op: ">=", Version: c.Version},
+		{Op: "<", Version: c.upper},
+	}
+}
+
+// ConstraintSet is a list of constraints that must all be satisfied
+// (e.g., ">=1.2.0, <2.0.0").
+type ConstraintSet []Constraint
+
+// ParseConstraintSet parses a comma-separated list of version constraints.
+// Terms may also be separated by whitespace (e.g., ">=2.1.0 <3.0.0"), and an
+// operator may be separated from its version (e.g., ">= 2.1.0").
+// Each term is parsed with ParseConstraint.
+func ParseConstraintSet(s string) (ConstraintSet, error) {
+	terms := splitConstraintTerms(s)
+	set := make(ConstraintSet, 0, len(terms))
+	for _, term := range terms {
+		c, err := ParseConstraint(term)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, c)
+	}
+	return set, nil
+}
+
+// Check returns true if the given version satisfies every constraint in the set.
+// A pre-release version additionally requires at least one constraint to
+// name a pre-release of the same major.minor.patch (see Constraint.Check).
+func (cs ConstraintSet) Check(v Version) bool {
+	return cs.CheckWithOptions(v, CheckOptions{})
+}
+
+// CheckWithOptions is like Check but applies the given options.
+func (cs ConstraintSet) CheckWithOptions(v Version, opts CheckOptions) bool {
+	admitted := opts.AllowPrereleases || v.Prerelease == ""
+	for _, c := range cs {
+		if !c.matches(v) {
+			return false
+		}
+		admitted = admitted || c.admitsPrerelease(v)
+	}
+	return admitted
+}
+
+// Explain reports whether v satisfies every constraint in the set, as Check
+// does, along with a human-readable reason naming the first constraint that
+// fails.
+func (cs ConstraintSet) Explain(v Version) (bool, string) {
+	admitted := v.Prerelease == ""
+	for _, c := range cs {
+		if reason := c.mismatch(v); reason != "" {
+			return false, fmt.Sprintf("%s does not satisfy %s: %s", v, c, reason)
+		}
+		admitted = admitted || c.admitsPrerelease(v)
+	}
+	if !admitted {
+		return false, fmt.Sprintf("%s does not satisfy %s: %s", v, cs, prereleaseReason(v))
+	}
+	return true, fmt.Sprintf("%s satisfies %s", v, cs)
+}
+
+// LatestMatch returns the highest available version that satisfies every
+// constraint in the set, or false if none does.
+func (cs ConstraintSet) LatestMatch(available []Version) (Version, bool) {
+	return latestMatch(cs.Check, available)
+}
+
+// latestMatch returns the maximum of the versions accepted by check.
+func latestMatch(check func(Version) bool, available []Version) (Version, bool) {
+	var matches []Version
+	for _, v := range available {
+		if check(v) {
+			matches = append(matches, v)
+		}
+	}
+	return Max(matches)
+}
+
+// String returns the constraint set as a comma-separated string.
+func (cs ConstraintSet) String() string {
+	parts := make([]string, len(cs))
+	for i, c := range cs {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// splitConstraintTerms splits a constraint set on commas and whitespace,
+// rejoining a bare operator with the version that follows it. Empty
+// comma-separated terms are kept so that ParseConstraint rejects them.
+func splitConstraintTerms(s string) []string {
+	var terms []string
+	for _, part := range strings.Split(s, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			terms = append(terms, "")
+			continue
+		}
+		for i := 0; i < len(fields); i++ {
+			term := fields[i]
+			if strings.Trim(term, "=<>^~") == "" && i+1 < len(fields) {
+				i++
+				term += fields[i]
+			}
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// ConstraintExpr is a disjunction of constraint sets
+// (e.g., "^1.2.0 || ^2.0.0"). A version matches if any set matches.
+type ConstraintExpr []ConstraintSet
+
+// ParseConstraintExpr parses a version constraint expression whose
+// top-level groups are separated by "||". Each group is parsed with
+// ParseConstraintSet.
+func ParseConstraintExpr(s string) (ConstraintExpr, error) {
+	groups := strings.Split(s, "||")
+	expr := make(ConstraintExpr, 0, len(groups))
+	for _, group := range groups {
+		cs, err := ParseConstraintSet(group)
+		if err != nil {
+			return nil, err
+		}
+		expr = append(expr, cs)
+	}
+	return expr, nil
+}
+
+// Check returns true if the given version satisfies any group in the expression.
+func (e ConstraintExpr) Check(v Version) bool {
+	return e.CheckWithOptions(v, CheckOptions{})
+}
+
+// CheckWithOptions is like Check but applies the given options.
+func (e ConstraintExpr) CheckWithOptions(v Version, opts CheckOptions) bool {
+	for _, cs := range e {
+		if cs.CheckWithOptions(v, opts) {
+			return true
+		}
+	}
+	return false
+}
+
+// Explain reports whether v satisfies any group in the expression, as Check
+// does. On a match the reason names the matching group; otherwise it lists
+// why each group failed, separated by "; ".
+func (e ConstraintExpr) Explain(v Version) (bool, string) {
+	if len(e) == 0 {
+		return false, fmt.Sprintf("%s does not satisfy an empty expression", v)
+	}
+	reasons := make([]string, 0, len(e))
+	for _, cs := range e {
+		ok, reason := cs.Explain(v)
+		if ok {
+			return true, reason
+		}
+		reasons = append(reasons, reason)
+	}
+	return false, strings.Join(reasons, "; ")
+}
+
+// String returns the expression with groups joined by " || ".
+func (e ConstraintExpr) String() string {
+	parts := make([]string, len(e))
+	for i, cs := range e {
+		parts[i] = cs.String()
+	}
+	return strings.Join(parts, " || ")
+}
+
+// MarshalJSON encodes the expression as its String form
+// (e.g., "^v1.2.0 || ^v2.0.0").
+func (e ConstraintExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON decodes an expression string using ParseConstraintExpr.
+// An empty string decodes to a nil expression, as if the field were absent.
+func (e *ConstraintExpr) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if strings.TrimSpace(s) == "" {
+		*e = nil
+		return nil
+	}
+	parsed, err := ParseConstraintExpr(s)
+	if err != nil {
+		return err
+	}
+	*e = parsed
+	return nil
+}
@@ -37,6 +37,28 @@
 //   - "^" - compatible (same major)
 //   - "~" - approximately (same major.minor)
 //
+// Pre-release versions are excluded unless the constraint names a
+// pre-release of the same major.minor.patch: ">=1.0.0" does not match
+// "2.0.0-alpha.1", but ">=2.0.0-alpha" does. Pass CheckOptions with
+// AllowPrereleases to CheckWithOptions to match them by precedence alone:
+//
+//	c.CheckWithOptions(v, version.CheckOptions{AllowPrereleases: true})
+//
+// Wildcards match a range: "1.2.x" means ">=1.2.0, <1.3.0", "1.*" or "1.x"
+// means ">=1.0.0, <2.0.0", and a bare "*" matches every version.
+//
+// Combine constraints with commas; a version must satisfy all of them:
+//
+//	cs, _ := version.ParseConstraintSet(">=1.2.0, <2.0.0")
+//	cs.Check(version.MustParse("1.9.0")) // true
+//	cs.Check(version.MustParse("2.0.0")) // false
+//
+// Separate alternatives with "||"; a version must satisfy any group:
+//
+//	e, _ := version.ParseConstraintExpr("^1.0.0 || >=2.1.0 <3.0.0")
+//	e.Check(version.MustParse("2.0.0")) // false
+//	e.Check(version.MustParse("2.5.0")) // true
+//
 // # Compatibility Matrix
 //
 // Track version compatibility across components:
@@ -49,6 +71,18 @@
 //
 //	ok, msg := matrix.Check("toolfoundation", version.MustParse("0.2.0"))
 //
+// An entry may instead carry a Constraint expression, which replaces the
+// min/max bounds for that component:
+//
+//	expr, _ := version.ParseConstraintExpr("^1.2.0 || ^2.0.0")
+//	matrix.Add(version.Compatibility{Component: "toolindex", Constraint: expr})
+//
+// Versions marshal to JSON as strings ("v1.2.3") and a Matrix marshals as an
+// array of Compatibility entries, so a matrix can be loaded from a config file:
+//
+//	var matrix version.Matrix
+//	err := json.Unmarshal([]byte(`[{"component": "toolfoundation", "minVersion": "v0.1.0"}]`), &matrix)
+//
 // # Version Negotiation
 //
 // Find the best compatible version from available options:
@@ -59,4 +93,14 @@
 //	    version.MustParse("2.0.0"),
 //	}
 //	best, err := matrix.Negotiate("component", available)
+//
+// Or pick the highest version satisfying a constraint directly:
+//
+//	cs, _ := version.ParseConstraintSet(">=1.0.0, <2.0.0")
+//	latest, ok := cs.LatestMatch(available) // v1.1.0, true
+//
+// LatestPrerelease picks the newest pre-release of one version, such as the
+// latest release candidate of v1.2.0:
+//
+//	rc, ok := version.LatestPrerelease(version.MustParse("1.2.0"), builds)
 package version
@@ -109,6 +109,19 @@ func ExampleConstraint_Check() {
 	// ~1.2.0 accepts 1.3.0: false
 }
 
+func ExampleParseConstraintSet() {
+	// Compatible with 1.2.0 but below the next breaking release
+	cs, _ := version.ParseConstraintSet(">=1.2.0, <2.0.0")
+
+	fmt.Println(cs)
+	fmt.Println("accepts 1.9.0:", cs.Check(version.MustParse("1.9.0")))
+	fmt.Println("accepts 2.0.0:", cs.Check(version.MustParse("2.0.0")))
+	// Output:
+	// >=v1.2.0, <v2.0.0
+	// accepts 1.9.0: true
+	// accepts 2.0.0: false
+}
+
 func ExampleMatrix() {
 	matrix := version.NewMatrix()
 
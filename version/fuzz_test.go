@@ -70,6 +70,9 @@ func FuzzParseConstraint(f *testing.F) {
 	f.Add("^1.0.0")
 	f.Add("~1.0.0")
 	f.Add(">=1.0.0-alpha")
+	f.Add("1.2.x")
+	f.Add("1.*")
+	f.Add("*")
 
 	// Invalid constraints
 	f.Add("")
@@ -1,8 +1,10 @@
 package version
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -18,6 +20,8 @@ type Version struct {
 
 var semverRegex = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z-.]+))?(?:\+([0-9A-Za-z-.]+))?$`)
 
+var coerceRegex = regexp.MustCompile(`^v?(\d+)(?:\.(\d+)(?:\.(\d+))?)?(?:-([0-9A-Za-z-.]+))?(?:\+([0-9A-Za-z-.]+))?$`)
+
 // Parse parses a semantic version string.
 func Parse(s string) (Version, error) {
 	matches := semverRegex.FindStringSubmatch(s)
@@ -38,6 +42,28 @@ func Parse(s string) (Version, error) {
 	}, nil
 }
 
+// Coerce parses a possibly partial version string, padding a missing minor
+// or patch component with zeros: "1" becomes 1.0.0 and "1.2-beta" becomes
+// 1.2.0-beta. Full versions parse exactly as with Parse.
+func Coerce(s string) (Version, error) {
+	matches := coerceRegex.FindStringSubmatch(s)
+	if matches == nil {
+		return Version{}, fmt.Errorf("invalid version: %s", s)
+	}
+
+	major, _ := strconv.Atoi(matches[1])
+	minor, _ := strconv.Atoi(matches[2])
+	patch, _ := strconv.Atoi(matches[3])
+
+	return Version{
+		Major:      major,
+		Minor:      minor,
+		Patch:      patch,
+		Prerelease: matches[4],
+		Build:      matches[5],
+	}, nil
+}
+
 // MustParse parses a version string and panics on error.
 func MustParse(s string) Version {
 	v, err := Parse(s)
@@ -49,7 +75,13 @@ func MustParse(s string) Version {
 
 // String returns the version as a string (with v prefix).
 func (v Version) String() string {
-	s := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	return "v" + v.StringNoPrefix()
+}
+
+// StringNoPrefix returns the version without the v prefix
+// (e.g., "1.2.3-beta+build"), as used by Docker tags and similar.
+func (v Version) StringNoPrefix() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
 	if v.Prerelease != "" {
 		s += "-" + v.Prerelease
 	}
@@ -59,6 +91,54 @@ func (v Version) String() string {
 	return s
 }
 
+// BumpMajor returns the next major version (e.g., v1.2.3 -> v2.0.0),
+// with pre-release and build metadata cleared.
+func (v Version) BumpMajor() Version {
+	return Version{Major: v.Major + 1}
+}
+
+// BumpMinor returns the next minor version (e.g., v1.2.3 -> v1.3.0),
+// with pre-release and build metadata cleared.
+func (v Version) BumpMinor() Version {
+	return Version{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// BumpPatch returns the next patch version (e.g., v1.2.3 -> v1.2.4),
+// with pre-release and build metadata cleared.
+func (v Version) BumpPatch() Version {
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+}
+
+// MarshalJSON encodes the version as its String form (e.g., "v1.2.3").
+func (v Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON decodes a version string using Parse.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// Satisfies reports whether v satisfies constraint, which may be a single
+// constraint, a set, or an expression (e.g., ">=1.0.0, <2.0.0" or
+// "^1.0.0 || ^2.0.0"). It returns an error if constraint cannot be parsed.
+func (v Version) Satisfies(constraint string) (bool, error) {
+	expr, err := ParseConstraintExpr(constraint)
+	if err != nil {
+		return false, err
+	}
+	return expr.Check(v), nil
+}
+
 // Compare returns -1, 0, or 1 if v < other, v == other, or v > other.
 func (v Version) Compare(other Version) int {
 	if v.Major != other.Major {
@@ -96,6 +176,78 @@ func (v Version) Compatible(other Version) bool {
 	return v.Compare(other) >= 0
 }
 
+// IsPrerelease reports whether v has a pre-release label, such as "rc.1".
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// IsInitialDevelopment reports whether v is a 0.y.z version, for which
+// SemVer promises no API stability.
+func (v Version) IsInitialDevelopment() bool {
+	return v.Major == 0
+}
+
+// IsStable reports whether v is a release with a stable API: not a
+// pre-release and at least 1.0.0.
+func (v Version) IsStable() bool {
+	return !v.IsPrerelease() && !v.IsInitialDevelopment()
+}
+
+// Sort sorts versions in ascending order. Versions that compare equal keep
+// their original relative order.
+func Sort(vs []Version) {
+	slices.SortStableFunc(vs, Version.Compare)
+}
+
+// SortDescending sorts versions in descending order. Versions that compare
+// equal keep their original relative order.
+func SortDescending(vs []Version) {
+	slices.SortStableFunc(vs, func(a, b Version) int {
+		return b.Compare(a)
+	})
+}
+
+// Max returns the greatest version, or false if vs is empty.
+// If several versions compare equal, the first is returned.
+func Max(vs []Version) (Version, bool) {
+	if len(vs) == 0 {
+		return Version{}, false
+	}
+	best := vs[0]
+	for _, v := range vs[1:] {
+		if v.GreaterThan(best) {
+			best = v
+		}
+	}
+	return best, true
+}
+
+// Min returns the least version, or false if vs is empty.
+// If several versions compare equal, the first is returned.
+func Min(vs []Version) (Version, bool) {
+	if len(vs) == 0 {
+		return Version{}, false
+	}
+	least := vs[0]
+	for _, v := range vs[1:] {
+		if v.LessThan(least) {
+			least = v
+		}
+	}
+	return least, true
+}
+
+// LatestPrerelease returns the highest pre-release in available that shares
+// base's major.minor.patch, e.g. v1.2.0-rc.2 for base v1.2.0, or false if
+// there is none. base's own pre-release and build metadata are ignored;
+// releases are never returned.
+func LatestPrerelease(base Version, available []Version) (Version, bool) {
+	return latestMatch(func(v Version) bool {
+		return v.Prerelease != "" &&
+			v.Major == base.Major && v.Minor == base.Minor && v.Patch == base.Patch
+	}, available)
+}
+
 func compareInt(a, b int) int {
 	if a < b {
 		return -1
@@ -1,6 +1,9 @@
 package version
 
 import (
+	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -36,6 +39,37 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Version
+		wantErr bool
+	}{
+		{"1", Version{1, 0, 0, "", ""}, false},
+		{"1.2", Version{1, 2, 0, "", ""}, false},
+		{"v3", Version{3, 0, 0, "", ""}, false},
+		{"2.1-beta", Version{2, 1, 0, "beta", ""}, false},
+		{"1.2.3-rc.1+build", Version{1, 2, 3, "rc.1", "build"}, false},
+		{"", Version{}, true},
+		{"1.", Version{}, true},
+		{"1.2.3.4", Version{}, true},
+		{"latest", Version{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Coerce(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Coerce(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Coerce(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMustParse(t *testing.T) {
 	// Test valid parse
 	v := MustParse("1.2.3")
@@ -73,6 +107,33 @@ func TestVersion_String(t *testing.T) {
 	}
 }
 
+func TestVersion_StringNoPrefix(t *testing.T) {
+	tests := []struct {
+		v          Version
+		want       string
+		wantPrefix string
+	}{
+		{Version{1, 2, 3, "", ""}, "1.2.3", "v1.2.3"},
+		{Version{1, 0, 0, "rc.1", ""}, "1.0.0-rc.1", "v1.0.0-rc.1"},
+		{Version{1, 0, 0, "", "sha.abc"}, "1.0.0+sha.abc", "v1.0.0+sha.abc"},
+		{Version{2, 1, 0, "beta", "123"}, "2.1.0-beta+123", "v2.1.0-beta+123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.v.StringNoPrefix(); got != tt.want {
+				t.Errorf("StringNoPrefix() = %q, want %q", got, tt.want)
+			}
+			if got := tt.v.String(); got != tt.wantPrefix {
+				t.Errorf("String() = %q, want %q", got, tt.wantPrefix)
+			}
+			if parsed := MustParse(tt.v.StringNoPrefix()); parsed != tt.v {
+				t.Errorf("Parse(StringNoPrefix()) = %v, want %v", parsed, tt.v)
+			}
+		})
+	}
+}
+
 func TestVersion_Compare(t *testing.T) {
 	tests := []struct {
 		a, b string
@@ -150,6 +211,223 @@ func TestVersion_Compatible(t *testing.T) {
 	}
 }
 
+func TestSort(t *testing.T) {
+	vs := []Version{
+		MustParse("1.0.0"),
+		MustParse("0.9.0"),
+		MustParse("1.0.0-beta"),
+		MustParse("2.0.0"),
+		MustParse("1.0.0-alpha"),
+	}
+	want := []string{"v0.9.0", "v1.0.0-alpha", "v1.0.0-beta", "v1.0.0", "v2.0.0"}
+
+	Sort(vs)
+	for i, v := range vs {
+		if v.String() != want[i] {
+			t.Errorf("Sort()[%d] = %s, want %s", i, v, want[i])
+		}
+	}
+
+	SortDescending(vs)
+	for i, v := range vs {
+		if w := want[len(want)-1-i]; v.String() != w {
+			t.Errorf("SortDescending()[%d] = %s, want %s", i, v, w)
+		}
+	}
+}
+
+func TestSort_Stable(t *testing.T) {
+	vs := []Version{MustParse("1.0.0+b"), MustParse("0.1.0"), MustParse("1.0.0+a")}
+	Sort(vs)
+	if vs[1].Build != "b" || vs[2].Build != "a" {
+		t.Errorf("Sort() reordered equal versions: %v", vs)
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	vs := []Version{
+		MustParse("1.0.0-beta"),
+		MustParse("1.0.0"),
+		MustParse("1.0.0-alpha"),
+	}
+
+	if got, ok := Max(vs); !ok || got.String() != "v1.0.0" {
+		t.Errorf("Max() = %s, %v, want v1.0.0, true", got, ok)
+	}
+	if got, ok := Min(vs); !ok || got.String() != "v1.0.0-alpha" {
+		t.Errorf("Min() = %s, %v, want v1.0.0-alpha, true", got, ok)
+	}
+
+	if _, ok := Max(nil); ok {
+		t.Error("Max(nil) should return false")
+	}
+	if _, ok := Min([]Version{}); ok {
+		t.Error("Min(empty) should return false")
+	}
+}
+
+func TestVersion_StabilityPredicates(t *testing.T) {
+	tests := []struct {
+		input          string
+		wantPrerelease bool
+		wantInitial    bool
+		wantStable     bool
+	}{
+		{"0.9.0", false, true, false},
+		{"0.9.0-beta", true, true, false},
+		{"1.0.0", false, false, true},
+		{"1.0.0-rc.1", true, false, false},
+		{"2.3.4+build.7", false, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v := MustParse(tt.input)
+			if got := v.IsPrerelease(); got != tt.wantPrerelease {
+				t.Errorf("IsPrerelease() = %v, want %v", got, tt.wantPrerelease)
+			}
+			if got := v.IsInitialDevelopment(); got != tt.wantInitial {
+				t.Errorf("IsInitialDevelopment() = %v, want %v", got, tt.wantInitial)
+			}
+			if got := v.IsStable(); got != tt.wantStable {
+				t.Errorf("IsStable() = %v, want %v", got, tt.wantStable)
+			}
+		})
+	}
+}
+
+func TestLatestPrerelease(t *testing.T) {
+	available := []Version{
+		MustParse("1.1.0-rc.9"),
+		MustParse("1.2.0-beta.3"),
+		MustParse("1.2.0-rc.1"),
+		MustParse("1.2.0-rc.3"),
+		MustParse("1.2.0-rc.2"),
+		MustParse("1.2.0"),
+		MustParse("1.2.1-alpha"),
+		MustParse("1.3.0-beta.1"),
+	}
+
+	tests := []struct {
+		base   string
+		want   string
+		wantOK bool
+	}{
+		{base: "1.2.0", want: "v1.2.0-rc.3", wantOK: true},
+		{base: "1.2.0-beta.1", want: "v1.2.0-rc.3", wantOK: true},
+		{base: "1.2.1", want: "v1.2.1-alpha", wantOK: true},
+		{base: "1.3.0", want: "v1.3.0-beta.1", wantOK: true},
+		{base: "2.0.0", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.base, func(t *testing.T) {
+			got, ok := LatestPrerelease(MustParse(tt.base), available)
+			if ok != tt.wantOK || (ok && got.String() != tt.want) {
+				t.Errorf("LatestPrerelease(%s) = %s, %v, want %s, %v", tt.base, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	betas := []Version{MustParse("1.2.0-beta.2"), MustParse("1.2.0-beta.4"), MustParse("1.2.0-beta.1")}
+	if got, ok := LatestPrerelease(MustParse("1.2.0"), betas); !ok || got.String() != "v1.2.0-beta.4" {
+		t.Errorf("LatestPrerelease(betas) = %s, %v, want v1.2.0-beta.4, true", got, ok)
+	}
+}
+
+func TestVersion_Bump(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantMajor string
+		wantMinor string
+		wantPatch string
+	}{
+		{"1.2.3", "v2.0.0", "v1.3.0", "v1.2.4"},
+		{"0.0.0", "v1.0.0", "v0.1.0", "v0.0.1"},
+		{"1.2.3-beta.1+build.5", "v2.0.0", "v1.3.0", "v1.2.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v := MustParse(tt.input)
+			if got := v.BumpMajor().String(); got != tt.wantMajor {
+				t.Errorf("BumpMajor() = %s, want %s", got, tt.wantMajor)
+			}
+			if got := v.BumpMinor().String(); got != tt.wantMinor {
+				t.Errorf("BumpMinor() = %s, want %s", got, tt.wantMinor)
+			}
+			if got := v.BumpPatch().String(); got != tt.wantPatch {
+				t.Errorf("BumpPatch() = %s, want %s", got, tt.wantPatch)
+			}
+		})
+	}
+
+	if got := MustParse("1.2.3").BumpMinor(); got != MustParse("1.3.0") {
+		t.Errorf("v1.2.3.BumpMinor() = %+v, want v1.3.0", got)
+	}
+}
+
+func TestVersion_JSON(t *testing.T) {
+	v := MustParse("1.2.3-beta.1+build.5")
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	if string(data) != `"v1.2.3-beta.1+build.5"` {
+		t.Errorf("Marshal() = %s, want %q", data, "v1.2.3-beta.1+build.5")
+	}
+
+	var got Version
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if got != v {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, v)
+	}
+
+	if err := json.Unmarshal([]byte(`"1.0.0"`), &got); err != nil || got != MustParse("1.0.0") {
+		t.Errorf("Unmarshal(unprefixed) = %+v, %v", got, err)
+	}
+}
+
+func TestVersion_UnmarshalJSON_Invalid(t *testing.T) {
+	for _, input := range []string{`"not-a-version"`, `123`, `{}`} {
+		var v Version
+		if err := json.Unmarshal([]byte(input), &v); err == nil {
+			t.Errorf("Unmarshal(%s) should fail", input)
+		}
+	}
+}
+
+func TestVersion_Satisfies(t *testing.T) {
+	tests := []struct {
+		version    string
+		constraint string
+		want       bool
+		wantErr    bool
+	}{
+		{"1.5.0", ">=1.0.0", true, false},
+		{"1.5.0", ">=1.0.0, <2.0.0", true, false},
+		{"2.0.0", ">=1.0.0, <2.0.0", false, false},
+		{"2.3.0", "^1.0.0 || ^2.0.0", true, false},
+		{"1.2.7", "1.2.x", true, false},
+		{"1.0.0", "", false, true},
+		{"1.0.0", ">=bad", false, true},
+		{"1.0.0", "^1.0.0 ||", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+" "+tt.constraint, func(t *testing.T) {
+			got, err := MustParse(tt.version).Satisfies(tt.constraint)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Satisfies(%q) error = %v, wantErr %v", tt.constraint, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Satisfies(%q) = %v, want %v", tt.constraint, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestConstraint_Check(t *testing.T) {
 	tests := []struct {
 		constraint string
@@ -216,6 +494,395 @@ func TestParseConstraint_Invalid(t *testing.T) {
 	}
 }
 
+func TestConstraint_Check_Wildcard(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"1.2.x", "1.2.0", true},
+		{"1.2.x", "1.2.9", true},
+		{"1.2.x", "1.3.0", false},
+		{"1.2.x", "1.1.9", false},
+		{"1.2.*", "1.2.5", true},
+		{"1.2.X", "1.2.5", true},
+		{"=1.2.x", "1.2.5", true},
+		{"v1.2.x", "1.2.5", true},
+		{"1.x", "1.0.0", true},
+		{"1.x", "1.9.9", true},
+		{"1.x", "2.0.0", false},
+		{"1.x", "0.9.9", false},
+		{"1.*", "1.5.0", true},
+		{"1.x.x", "1.5.0", true},
+		{"1.*.*", "2.0.0", false},
+		{"*", "0.0.0", true},
+		{"*", "999.999.999", true},
+		{"x", "1.2.3", true},
+		{"*.*.*", "1.2.3", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+"_"+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint(%q) error: %v", tt.constraint, err)
+			}
+			v := MustParse(tt.version)
+			if got := c.Check(v); got != tt.want {
+				t.Errorf("Constraint(%q).Check(%s) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraint_String_Wildcard(t *testing.T) {
+	for _, input := range []string{"1.2.x", "1.*", "1.x", "*", "1.2.X"} {
+		t.Run(input, func(t *testing.T) {
+			c, err := ParseConstraint(input)
+			if err != nil {
+				t.Fatalf("ParseConstraint(%q) error: %v", input, err)
+			}
+			if got := c.String(); got != input {
+				t.Errorf("Constraint(%q).String() = %q, want %q", input, got, input)
+			}
+		})
+	}
+
+	if got := (Constraint{Op: "*"}).String(); got != "*" {
+		t.Errorf("Constraint{Op: \"*\"}.String() = %q, want %q", got, "*")
+	}
+}
+
+func TestParseConstraint_ExactWithWildcardLetters(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"1.0.0-next.1", "v1.0.0-next.1"},
+		{"=1.0.0+exp.sha", "v1.0.0+exp.sha"},
+		{"1.0.0-x", "v1.0.0-x"},
+		{"1.2.3-X.1", "v1.2.3-X.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			c, err := ParseConstraint(tt.input)
+			if err != nil {
+				t.Fatalf("ParseConstraint(%q) error: %v", tt.input, err)
+			}
+			if c.Op != "=" || c.Version.String() != tt.want {
+				t.Errorf("ParseConstraint(%q) = %q %q, want = %q", tt.input, c.Op, c.Version.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConstraint_InvalidWildcard(t *testing.T) {
+	for _, input := range []string{"x.1", "1.x.2", "1.2.3.x", "a.x", "^1.x", ">=1.2.x", "1.x-beta"} {
+		if _, err := ParseConstraint(input); err == nil {
+			t.Errorf("ParseConstraint(%q) should fail", input)
+		}
+	}
+}
+
+func TestConstraintSet_Wildcard(t *testing.T) {
+	cs, err := ParseConstraintSet("1.x, >=1.4.0")
+	if err != nil {
+		t.Fatalf("ParseConstraintSet() error: %v", err)
+	}
+	if cs.Check(MustParse("1.3.0")) || !cs.Check(MustParse("1.4.0")) || cs.Check(MustParse("2.0.0")) {
+		t.Errorf("ConstraintSet(%q) checks wrong range", cs)
+	}
+	if got, want := cs.String(), "1.x, >=v1.4.0"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestConstraint_Check_Prerelease(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+		wantAllow  bool
+	}{
+		{">=1.0.0", "2.0.0-alpha.1", false, true},
+		{">=1.0.0", "1.0.0-rc.1", false, false},
+		{"<2.0.0", "2.0.0-alpha.1", false, true},
+		{"^1.0.0", "1.5.0-beta", false, true},
+		{">=2.0.0-alpha", "2.0.0-alpha.1", true, true},
+		{">=2.0.0-alpha", "2.0.0-beta", true, true},
+		{">=2.0.0-alpha", "2.1.0-beta", false, true},
+		{"=1.0.0-beta", "1.0.0-beta", true, true},
+		{"~1.2.0-rc", "1.2.0-rc.2", true, true},
+		{"1.x", "1.5.0-beta", false, true},
+		{"*", "1.0.0-alpha", false, true},
+		{">=1.0.0", "2.0.0", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+"_"+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint(%q) error: %v", tt.constraint, err)
+			}
+			v := MustParse(tt.version)
+			if got := c.Check(v); got != tt.want {
+				t.Errorf("Constraint(%q).Check(%s) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+			}
+			if got := c.CheckWithOptions(v, CheckOptions{AllowPrereleases: true}); got != tt.wantAllow {
+				t.Errorf("Constraint(%q).CheckWithOptions(%s, AllowPrereleases) = %v, want %v", tt.constraint, tt.version, got, tt.wantAllow)
+			}
+		})
+	}
+}
+
+func TestConstraintSet_Check_Prerelease(t *testing.T) {
+	tests := []struct {
+		constraints string
+		version     string
+		want        bool
+	}{
+		{">=1.0.0-beta, <1.0.0", "1.0.0-rc.1", true},
+		{">=1.0.0-beta, <1.0.0", "1.0.0", false},
+		{">=1.0.0, <2.0.0", "1.5.0-beta", false},
+		{">=1.2.0-alpha, <2.0.0", "1.3.0-alpha", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraints+"_"+tt.version, func(t *testing.T) {
+			cs, err := ParseConstraintSet(tt.constraints)
+			if err != nil {
+				t.Fatalf("ParseConstraintSet(%q) error: %v", tt.constraints, err)
+			}
+			v := MustParse(tt.version)
+			if got := cs.Check(v); got != tt.want {
+				t.Errorf("ConstraintSet(%q).Check(%s) = %v, want %v", tt.constraints, tt.version, got, tt.want)
+			}
+		})
+	}
+
+	e, _ := ParseConstraintExpr("^1.0.0 || ^2.0.0")
+	v := MustParse("2.1.0-beta")
+	if e.Check(v) {
+		t.Errorf("ConstraintExpr.Check(%s) = true, want false by default", v)
+	}
+	if !e.CheckWithOptions(v, CheckOptions{AllowPrereleases: true}) {
+		t.Errorf("ConstraintExpr.CheckWithOptions(%s, AllowPrereleases) = false, want true", v)
+	}
+}
+
+func TestConstraintSet_Check(t *testing.T) {
+	tests := []struct {
+		constraints string
+		version     string
+		want        bool
+	}{
+		{">=1.2.0, <2.0.0", "1.2.0", true},
+		{">=1.2.0, <2.0.0", "1.9.9", true},
+		{">=1.2.0, <2.0.0", "1.1.9", false},
+		{">=1.2.0, <2.0.0", "2.0.0", false},
+		{">=1.0.0,<=1.0.0", "1.0.0", true},
+		{"^1.0.0, >1.4.0", "1.4.0", false},
+		{"^1.0.0, >1.4.0", "1.5.0", true},
+		{">=1.0.0", "1.0.0", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraints+"_"+tt.version, func(t *testing.T) {
+			cs, err := ParseConstraintSet(tt.constraints)
+			if err != nil {
+				t.Fatalf("ParseConstraintSet(%q) error: %v", tt.constraints, err)
+			}
+			v := MustParse(tt.version)
+			if got := cs.Check(v); got != tt.want {
+				t.Errorf("ConstraintSet(%q).Check(%s) = %v, want %v", tt.constraints, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintSet_String(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{">=1.2.0, <2.0.0", ">=v1.2.0, <v2.0.0"},
+		{">=1.2.0,<2.0.0", ">=v1.2.0, <v2.0.0"},
+		{">=1.2.0 <2.0.0", ">=v1.2.0, <v2.0.0"},
+		{">= 1.2.0, < 2.0.0", ">=v1.2.0, <v2.0.0"},
+		{"1.0.0", "v1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cs, err := ParseConstraintSet(tt.input)
+			if err != nil {
+				t.Fatalf("ParseConstraintSet(%q) error: %v", tt.input, err)
+			}
+			if got := cs.String(); got != tt.want {
+				t.Errorf("ConstraintSet(%q).String() = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConstraintSet_Invalid(t *testing.T) {
+	for _, input := range []string{"", ">=1.0.0, invalid", ">=1.0.0,", ",<2.0.0", ">=1.0.0 >="} {
+		if _, err := ParseConstraintSet(input); err == nil {
+			t.Errorf("ParseConstraintSet(%q) should fail", input)
+		}
+	}
+}
+
+func TestLatestMatch(t *testing.T) {
+	available := []Version{
+		MustParse("1.0.0"),
+		MustParse("1.4.2"),
+		MustParse("1.5.0-beta"),
+		MustParse("2.0.0"),
+		MustParse("1.3.0"),
+	}
+
+	tests := []struct {
+		constraint string
+		want       string
+		wantOK     bool
+	}{
+		{"^1.0.0", "v1.4.2", true},
+		{"^1.5.0-alpha", "v1.5.0-beta", true},
+		{"~1.3.0", "v1.3.0", true},
+		{"<1.5.0-beta", "v1.4.2", true},
+		{">=1.0.0, <2.0.0", "v1.4.2", true},
+		{">=1.0.0, <1.4.0", "v1.3.0", true},
+		{"^3.0.0", "", false},
+		{">1.0.0, <1.3.0", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			cs, err := ParseConstraintSet(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraintSet(%q) error: %v", tt.constraint, err)
+			}
+			got, ok := cs.LatestMatch(available)
+			if ok != tt.wantOK || (ok && got.String() != tt.want) {
+				t.Errorf("ConstraintSet(%q).LatestMatch() = %s, %v, want %s, %v", tt.constraint, got, ok, tt.want, tt.wantOK)
+			}
+
+			if len(cs) == 1 {
+				got, ok := cs[0].LatestMatch(available)
+				if ok != tt.wantOK || (ok && got.String() != tt.want) {
+					t.Errorf("Constraint(%q).LatestMatch() = %s, %v, want %s, %v", tt.constraint, got, ok, tt.want, tt.wantOK)
+				}
+			}
+		})
+	}
+}
+
+func TestLatestMatch_Empty(t *testing.T) {
+	c, _ := ParseConstraint("*")
+	if _, ok := c.LatestMatch(nil); ok {
+		t.Error("LatestMatch(nil) should return false")
+	}
+}
+
+func TestConstraintExpr_Check(t *testing.T) {
+	const expr = "^1.0.0 || >=2.1.0 <3.0.0"
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"0.9.0", false},
+		{"1.0.0", true},
+		{"1.9.9", true},
+		{"2.0.0", false},
+		{"2.0.9", false},
+		{"2.1.0", true},
+		{"2.9.9", true},
+		{"3.0.0", false},
+	}
+
+	e, err := ParseConstraintExpr(expr)
+	if err != nil {
+		t.Fatalf("ParseConstraintExpr(%q) error: %v", expr, err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := e.Check(MustParse(tt.version)); got != tt.want {
+				t.Errorf("ConstraintExpr(%q).Check(%s) = %v, want %v", expr, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintExpr_String(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"^1.0.0 || >=2.1.0 <3.0.0", "^v1.0.0 || >=v2.1.0, <v3.0.0"},
+		{"^1.2.0||^2.0.0", "^v1.2.0 || ^v2.0.0"},
+		{">=1.0.0, <2.0.0", ">=v1.0.0, <v2.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			e, err := ParseConstraintExpr(tt.input)
+			if err != nil {
+				t.Fatalf("ParseConstraintExpr(%q) error: %v", tt.input, err)
+			}
+			if got := e.String(); got != tt.want {
+				t.Errorf("ConstraintExpr(%q).String() = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		expr    string
+		version string
+		want    bool
+		reason  string
+	}{
+		{"^1.0.0", "2.1.0", false, "v2.1.0 does not satisfy ^v1.0.0: major version differs"},
+		{"^1.2.0", "1.1.0", false, "v1.1.0 does not satisfy ^v1.2.0: version is too low"},
+		{"^1.0.0", "1.4.0", true, "v1.4.0 satisfies ^v1.0.0"},
+		{"~1.2.0", "1.3.0", false, "v1.3.0 does not satisfy ~v1.2.0: minor version differs"},
+		{"1.2.x", "1.3.0", false, "v1.3.0 does not satisfy 1.2.x: version is above the wildcard range"},
+		{">=1.0.0", "2.0.0-alpha", false, "v2.0.0-alpha does not satisfy >=v1.0.0: pre-releases only match constraints naming a pre-release of v2.0.0"},
+		{">=1.0.0, <2.0.0", "2.1.0", false, "v2.1.0 does not satisfy <v2.0.0: version is too high"},
+		{">=1.0.0, <2.0.0", "1.5.0", true, "v1.5.0 satisfies >=v1.0.0, <v2.0.0"},
+		{"^1.0.0 || ^3.0.0", "3.1.0", true, "v3.1.0 satisfies ^v3.0.0"},
+		{"^1.0.0 || ^3.0.0", "2.0.0", false, "v2.0.0 does not satisfy ^v1.0.0: major version differs; v2.0.0 does not satisfy ^v3.0.0: major version differs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr+"/"+tt.version, func(t *testing.T) {
+			e, err := ParseConstraintExpr(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseConstraintExpr(%q) error: %v", tt.expr, err)
+			}
+			v := MustParse(tt.version)
+			got, reason := e.Explain(v)
+			if got != tt.want || reason != tt.reason {
+				t.Errorf("Explain(%s) = (%v, %q), want (%v, %q)", tt.version, got, reason, tt.want, tt.reason)
+			}
+			if got != e.Check(v) {
+				t.Errorf("Explain(%s) = %v disagrees with Check", tt.version, got)
+			}
+		})
+	}
+}
+
+func TestParseConstraintExpr_Invalid(t *testing.T) {
+	for _, input := range []string{"", "^1.0.0 ||", "|| ^2.0.0", "^1.0.0 || invalid"} {
+		if _, err := ParseConstraintExpr(input); err == nil {
+			t.Errorf("ParseConstraintExpr(%q) should fail", input)
+		}
+	}
+}
+
 func TestMatrix_Check(t *testing.T) {
 	m := NewMatrix()
 	m.Add(Compatibility{
@@ -319,7 +986,167 @@ func TestMatrix_Negotiate_NoCompatible(t *testing.T) {
 
 	_, err := m.Negotiate("test", available)
 	if err == nil {
-		t.Error("Negotiate should fail when no compatible version exists")
+		t.Fatal("Negotiate should fail when no compatible version exists")
+	}
+	if want := "version v1.0.0 is below minimum v2.0.0"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Negotiate error = %q, want it to contain %q", err, want)
+	}
+}
+
+func TestMatrix_Constraint(t *testing.T) {
+	m := NewMatrix()
+	m.Add(Compatibility{
+		Component:  "toolindex",
+		Constraint: ConstraintExpr{{{Op: "^", Version: MustParse("1.2.0")}}, {{Op: "^", Version: MustParse("2.0.0")}}},
+	})
+	m.Add(Compatibility{Component: "toolexec", MinVersion: MustParse("1.0.0")})
+
+	tests := []struct {
+		component string
+		version   string
+		want      bool
+	}{
+		{"toolindex", "1.1.0", false},
+		{"toolindex", "1.5.0", true},
+		{"toolindex", "2.3.0", true},
+		{"toolindex", "3.0.0", false},
+		{"toolexec", "0.9.0", false},
+		{"toolexec", "3.0.0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.component+"@"+tt.version, func(t *testing.T) {
+			if got, msg := m.Check(tt.component, MustParse(tt.version)); got != tt.want {
+				t.Errorf("Check(%s, %s) = %v (%s), want %v", tt.component, tt.version, got, msg, tt.want)
+			}
+		})
+	}
+
+	available := []Version{MustParse("1.1.0"), MustParse("1.9.0"), MustParse("2.4.0"), MustParse("3.0.0")}
+	best, err := m.Negotiate("toolindex", available)
+	if err != nil {
+		t.Fatalf("Negotiate() error: %v", err)
+	}
+	if best != MustParse("2.4.0") {
+		t.Errorf("Negotiate() = %s, want v2.4.0", best)
+	}
+	if best, _ := m.Negotiate("toolexec", available); best != MustParse("3.0.0") {
+		t.Errorf("Negotiate(toolexec) = %s, want v3.0.0", best)
+	}
+}
+
+func TestMatrix_Constraint_JSON(t *testing.T) {
+	var m Matrix
+	data := `[{"component": "toolindex", "constraint": "^1.2.0 || ^2.0.0"}]`
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if ok, msg := m.Check("toolindex", MustParse("2.1.0")); !ok {
+		t.Errorf("Check(2.1.0) = false (%s), want true", msg)
+	}
+
+	out, err := json.Marshal(&m)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	if want := `"constraint":"^v1.2.0 || ^v2.0.0"`; !strings.Contains(string(out), want) {
+		t.Errorf("Marshal() = %s, want it to contain %s", out, want)
+	}
+
+	if err := json.Unmarshal([]byte(`[{"component": "x", "constraint": "^bad"}]`), &m); err == nil {
+		t.Error("Unmarshal() should reject an invalid constraint")
+	}
+}
+
+func TestMatrix_Constraint_JSON_Empty(t *testing.T) {
+	var m Matrix
+	data := `[{"component": "toolindex", "minVersion": "1.0.0", "constraint": ""}]`
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if comp, _ := m.Get("toolindex"); comp.Constraint != nil {
+		t.Errorf("Constraint = %v, want nil", comp.Constraint)
+	}
+	if ok, msg := m.Check("toolindex", MustParse("1.5.0")); !ok {
+		t.Errorf("Check(1.5.0) = false (%s), want true", msg)
+	}
+	if ok, _ := m.Check("toolindex", MustParse("0.5.0")); ok {
+		t.Error("Check(0.5.0) = true, want false from minVersion")
+	}
+}
+
+func TestMatrix_JSON_RoundTrip(t *testing.T) {
+	maxVersion := MustParse("1.9.0")
+	m := NewMatrix()
+	m.Add(Compatibility{Component: "toolindex", MinVersion: MustParse("0.2.0")})
+	m.Add(Compatibility{
+		Component:  "toolfoundation",
+		MinVersion: MustParse("1.0.0"),
+		MaxVersion: &maxVersion,
+		Deprecated: true,
+		Message:    "upgrade to v2",
+	})
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+
+	var decoded Matrix
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.entries, m.entries) {
+		t.Errorf("round trip entries = %+v, want %+v", decoded.entries, m.entries)
+	}
+
+	again, err := json.Marshal(&decoded)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("re-marshal = %s, want %s", again, data)
+	}
+
+	ok, msg := decoded.Check("toolfoundation", MustParse("2.0.0"))
+	if ok {
+		t.Errorf("Check() after round trip = true, %q; want rejected above maximum", msg)
+	}
+}
+
+func TestMatrix_UnmarshalJSON_Config(t *testing.T) {
+	config := `[
+		{"component": "toolfoundation", "minVersion": "v0.1.0"},
+		{"component": "toolindex", "minVersion": "0.2.0", "maxVersion": "v0.9.0"}
+	]`
+
+	m := NewMatrix()
+	m.Add(Compatibility{Component: "stale", MinVersion: MustParse("9.0.0")})
+	if err := json.Unmarshal([]byte(config), m); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+
+	if ok, _ := m.Check("toolindex", MustParse("1.0.0")); ok {
+		t.Error("toolindex 1.0.0 should exceed maximum")
+	}
+	if ok, _ := m.Check("toolfoundation", MustParse("0.0.1")); ok {
+		t.Error("toolfoundation 0.0.1 should be below minimum")
+	}
+	if ok, _ := m.Check("stale", MustParse("1.0.0")); !ok {
+		t.Error("Unmarshal should replace existing entries")
+	}
+
+	if err := json.Unmarshal([]byte(`[{"component": "x", "minVersion": "bad"}]`), m); err == nil {
+		t.Error("Unmarshal should fail on invalid version")
+	}
+}
+
+func TestMatrix_MarshalJSON_Empty(t *testing.T) {
+	data, err := json.Marshal(NewMatrix())
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	if string(data) != "[]" {
+		t.Errorf("Marshal(empty) = %s, want []", data)
 	}
 }
 
@@ -372,6 +1199,56 @@ func TestVersion_Compare_AllEqual(t *testing.T) {
 	}
 }
 
+func TestMatrix_Add_Overwrites(t *testing.T) {
+	m := NewMatrix()
+	m.Add(Compatibility{Component: "toolindex", MinVersion: MustParse("1.0.0")})
+	m.Add(Compatibility{Component: "toolindex", MinVersion: MustParse("2.0.0")})
+
+	if got := m.Components(); !reflect.DeepEqual(got, []string{"toolindex"}) {
+		t.Errorf("Components() = %v, want [toolindex]", got)
+	}
+	comp, ok := m.Get("toolindex")
+	if !ok || comp.MinVersion != MustParse("2.0.0") {
+		t.Errorf("Get() = %+v, %v, want MinVersion v2.0.0", comp, ok)
+	}
+	if ok, _ := m.Check("toolindex", MustParse("1.5.0")); ok {
+		t.Error("Check() should use the replacing entry")
+	}
+}
+
+func TestMatrix_Remove(t *testing.T) {
+	m := NewMatrix()
+	m.Add(Compatibility{Component: "toolindex", MinVersion: MustParse("2.0.0")})
+
+	if !m.Remove("toolindex") {
+		t.Error("Remove() = false, want true for registered component")
+	}
+	if m.Remove("toolindex") {
+		t.Error("Remove() = true, want false for removed component")
+	}
+	if _, ok := m.Get("toolindex"); ok {
+		t.Error("Get() should not find removed component")
+	}
+	if ok, _ := m.Check("toolindex", MustParse("1.0.0")); !ok {
+		t.Error("Check() should treat removed component as unknown")
+	}
+}
+
+func TestMatrix_Components(t *testing.T) {
+	m := NewMatrix()
+	if got := m.Components(); len(got) != 0 {
+		t.Errorf("Components() = %v, want empty", got)
+	}
+
+	for _, name := range []string{"toolrun", "toolfoundation", "toolindex"} {
+		m.Add(Compatibility{Component: name, MinVersion: MustParse("0.1.0")})
+	}
+	want := []string{"toolfoundation", "toolindex", "toolrun"}
+	if got := m.Components(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Components() = %v, want %v", got, want)
+	}
+}
+
 func TestMatrix_ConcurrentAccess(t *testing.T) {
 	// Test that Matrix is safe for concurrent access
 	m := NewMatrix()
@@ -391,10 +1268,14 @@ func TestMatrix_ConcurrentAccess(t *testing.T) {
 	for i := 0; i < goroutines; i++ {
 		go func(id int) {
 			for j := 0; j < iterations; j++ {
+				name := "component-" + string(rune('a'+id))
 				m.Add(Compatibility{
-					Component:  "component-" + string(rune('a'+id)),
+					Component:  name,
 					MinVersion: MustParse("1.0.0"),
 				})
+				_, _ = m.Get(name)
+				_ = m.Components()
+				m.Remove(name)
 			}
 			done <- true
 		}(i)